@@ -0,0 +1,235 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Parameter is an input or an output of an endpoint (or of a constructor)
+type Parameter struct {
+	Name string
+	// ValueCreator creates a placeholder (for decoding) of the type of the parameter
+	ValueCreator func() any
+}
+
+// Endpoint describes an endpoint of a contract
+type Endpoint struct {
+	Name    string
+	Inputs  []Parameter
+	Outputs []Parameter
+}
+
+// EventInput is an input of an event: indexed inputs are held by the topics of the event, while the others are held by its data
+type EventInput struct {
+	Name         string
+	ValueCreator func() any
+	Indexed      bool
+}
+
+// Event describes an event emitted by a contract
+type Event struct {
+	Identifier string
+	Inputs     []EventInput
+}
+
+// Definition is the ABI definition of a contract: either defined programmatically, or loaded from an "*.abi.json" file (see LoadAbiDefinition).
+// Types are described by placeholders, as the ones used for decoding.
+type Definition struct {
+	Constructor        *Endpoint
+	UpgradeConstructor *Endpoint
+	Endpoints          []Endpoint
+	// Callbacks are the callbacks of asynchronous calls: their inputs are the closure arguments
+	// (the results of the asynchronous call are decoded according to the outputs of the called endpoint).
+	Callbacks []Endpoint
+	Events    []Event
+}
+
+// GetEndpoint returns the endpoint with the given name
+func (definition *Definition) GetEndpoint(name string) (*Endpoint, error) {
+	for i := range definition.Endpoints {
+		if definition.Endpoints[i].Name == name {
+			return &definition.Endpoints[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("endpoint not found: %s", name)
+}
+
+func (definition *Definition) hasEndpoint(name string) bool {
+	_, err := definition.GetEndpoint(name)
+	return err == nil
+}
+
+// GetCallback returns the callback with the given name
+func (definition *Definition) GetCallback(name string) (*Endpoint, error) {
+	for i := range definition.Callbacks {
+		if definition.Callbacks[i].Name == name {
+			return &definition.Callbacks[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("callback not found: %s", name)
+}
+
+// GetEvent returns the event with the given identifier
+func (definition *Definition) GetEvent(identifier string) (*Event, error) {
+	for i := range definition.Events {
+		if definition.Events[i].Identifier == identifier {
+			return &definition.Events[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("event not found: %s", identifier)
+}
+
+// CreatePlaceholders creates placeholders (for decoding) for the given parameters
+func CreatePlaceholders(parameters []Parameter) []any {
+	placeholders := make([]any, len(parameters))
+
+	for i, parameter := range parameters {
+		placeholders[i] = parameter.ValueCreator()
+	}
+
+	return placeholders
+}
+
+// CheckValuesAgainstParameters checks that the given values match (in number and in type) the given parameters,
+// e.g. before using them as inputs of an endpoint. Trailing optional (and variadic) parameters may be omitted.
+func CheckValuesAgainstParameters(values []any, parameters []Parameter) error {
+	if len(values) > len(parameters) {
+		return fmt.Errorf("too many values: expected at most %d, got %d", len(parameters), len(values))
+	}
+
+	for i, parameter := range parameters {
+		placeholder := parameter.ValueCreator()
+
+		if i >= len(values) {
+			if !isOmittable(placeholder) {
+				return fmt.Errorf("missing value for parameter '%s'", parameter.Name)
+			}
+
+			continue
+		}
+
+		err := checkValueAgainstPlaceholder(fmt.Sprintf("$[%d]", i), values[i], placeholder)
+		if err != nil {
+			return fmt.Errorf("bad value for parameter '%s': %w", parameter.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func isOmittable(placeholder any) bool {
+	switch untypedView(placeholder).(type) {
+	case *OptionalValue, *VariadicValues:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkValueAgainstPlaceholder checks that a value has the same type (and shape) as a placeholder.
+// Values within containers (e.g. items of lists) are checked against placeholders created by the item creators.
+func checkValueAgainstPlaceholder(path string, value any, placeholder any) error {
+	value = untypedView(value)
+	placeholder = untypedView(placeholder)
+
+	if value == nil {
+		return fmt.Errorf("%s: nil value", path)
+	}
+
+	if reflect.TypeOf(value) != reflect.TypeOf(placeholder) {
+		return fmt.Errorf("%s: expected %T, got %T", path, placeholder, value)
+	}
+
+	switch value := value.(type) {
+	case *OptionalValue:
+		placeholder := placeholder.(*OptionalValue)
+		if value.Value == nil || placeholder.Value == nil {
+			return nil
+		}
+
+		return checkValueAgainstPlaceholder(path+".value", value.Value, placeholder.Value)
+	case *MultiValue:
+		return checkItemsAgainstPlaceholders(path, value.Items, placeholder.(*MultiValue).Items)
+	case *VariadicValues:
+		return checkItemsAgainstItemCreator(path, value.Items, placeholder.(*VariadicValues).ItemCreator)
+	case *OptionValue:
+		placeholder := placeholder.(*OptionValue)
+		if value.Value == nil || placeholder.Value == nil {
+			return nil
+		}
+
+		return checkValueAgainstPlaceholder(path+".value", value.Value, placeholder.Value)
+	case *ListValue:
+		itemCreator := placeholder.(*ListValue).ItemCreator
+		if itemCreator == nil {
+			return nil
+		}
+
+		return checkItemsAgainstItemCreator(path, singleValuesAsAny(value.Items), func() any { return itemCreator() })
+	case *StructValue:
+		return checkFieldsAgainstPlaceholders(path, value.Fields, placeholder.(*StructValue).Fields)
+	case *EnumValue:
+		fieldsProvider := placeholder.(*EnumValue).FieldsProvider
+		if fieldsProvider == nil {
+			return nil
+		}
+
+		return checkFieldsAgainstPlaceholders(path, value.Fields, fieldsProvider(value.Discriminant))
+	default:
+		return nil
+	}
+}
+
+func checkItemsAgainstPlaceholders(path string, items []any, placeholders []any) error {
+	if len(items) != len(placeholders) {
+		return fmt.Errorf("%s: expected %d items, got %d", path, len(placeholders), len(items))
+	}
+
+	for i, item := range items {
+		err := checkValueAgainstPlaceholder(fmt.Sprintf("%s[%d]", path, i), item, placeholders[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkItemsAgainstItemCreator(path string, items []any, itemCreator func() any) error {
+	if itemCreator == nil {
+		return nil
+	}
+
+	for i, item := range items {
+		err := checkValueAgainstPlaceholder(fmt.Sprintf("%s[%d]", path, i), item, itemCreator())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkFieldsAgainstPlaceholders(path string, fields []Field, placeholders []Field) error {
+	if len(fields) != len(placeholders) {
+		return fmt.Errorf("%s: expected %d fields, got %d", path, len(placeholders), len(fields))
+	}
+
+	for i, field := range fields {
+		fieldPath := pathOfField(path, i, placeholders[i].Name)
+
+		if field.Name != placeholders[i].Name {
+			return fmt.Errorf("%s: expected field '%s', got '%s'", fieldPath, placeholders[i].Name, field.Name)
+		}
+
+		err := checkValueAgainstPlaceholder(fieldPath, singleValueAsAny(field.Value), singleValueAsAny(placeholders[i].Value))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
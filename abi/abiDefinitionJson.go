@@ -0,0 +1,512 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// abiJson is the content of an "*.abi.json" file, as generated by the Rust framework
+type abiJson struct {
+	Constructor        *abiJsonEndpoint       `json:"constructor"`
+	UpgradeConstructor *abiJsonEndpoint       `json:"upgradeConstructor"`
+	Endpoints          []abiJsonEndpoint      `json:"endpoints"`
+	Events             []abiJsonEvent         `json:"events"`
+	Types              map[string]abiJsonType `json:"types"`
+}
+
+type abiJsonEndpoint struct {
+	Name    string             `json:"name"`
+	Inputs  []abiJsonParameter `json:"inputs"`
+	Outputs []abiJsonParameter `json:"outputs"`
+}
+
+type abiJsonParameter struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+type abiJsonEvent struct {
+	Identifier string             `json:"identifier"`
+	Inputs     []abiJsonParameter `json:"inputs"`
+}
+
+type abiJsonType struct {
+	// Type is the kind of the custom type: "struct" or "enum"
+	Type     string             `json:"type"`
+	Fields   []abiJsonParameter `json:"fields"`
+	Variants []abiJsonVariant   `json:"variants"`
+}
+
+type abiJsonVariant struct {
+	Name         string             `json:"name"`
+	Discriminant uint8              `json:"discriminant"`
+	Fields       []abiJsonParameter `json:"fields"`
+}
+
+// LoadAbiDefinition loads the ABI definition of a contract from an "*.abi.json" file. See ParseAbiDefinition.
+func LoadAbiDefinition(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ABI file, because of: %w", err)
+	}
+
+	return ParseAbiDefinition(data)
+}
+
+// ParseAbiDefinition parses the ABI definition of a contract, given the content of an "*.abi.json" file.
+// The constructors, the endpoints and the events are loaded, and their types are mapped to placeholders (e.g. "BigUint" to BigUIntValue,
+// "List<T>" to ListValue, custom structs and enums to StructValue and EnumValue). Fixed-size arrays (e.g. "array32<u8>", "H256")
+// are mapped to Array[SingleValue].
+// ABI files don't describe the inputs of callbacks (the closure arguments): they have to be added programmatically, if needed.
+func ParseAbiDefinition(data []byte) (*Definition, error) {
+	content := &abiJson{}
+
+	err := json.Unmarshal(data, content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ABI, because of: %w", err)
+	}
+
+	resolver := &abiTypeResolver{
+		types:    content.Types,
+		creators: make(map[string]func() SingleValue),
+	}
+
+	err = resolver.resolveCustomTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &Definition{
+		Endpoints: make([]Endpoint, 0, len(content.Endpoints)),
+		Events:    make([]Event, 0, len(content.Events)),
+	}
+
+	if content.Constructor != nil {
+		definition.Constructor, err = resolver.resolveEndpoint(content.Constructor)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load constructor, because of: %w", err)
+		}
+	}
+
+	if content.UpgradeConstructor != nil {
+		definition.UpgradeConstructor, err = resolver.resolveEndpoint(content.UpgradeConstructor)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load upgrade constructor, because of: %w", err)
+		}
+	}
+
+	for i := range content.Endpoints {
+		endpoint, err := resolver.resolveEndpoint(&content.Endpoints[i])
+		if err != nil {
+			return nil, fmt.Errorf("cannot load endpoint '%s', because of: %w", content.Endpoints[i].Name, err)
+		}
+
+		definition.Endpoints = append(definition.Endpoints, *endpoint)
+	}
+
+	for _, jsonEvent := range content.Events {
+		event, err := resolver.resolveEvent(jsonEvent)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load event '%s', because of: %w", jsonEvent.Identifier, err)
+		}
+
+		definition.Events = append(definition.Events, *event)
+	}
+
+	return definition, nil
+}
+
+// abiTypeResolver maps the types of an ABI file (e.g. "List<MyStruct>") to creators of placeholders.
+// Custom types are looked up when the placeholders are created (not when resolved), thus recursive types are supported.
+type abiTypeResolver struct {
+	types    map[string]abiJsonType
+	creators map[string]func() SingleValue
+}
+
+func (resolver *abiTypeResolver) resolveCustomTypes() error {
+	for name, customType := range resolver.types {
+		var creator func() SingleValue
+		var err error
+
+		switch customType.Type {
+		case "struct":
+			creator, err = resolver.resolveStruct(customType)
+		case "enum":
+			creator, err = resolver.resolveEnum(customType)
+		default:
+			// Unsupported kinds (e.g. "explicit-enum") only cause errors if actually used
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot load type '%s', because of: %w", name, err)
+		}
+
+		resolver.creators[name] = creator
+	}
+
+	return nil
+}
+
+func (resolver *abiTypeResolver) resolveStruct(customType abiJsonType) (func() SingleValue, error) {
+	fieldsCreator, err := resolver.resolveFields(customType.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() SingleValue {
+		return &StructValue{Fields: fieldsCreator()}
+	}, nil
+}
+
+func (resolver *abiTypeResolver) resolveEnum(customType abiJsonType) (func() SingleValue, error) {
+	fieldsCreators := make(map[uint8]func() []Field)
+	names := make(map[uint8]string)
+
+	for _, variant := range customType.Variants {
+		fieldsCreator, err := resolver.resolveFields(variant.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load variant '%s', because of: %w", variant.Name, err)
+		}
+
+		fieldsCreators[variant.Discriminant] = fieldsCreator
+		names[variant.Discriminant] = variant.Name
+	}
+
+	fieldsProvider := func(discriminant uint8) []Field {
+		fieldsCreator, ok := fieldsCreators[discriminant]
+		if !ok {
+			return nil
+		}
+
+		return fieldsCreator()
+	}
+
+	variantNameProvider := func(discriminant uint8) string {
+		return names[discriminant]
+	}
+
+	return func() SingleValue {
+		return &EnumValue{FieldsProvider: fieldsProvider, VariantNameProvider: variantNameProvider}
+	}, nil
+}
+
+func (resolver *abiTypeResolver) resolveFields(jsonFields []abiJsonParameter) (func() []Field, error) {
+	creators := make([]func() SingleValue, len(jsonFields))
+
+	for i, field := range jsonFields {
+		creator, err := resolver.resolveSingleValueType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load field '%s', because of: %w", field.Name, err)
+		}
+
+		creators[i] = creator
+	}
+
+	return func() []Field {
+		if len(jsonFields) == 0 {
+			return nil
+		}
+
+		fields := make([]Field, len(jsonFields))
+
+		for i, field := range jsonFields {
+			fields[i] = Field{Name: field.Name, Value: creators[i]()}
+		}
+
+		return fields
+	}, nil
+}
+
+func (resolver *abiTypeResolver) resolveEndpoint(jsonEndpoint *abiJsonEndpoint) (*Endpoint, error) {
+	inputs, err := resolver.resolveParameters(jsonEndpoint.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := resolver.resolveParameters(jsonEndpoint.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Endpoint{
+		Name:    jsonEndpoint.Name,
+		Inputs:  inputs,
+		Outputs: outputs,
+	}, nil
+}
+
+func (resolver *abiTypeResolver) resolveParameters(jsonParameters []abiJsonParameter) ([]Parameter, error) {
+	parameters := make([]Parameter, len(jsonParameters))
+
+	for i, jsonParameter := range jsonParameters {
+		creator, err := resolver.resolveType(jsonParameter.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load parameter '%s', because of: %w", jsonParameter.Name, err)
+		}
+
+		parameters[i] = Parameter{Name: jsonParameter.Name, ValueCreator: creator}
+	}
+
+	return parameters, nil
+}
+
+func (resolver *abiTypeResolver) resolveEvent(jsonEvent abiJsonEvent) (*Event, error) {
+	inputs := make([]EventInput, len(jsonEvent.Inputs))
+
+	for i, jsonInput := range jsonEvent.Inputs {
+		creator, err := resolver.resolveType(jsonInput.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load input '%s', because of: %w", jsonInput.Name, err)
+		}
+
+		inputs[i] = EventInput{Name: jsonInput.Name, ValueCreator: creator, Indexed: jsonInput.Indexed}
+	}
+
+	return &Event{Identifier: jsonEvent.Identifier, Inputs: inputs}, nil
+}
+
+// resolveType resolves any type: either a single value type, or a multi-value type (e.g. "optional<u8>", "variadic<multi<u8,u16>>")
+func (resolver *abiTypeResolver) resolveType(typeName string) (func() any, error) {
+	name, typeArguments, err := parseAbiTypeName(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "optional":
+		inner, err := resolver.resolveTypeArgument(name, typeArguments)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() any { return &OptionalValue{Value: inner()} }, nil
+	case "variadic":
+		inner, err := resolver.resolveTypeArgument(name, typeArguments)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() any { return &VariadicValues{ItemCreator: inner} }, nil
+	case "multi":
+		if len(typeArguments) == 0 {
+			return nil, fmt.Errorf("missing type arguments: %s", typeName)
+		}
+
+		creators := make([]func() any, len(typeArguments))
+
+		for i, typeArgument := range typeArguments {
+			creators[i], err = resolver.resolveType(typeArgument)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return func() any {
+			items := make([]any, len(creators))
+			for i, creator := range creators {
+				items[i] = creator()
+			}
+
+			return &MultiValue{Items: items}
+		}, nil
+	}
+
+	creator, err := resolver.resolveSingleValueType(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() any { return creator() }, nil
+}
+
+func (resolver *abiTypeResolver) resolveTypeArgument(name string, typeArguments []string) (func() any, error) {
+	if len(typeArguments) != 1 {
+		return nil, fmt.Errorf("%s expects one type argument, got %d", name, len(typeArguments))
+	}
+
+	return resolver.resolveType(typeArguments[0])
+}
+
+// resolveSingleValueType resolves the type of a single value (e.g. a field of a struct, an item of a list)
+func (resolver *abiTypeResolver) resolveSingleValueType(typeName string) (func() SingleValue, error) {
+	name, typeArguments, err := parseAbiTypeName(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(typeArguments) == 0 {
+		return resolver.resolveSimpleType(name)
+	}
+
+	if len(typeArguments) == 1 {
+		inner, err := resolver.resolveSingleValueType(typeArguments[0])
+		if err != nil {
+			return nil, err
+		}
+
+		switch name {
+		case "List", "Vec":
+			return func() SingleValue { return &ListValue{ItemCreator: inner} }, nil
+		case "Option":
+			return func() SingleValue { return &OptionValue{Value: inner()} }, nil
+		}
+
+		if length, ok := parseAbiArrayLength(name); ok {
+			return newArrayCreator(length, inner), nil
+		}
+	}
+
+	if name == "tuple" {
+		creators := make([]func() SingleValue, len(typeArguments))
+
+		for i, typeArgument := range typeArguments {
+			creators[i], err = resolver.resolveSingleValueType(typeArgument)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return func() SingleValue {
+			fields := make([]Field, len(creators))
+			for i, creator := range creators {
+				fields[i] = Field{Name: strconv.Itoa(i), Value: creator()}
+			}
+
+			return &StructValue{Fields: fields}
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type: %s", typeName)
+}
+
+func (resolver *abiTypeResolver) resolveSimpleType(name string) (func() SingleValue, error) {
+	switch name {
+	case "u8":
+		return func() SingleValue { return &U8Value{} }, nil
+	case "u16":
+		return func() SingleValue { return &U16Value{} }, nil
+	case "u32", "usize":
+		return func() SingleValue { return &U32Value{} }, nil
+	case "u64":
+		return func() SingleValue { return &U64Value{} }, nil
+	case "i8":
+		return func() SingleValue { return &I8Value{} }, nil
+	case "i16":
+		return func() SingleValue { return &I16Value{} }, nil
+	case "i32", "isize":
+		return func() SingleValue { return &I32Value{} }, nil
+	case "i64":
+		return func() SingleValue { return &I64Value{} }, nil
+	case "BigUint":
+		return func() SingleValue { return &BigUIntValue{} }, nil
+	case "BigInt":
+		return func() SingleValue { return &BigIntValue{} }, nil
+	case "bool":
+		return func() SingleValue { return &BoolValue{} }, nil
+	case "bytes", "ManagedBuffer", "BoxedBytes":
+		return func() SingleValue { return &BytesValue{} }, nil
+	case "utf-8 string", "TokenIdentifier", "EgldOrEsdtTokenIdentifier":
+		return func() SingleValue { return &StringValue{} }, nil
+	case "Address", "ManagedAddress":
+		return func() SingleValue { return &AddressValue{} }, nil
+	case "H256":
+		return newArrayCreator(32, func() SingleValue { return &U8Value{} }), nil
+	case "CodeMetadata":
+		return func() SingleValue { return &U16Value{} }, nil
+	}
+
+	if customType, ok := resolver.types[name]; ok {
+		if !isSupportedKindOfCustomType(customType.Type) {
+			return nil, fmt.Errorf("unsupported kind of custom type: %s (%s)", customType.Type, name)
+		}
+
+		// Looked up lazily, since custom types might not be resolved yet (or might be recursive)
+		return func() SingleValue { return resolver.creators[name]() }, nil
+	}
+
+	return nil, fmt.Errorf("unknown type: %s", name)
+}
+
+func isSupportedKindOfCustomType(kind string) bool {
+	return kind == "struct" || kind == "enum"
+}
+
+func newArrayCreator(length int, itemCreator func() SingleValue) func() SingleValue {
+	return func() SingleValue {
+		return &Array[SingleValue]{Length: length, ItemCreator: itemCreator}
+	}
+}
+
+// parseAbiTypeName splits a type name into the name and the type arguments, e.g. "multi<u8,List<u16>>" into "multi" and ["u8", "List<u16>"]
+func parseAbiTypeName(typeName string) (string, []string, error) {
+	typeName = strings.TrimSpace(typeName)
+
+	start := strings.IndexByte(typeName, '<')
+	if start < 0 {
+		if typeName == "" || strings.ContainsAny(typeName, ">,") {
+			return "", nil, fmt.Errorf("bad type name: %q", typeName)
+		}
+
+		return typeName, nil, nil
+	}
+
+	if start == 0 || !strings.HasSuffix(typeName, ">") {
+		return "", nil, fmt.Errorf("bad type name: %q", typeName)
+	}
+
+	name := typeName[:start]
+	inner := typeName[start+1 : len(typeName)-1]
+	typeArguments := make([]string, 0)
+	depth := 0
+	argumentStart := 0
+
+	for i, character := range inner {
+		switch character {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return "", nil, fmt.Errorf("bad type name: %q", typeName)
+			}
+		case ',':
+			if depth == 0 {
+				typeArguments = append(typeArguments, strings.TrimSpace(inner[argumentStart:i]))
+				argumentStart = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return "", nil, fmt.Errorf("bad type name: %q", typeName)
+	}
+
+	typeArguments = append(typeArguments, strings.TrimSpace(inner[argumentStart:]))
+
+	for _, typeArgument := range typeArguments {
+		if typeArgument == "" {
+			return "", nil, fmt.Errorf("bad type name: %q", typeName)
+		}
+	}
+
+	return name, typeArguments, nil
+}
+
+// parseAbiArrayLength parses the length of fixed-size arrays, e.g. 32 for "array32"
+func parseAbiArrayLength(name string) (int, bool) {
+	lengthText, ok := strings.CutPrefix(name, "array")
+	if !ok {
+		return 0, false
+	}
+
+	length, err := strconv.Atoi(lengthText)
+	if err != nil || length < 0 {
+		return 0, false
+	}
+
+	return length, true
+}
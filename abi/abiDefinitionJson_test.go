@@ -0,0 +1,153 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAbiDefinition(t *testing.T) {
+	definition, err := LoadAbiDefinition("testdata/example.abi.json")
+	require.NoError(t, err)
+
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should load constructors, endpoints and events", func(t *testing.T) {
+		require.Equal(t, []any{&BigUIntValue{}}, CreatePlaceholders(definition.Constructor.Inputs))
+		require.Empty(t, definition.UpgradeConstructor.Inputs)
+		require.Len(t, definition.Endpoints, 3)
+		require.Empty(t, definition.Callbacks)
+
+		getSum, err := definition.GetEndpoint("getSum")
+		require.NoError(t, err)
+		require.Empty(t, getSum.Inputs)
+		require.Equal(t, []any{&BigUIntValue{}}, CreatePlaceholders(getSum.Outputs))
+
+		event, err := definition.GetEvent("distributed")
+		require.NoError(t, err)
+		require.Equal(t, "token", event.Inputs[0].Name)
+		require.Equal(t, &StringValue{}, event.Inputs[0].ValueCreator())
+		require.True(t, event.Inputs[0].Indexed)
+		require.False(t, event.Inputs[1].Indexed)
+	})
+
+	t.Run("should build and parse call data", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{Abi: definition})
+		require.NoError(t, err)
+
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{Abi: definition})
+		require.NoError(t, err)
+
+		transfer := &StructValue{Fields: []Field{
+			{Name: "to", Value: &AddressValue{Value: make([]byte, 32)}},
+			{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1000)}},
+			{Name: "tags", Value: &StructValue{Fields: []Field{
+				{Name: "0", Value: &U8Value{Value: 7}},
+				{Name: "1", Value: &Array[SingleValue]{Length: 2, Items: []SingleValue{&U16Value{Value: 1}, &U16Value{Value: 2}}}},
+			}}},
+		}}
+
+		data, err := builder.Build("distribute", []any{
+			&ListValue{Items: []SingleValue{transfer}},
+			&OptionalValue{Value: &StringValue{Value: "hello"}},
+		})
+		require.NoError(t, err)
+
+		call, err := parser.Parse(data)
+		require.NoError(t, err)
+		require.Equal(t, "transfers", call.Arguments[0].Name)
+		require.Empty(t, DiffValues(&ListValue{Items: []SingleValue{transfer}}, call.Arguments[0].Value))
+		require.Equal(t, &OptionalValue{Value: &StringValue{Value: "hello"}}, call.Arguments[1].Value)
+
+		_, err = builder.Build("distribute", []any{&ListValue{Items: []SingleValue{&U8Value{}}}})
+		require.ErrorContains(t, err, "expected *abi.StructValue, got *abi.U8Value")
+	})
+
+	t.Run("should decode outputs (multi-values and enums)", func(t *testing.T) {
+		distribute, err := definition.GetEndpoint("distribute")
+		require.NoError(t, err)
+
+		outputValues := CreatePlaceholders(distribute.Outputs)
+		address := "0000000000000000000000000000000000000000000000000000000000000001"
+
+		err = serializer.Deserialize(address+"@@"+address+"@010000002a", outputValues)
+		require.NoError(t, err)
+
+		items := outputValues[0].(*VariadicValues).Items
+		require.Len(t, items, 2)
+
+		first := items[0].(*MultiValue).Items[1].(*EnumValue)
+		require.Equal(t, uint8(0), first.Discriminant)
+		require.Equal(t, "Pending", first.VariantNameProvider(first.Discriminant))
+
+		second := items[1].(*MultiValue).Items[1].(*EnumValue)
+		require.Equal(t, "Failed", second.VariantNameProvider(second.Discriminant))
+		require.Equal(t, []Field{{Name: "0", Value: &U32Value{Value: 42}}}, second.Fields)
+	})
+
+	t.Run("should decode recursive types and fixed-size arrays", func(t *testing.T) {
+		getTree, err := definition.GetEndpoint("getTree")
+		require.NoError(t, err)
+
+		hash := CreatePlaceholders(getTree.Inputs)[0]
+		require.Equal(t, &Array[SingleValue]{Length: 32}, withoutItemCreator(hash))
+
+		// Some(Node { value: 1, children: [Node { value: 2, children: [] }] })
+		outputValues := CreatePlaceholders(getTree.Outputs)
+		err = serializer.Deserialize("01"+"0000000000000001"+"00000001"+"0000000000000002"+"00000000", outputValues)
+		require.NoError(t, err)
+
+		root := outputValues[0].(*OptionValue).Value.(*StructValue)
+		require.Equal(t, &U64Value{Value: 1}, root.Fields[0].Value)
+
+		child := root.Fields[1].Value.(*ListValue).Items[0].(*StructValue)
+		require.Equal(t, &U64Value{Value: 2}, child.Fields[0].Value)
+		require.Empty(t, child.Fields[1].Value.(*ListValue).Items)
+	})
+}
+
+func TestParseAbiDefinition(t *testing.T) {
+	t.Run("should err on bad JSON", func(t *testing.T) {
+		_, err := ParseAbiDefinition([]byte("{"))
+		require.ErrorContains(t, err, "cannot parse ABI")
+	})
+
+	t.Run("should err on missing file", func(t *testing.T) {
+		_, err := LoadAbiDefinition("testdata/missing.abi.json")
+		require.ErrorContains(t, err, "cannot read ABI file")
+	})
+
+	t.Run("should err on unknown types", func(t *testing.T) {
+		_, err := ParseAbiDefinition([]byte(`{"endpoints": [{"name": "foo", "inputs": [{"name": "a", "type": "Foo"}]}]}`))
+		require.ErrorContains(t, err, "cannot load endpoint 'foo', because of: cannot load parameter 'a', because of: unknown type: Foo")
+
+		_, err = ParseAbiDefinition([]byte(`{"types": {"Foo": {"type": "struct", "fields": [{"name": "a", "type": "List<Bar>"}]}}}`))
+		require.ErrorContains(t, err, "cannot load type 'Foo', because of: cannot load field 'a', because of: unknown type: Bar")
+	})
+
+	t.Run("should err on unsupported types", func(t *testing.T) {
+		_, err := ParseAbiDefinition([]byte(`{"endpoints": [{"name": "foo", "inputs": [{"name": "a", "type": "List<optional<u8>>"}]}]}`))
+		require.ErrorContains(t, err, "unsupported type: optional<u8>")
+
+		_, err = ParseAbiDefinition([]byte(`{"endpoints": [{"name": "foo", "inputs": [{"name": "a", "type": "Foo"}]}], "types": {"Foo": {"type": "explicit-enum"}}}`))
+		require.ErrorContains(t, err, "unsupported kind of custom type: explicit-enum (Foo)")
+	})
+
+	t.Run("should err on bad type names", func(t *testing.T) {
+		for _, typeName := range []string{"", "List<u8", "List<>", "<u8>", "multi<u8,>", "List<u8>>", "u8>"} {
+			_, err := ParseAbiDefinition([]byte(`{"endpoints": [{"name": "foo", "outputs": [{"type": "` + typeName + `"}]}]}`))
+			require.ErrorContains(t, err, "bad type name", typeName)
+		}
+
+		_, err := ParseAbiDefinition([]byte(`{"endpoints": [{"name": "foo", "outputs": [{"type": "variadic<u8,u16>"}]}]}`))
+		require.ErrorContains(t, err, "variadic expects one type argument, got 2")
+	})
+}
+
+func withoutItemCreator(value any) any {
+	array := *value.(*Array[SingleValue])
+	array.ItemCreator = nil
+	return &array
+}
@@ -0,0 +1,143 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAbi() *Definition {
+	createTransfer := func() SingleValue {
+		return &StructValue{Fields: []Field{
+			{Name: "to", Value: &AddressValue{}},
+			{Name: "amount", Value: &BigUIntValue{}},
+		}}
+	}
+
+	return &Definition{
+		Constructor: &Endpoint{
+			Inputs: []Parameter{{Name: "initial_value", ValueCreator: func() any { return &BigUIntValue{} }}},
+		},
+		Endpoints: []Endpoint{
+			{
+				Name: "add",
+				Inputs: []Parameter{
+					{Name: "value", ValueCreator: func() any { return &BigUIntValue{} }},
+				},
+			},
+			{
+				Name: "getSum",
+				Outputs: []Parameter{
+					{Name: "sum", ValueCreator: func() any { return &BigUIntValue{} }},
+				},
+			},
+			{
+				Name: "distribute",
+				Inputs: []Parameter{
+					{Name: "token", ValueCreator: func() any { return &StringValue{} }},
+					{Name: "transfers", ValueCreator: func() any { return &ListValue{ItemCreator: createTransfer} }},
+					{Name: "memo", ValueCreator: func() any { return &OptionalValue{Value: &StringValue{}} }},
+				},
+			},
+		},
+		Callbacks: []Endpoint{
+			{
+				Name: "onSumReceived",
+				Inputs: []Parameter{
+					{Name: "requester", ValueCreator: func() any { return &AddressValue{} }},
+					{Name: "label", ValueCreator: func() any { return &StringValue{} }},
+				},
+			},
+		},
+		Events: []Event{
+			{
+				Identifier: "distributed",
+				Inputs: []EventInput{
+					{Name: "token", ValueCreator: func() any { return &StringValue{} }, Indexed: true},
+					{Name: "amount", ValueCreator: func() any { return &BigUIntValue{} }},
+				},
+			},
+		},
+	}
+}
+
+func TestDefinition_GetEndpoint(t *testing.T) {
+	abi := createTestAbi()
+
+	endpoint, err := abi.GetEndpoint("getSum")
+	require.NoError(t, err)
+	require.Equal(t, "getSum", endpoint.Name)
+
+	_, err = abi.GetEndpoint("missing")
+	require.ErrorContains(t, err, "endpoint not found: missing")
+
+	event, err := abi.GetEvent("distributed")
+	require.NoError(t, err)
+	require.Len(t, event.Inputs, 2)
+
+	_, err = abi.GetEvent("missing")
+	require.ErrorContains(t, err, "event not found: missing")
+
+	callback, err := abi.GetCallback("onSumReceived")
+	require.NoError(t, err)
+	require.Len(t, callback.Inputs, 2)
+
+	_, err = abi.GetCallback("getSum")
+	require.ErrorContains(t, err, "callback not found: getSum")
+}
+
+func TestCheckValuesAgainstParameters(t *testing.T) {
+	endpoint, _ := createTestAbi().GetEndpoint("distribute")
+	transfers := &ListValue{Items: []SingleValue{
+		&StructValue{Fields: []Field{
+			{Name: "to", Value: &AddressValue{Value: make([]byte, 32)}},
+			{Name: "amount", Value: &BigUIntValue{}},
+		}},
+	}}
+
+	t.Run("should accept matching values", func(t *testing.T) {
+		err := CheckValuesAgainstParameters([]any{&StringValue{}, transfers, &OptionalValue{Value: &StringValue{}}}, endpoint.Inputs)
+		require.NoError(t, err)
+
+		// Optional values may be omitted
+		err = CheckValuesAgainstParameters([]any{&StringValue{}, transfers}, endpoint.Inputs)
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject mismatching values", func(t *testing.T) {
+		err := CheckValuesAgainstParameters([]any{&StringValue{}}, endpoint.Inputs)
+		require.ErrorContains(t, err, "missing value for parameter 'transfers'")
+
+		err = CheckValuesAgainstParameters([]any{&StringValue{}, transfers, &OptionalValue{}, &U8Value{}}, endpoint.Inputs)
+		require.ErrorContains(t, err, "too many values: expected at most 3, got 4")
+
+		err = CheckValuesAgainstParameters([]any{&BytesValue{}, transfers}, endpoint.Inputs)
+		require.ErrorContains(t, err, "bad value for parameter 'token': $[0]: expected *abi.StringValue, got *abi.BytesValue")
+
+		badTransfers := &ListValue{Items: []SingleValue{
+			&StructValue{Fields: []Field{
+				{Name: "to", Value: &AddressValue{}},
+				{Name: "amount", Value: &U64Value{}},
+			}},
+		}}
+
+		err = CheckValuesAgainstParameters([]any{&StringValue{}, badTransfers}, endpoint.Inputs)
+		require.ErrorContains(t, err, "bad value for parameter 'transfers': $[1][0].amount: expected *abi.BigUIntValue, got *abi.U64Value")
+
+		err = CheckValuesAgainstParameters([]any{&StringValue{}, transfers, &OptionalValue{Value: &U8Value{}}}, endpoint.Inputs)
+		require.ErrorContains(t, err, "$[2].value: expected *abi.StringValue, got *abi.U8Value")
+
+		err = CheckValuesAgainstParameters([]any{nil, transfers}, endpoint.Inputs)
+		require.ErrorContains(t, err, "$[0]: nil value")
+	})
+
+	t.Run("should accept generic counterparts", func(t *testing.T) {
+		parameters := []Parameter{{Name: "values", ValueCreator: func() any { return &List[*U8Value]{} }}}
+
+		err := CheckValuesAgainstParameters([]any{&ListValue{Items: []SingleValue{&U8Value{}}}}, parameters)
+		require.NoError(t, err)
+
+		err = CheckValuesAgainstParameters([]any{&List[*U16Value]{Items: []*U16Value{{}}}}, parameters)
+		require.ErrorContains(t, err, "$[0][0]: expected *abi.U8Value, got *abi.U16Value")
+	})
+}
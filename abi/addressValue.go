@@ -26,6 +26,31 @@ func (value *AddressValue) EncodeTopLevel(writer io.Writer) error {
 	return value.EncodeNested(writer)
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *AddressValue) SizeNested() int {
+	return len(value.Value)
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *AddressValue) SizeTopLevel() int {
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *AddressValue) AppendNested(dst []byte) ([]byte, error) {
+	err := value.checkPubKeyLength(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, value.Value...), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *AddressValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	return value.AppendNested(dst)
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *AddressValue) DecodeNested(reader io.Reader) error {
 	data, err := readBytesExactly(reader, pubKeyLength)
@@ -48,6 +73,30 @@ func (value *AddressValue) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *AddressValue) decodeNested(c *cursor) error {
+	data, err := c.readBytes(pubKeyLength)
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+func (value *AddressValue) decodeTopLevel(c *cursor) error {
+	err := value.checkPubKeyLength(c.data[c.offset:])
+	if err != nil {
+		return err
+	}
+
+	return value.decodeNested(c)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *AddressValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 func (value *AddressValue) checkPubKeyLength(pubkey []byte) error {
 	if len(pubkey) != pubKeyLength {
 		return fmt.Errorf("public key (address) has invalid length: %d", len(pubkey))
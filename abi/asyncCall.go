@@ -0,0 +1,310 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// CallType is the type of a call, as found in smart contract results
+type CallType int
+
+const (
+	// DirectCall is a regular (synchronous) call
+	DirectCall CallType = 0
+	// AsynchronousCall is an asynchronous call (async v1, or promise)
+	AsynchronousCall CallType = 1
+	// AsynchronousCallBack is the call of the callback, once the asynchronous call is completed
+	AsynchronousCallBack CallType = 2
+	// ESDTTransferAndExecute is a token transfer followed by a call
+	ESDTTransferAndExecute CallType = 3
+)
+
+// callbackClosureStorageKeyPrefix is the prefix of the storage keys holding the callback closures of asynchronous calls (async v1)
+const callbackClosureStorageKeyPrefix = "CB_CLOSURE"
+
+// CallbackClosure is the callback (name and arguments) to be called once an asynchronous call is completed.
+// For async v1, the closure is held by the storage of the caller, under a key derived from the hash of the transaction:
+// the name of the callback (nested), then the arguments (as a nested list). See EncodeCallbackClosure.
+// For promises, the name of the callback is registered separately, while the closure holds only the arguments
+// (top-level encoded list, i.e. concatenated nested buffers, without a count). See EncodePromiseCallbackClosure.
+type CallbackClosure struct {
+	CallbackName string
+	Arguments    [][]byte
+}
+
+// CallbackClosureStorageKey returns the storage key holding the callback closure of an asynchronous call (async v1)
+func CallbackClosureStorageKey(txHash []byte) []byte {
+	return append([]byte(callbackClosureStorageKeyPrefix), txHash...)
+}
+
+// ArgsNewAsyncCallCodec defines the arguments needed for a new async call codec
+type ArgsNewAsyncCallCodec struct {
+	// Abi is optional: if set, the callbacks and the endpoints are looked up (for validating and decoding arguments and results)
+	Abi *Definition
+}
+
+// asyncCallCodec encodes and decodes the data carried by asynchronous calls:
+// the callback closures (callback name, closure arguments) and the data of the callbacks ("@returnCode@result1@result2...").
+type asyncCallCodec struct {
+	codec          *codec
+	callDataParser *callDataParser
+	abi            *Definition
+}
+
+// NewAsyncCallCodec creates a new async call codec
+func NewAsyncCallCodec(args ArgsNewAsyncCallCodec) (*asyncCallCodec, error) {
+	callDataParser, err := NewCallDataParser(ArgsNewCallDataParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &asyncCallCodec{
+		codec:          &codec{},
+		callDataParser: callDataParser,
+		abi:            args.Abi,
+	}, nil
+}
+
+// EncodeCallbackClosure encodes a closure for the given callback, with the given (typed) arguments.
+// If the ABI is available, the arguments must match the inputs of the callback.
+func (asyncCodec *asyncCallCodec) EncodeCallbackClosure(callbackName string, args []any) ([]byte, error) {
+	arguments, err := asyncCodec.serializeCallbackArguments(callbackName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return asyncCodec.codec.EncodeTopLevel(newCallbackClosureValue(CallbackClosure{
+		CallbackName: callbackName,
+		Arguments:    arguments,
+	}))
+}
+
+// EncodePromiseCallbackClosure encodes the closure (the arguments only) for the given callback of a promise, with the given (typed) arguments.
+// If the ABI is available, the arguments must match the inputs of the callback.
+func (asyncCodec *asyncCallCodec) EncodePromiseCallbackClosure(callbackName string, args []any) ([]byte, error) {
+	arguments, err := asyncCodec.serializeCallbackArguments(callbackName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return asyncCodec.codec.EncodeTopLevel(newCallbackArgumentsValue(arguments))
+}
+
+func (asyncCodec *asyncCallCodec) serializeCallbackArguments(callbackName string, args []any) ([][]byte, error) {
+	err := checkFunctionName(callbackName)
+	if err != nil {
+		return nil, err
+	}
+
+	if asyncCodec.abi != nil {
+		callback, err := asyncCodec.abi.GetCallback(callbackName)
+		if err != nil {
+			return nil, err
+		}
+
+		err = CheckValuesAgainstParameters(args, callback.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("bad arguments for callback '%s': %w", callbackName, err)
+		}
+	}
+
+	return asyncCodec.callDataParser.serializer.serializeToParts(args)
+}
+
+// DecodeCallbackClosure decodes a callback closure. If the ABI is available, the arguments are decoded according to the inputs of the callback.
+func (asyncCodec *asyncCallCodec) DecodeCallbackClosure(data []byte) (*CallbackClosure, []NamedValue, error) {
+	value := newCallbackClosureValue(CallbackClosure{})
+
+	err := decodeTopLevelExactly(data, value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode callback closure, because of: %w", err)
+	}
+
+	closure := &CallbackClosure{
+		CallbackName: string(value.Fields[0].Value.(*BytesValue).Value),
+		Arguments:    callbackArgumentsOf(value.Fields[1].Value.(*ListValue)),
+	}
+
+	return asyncCodec.decodeCallbackArguments(closure)
+}
+
+// DecodePromiseCallbackClosure decodes the closure (the arguments only) of the given callback of a promise.
+// If the ABI is available, the arguments are decoded according to the inputs of the callback.
+func (asyncCodec *asyncCallCodec) DecodePromiseCallbackClosure(callbackName string, data []byte) (*CallbackClosure, []NamedValue, error) {
+	err := checkFunctionName(callbackName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value := newCallbackArgumentsValue(nil)
+
+	err = decodeTopLevelExactly(data, value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode callback closure, because of: %w", err)
+	}
+
+	closure := &CallbackClosure{
+		CallbackName: callbackName,
+		Arguments:    callbackArgumentsOf(value),
+	}
+
+	return asyncCodec.decodeCallbackArguments(closure)
+}
+
+func (asyncCodec *asyncCallCodec) decodeCallbackArguments(closure *CallbackClosure) (*CallbackClosure, []NamedValue, error) {
+	if asyncCodec.abi == nil {
+		return closure, nil, nil
+	}
+
+	callback, err := asyncCodec.abi.GetCallback(closure.CallbackName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := asyncCodec.callDataParser.decodeExactly(closure.Arguments, callback.Inputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode arguments of callback '%s': %w", closure.CallbackName, err)
+	}
+
+	return closure, nameValues(values, callback.Inputs), nil
+}
+
+// EncodeCallbackData encodes the data of a callback: "@00@result1@result2..." on success, "@returnCode@message" on failure.
+// The return code is numeric (e.g. "00" for ok, "04" for user error).
+func (asyncCodec *asyncCallCodec) EncodeCallbackData(returnCode ReturnCode, message string, results []any) (string, error) {
+	if returnCode < 0 || returnCode > 0xFF {
+		return "", fmt.Errorf("bad return code: %d", returnCode)
+	}
+
+	values := []any{&U8Value{Value: uint8(returnCode)}}
+
+	if returnCode == ReturnCodeOk {
+		if message != "" {
+			return "", errors.New("message is not allowed on success")
+		}
+
+		values = append(values, results...)
+	} else {
+		if len(results) > 0 {
+			return "", errors.New("results are not allowed on failure")
+		}
+
+		values = append(values, &StringValue{Value: message})
+	}
+
+	parts, err := asyncCodec.callDataParser.serializer.serializeToParts(values)
+	if err != nil {
+		return "", err
+	}
+
+	// The return code is always explicit (even if zero)
+	parts[0] = []byte{byte(returnCode)}
+
+	return callDataPartsSeparator + asyncCodec.callDataParser.serializer.encodeParts(parts), nil
+}
+
+// DecodeCallbackData decodes the data of a callback. The return code can be numeric (e.g. "@00", "@04") or textual (e.g. "@6f6b").
+// If the called endpoint is given (and the ABI is available), the results are decoded according to its outputs (on success).
+func (asyncCodec *asyncCallCodec) DecodeCallbackData(data string, calledEndpoint string) (*ParsedContractResult, error) {
+	if !strings.HasPrefix(data, callDataPartsSeparator) {
+		return nil, fmt.Errorf("missing leading separator: %q", data)
+	}
+
+	parts, err := asyncCodec.callDataParser.serializer.decodeIntoParts(data[len(callDataPartsSeparator):])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode callback data: %w", err)
+	}
+
+	returnCode, returnCodeText := parseAnyReturnCode(parts[0])
+	parsed := &ParsedContractResult{
+		ReturnCode:     returnCode,
+		ReturnCodeText: returnCodeText,
+		RawValues:      parts[1:],
+	}
+
+	if !parsed.IsSuccess() {
+		if len(parsed.RawValues) > 0 {
+			parsed.ReturnMessage = string(parsed.RawValues[0])
+			parsed.RawValues = parsed.RawValues[1:]
+		}
+
+		return parsed, nil
+	}
+
+	if calledEndpoint == "" || asyncCodec.abi == nil {
+		return parsed, nil
+	}
+
+	endpoint, err := asyncCodec.abi.GetEndpoint(calledEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := asyncCodec.callDataParser.decodeExactly(parsed.RawValues, endpoint.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode outputs of endpoint '%s': %w", calledEndpoint, err)
+	}
+
+	parsed.Values = nameValues(values, endpoint.Outputs)
+	return parsed, nil
+}
+
+// parseAnyReturnCode parses a return code given either as text (e.g. "ok") or as a (big-endian) number (e.g. 0x04)
+func parseAnyReturnCode(data []byte) (ReturnCode, string) {
+	text := string(data)
+
+	code := ParseReturnCode(text)
+	if code != ReturnCodeUnknown {
+		return code, text
+	}
+
+	number := big.NewInt(0).SetBytes(data)
+	if !number.IsInt64() {
+		return ReturnCodeUnknown, text
+	}
+
+	code = ReturnCode(number.Int64())
+	if _, ok := returnCodesTexts[code]; !ok {
+		return ReturnCodeUnknown, text
+	}
+
+	return code, code.String()
+}
+
+// newCallbackClosureValue creates the value of a callback closure, as encoded by the Rust framework:
+// the name of the callback (nested), then the arguments (as a nested list of nested buffers).
+func newCallbackClosureValue(closure CallbackClosure) *StructValue {
+	return &StructValue{Fields: []Field{
+		{Name: "callback_name", Value: &BytesValue{Value: []byte(closure.CallbackName)}},
+		{Name: "closure_args", Value: newCallbackArgumentsValue(closure.Arguments)},
+	}}
+}
+
+// newCallbackArgumentsValue creates the value of the arguments of a callback: a list of buffers.
+// Nested within the closure of async v1, it's prefixed by the number of arguments. At top-level (promises), it isn't.
+func newCallbackArgumentsValue(arguments [][]byte) *ListValue {
+	items := make([]SingleValue, len(arguments))
+
+	for i, argument := range arguments {
+		items[i] = &BytesValue{Value: argument}
+	}
+
+	return &ListValue{
+		Items:       items,
+		ItemCreator: func() SingleValue { return &BytesValue{} },
+	}
+}
+
+func callbackArgumentsOf(value *ListValue) [][]byte {
+	arguments := make([][]byte, 0, len(value.Items))
+
+	for _, item := range value.Items {
+		arguments = append(arguments, item.(*BytesValue).Value)
+	}
+
+	return arguments
+}
@@ -0,0 +1,278 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackClosureStorageKey(t *testing.T) {
+	key := CallbackClosureStorageKey([]byte{0xab, 0xcd})
+	require.Equal(t, append([]byte("CB_CLOSURE"), 0xab, 0xcd), key)
+}
+
+func TestAsyncCallCodec_EncodeCallbackClosure(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{})
+		require.NoError(t, err)
+
+		data, err := asyncCodec.EncodeCallbackClosure("cb", []any{&U8Value{Value: 42}})
+		require.NoError(t, err)
+		require.Equal(t, "00000002"+"6362"+"00000001"+"00000001"+"2a", hex.EncodeToString(data))
+
+		data, err = asyncCodec.EncodeCallbackClosure("cb", []any{})
+		require.NoError(t, err)
+		require.Equal(t, "00000002"+"6362"+"00000000", hex.EncodeToString(data))
+
+		closure, values, err := asyncCodec.DecodeCallbackClosure(data)
+		require.NoError(t, err)
+		require.Equal(t, &CallbackClosure{CallbackName: "cb", Arguments: [][]byte{}}, closure)
+		require.Nil(t, values)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		requester := &AddressValue{Value: make([]byte, 32)}
+		requester.Value[31] = 0x01
+
+		data, err := asyncCodec.EncodeCallbackClosure("onSumReceived", []any{requester, &StringValue{Value: "hello"}})
+		require.NoError(t, err)
+
+		closure, values, err := asyncCodec.DecodeCallbackClosure(data)
+		require.NoError(t, err)
+		require.Equal(t, &CallbackClosure{
+			CallbackName: "onSumReceived",
+			Arguments:    [][]byte{requester.Value, []byte("hello")},
+		}, closure)
+		require.Equal(t, []NamedValue{
+			{Name: "requester", Value: requester},
+			{Name: "label", Value: &StringValue{Value: "hello"}},
+		}, values)
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = asyncCodec.EncodeCallbackClosure("", []any{})
+		require.Error(t, err)
+
+		_, err = asyncCodec.EncodeCallbackClosure("missing", []any{})
+		require.ErrorContains(t, err, "callback not found: missing")
+
+		_, err = asyncCodec.EncodeCallbackClosure("onSumReceived", []any{&StringValue{Value: "hello"}})
+		require.ErrorContains(t, err, "bad arguments for callback 'onSumReceived'")
+	})
+}
+
+func TestAsyncCallCodec_DecodeCallbackClosure(t *testing.T) {
+	asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("with bad data", func(t *testing.T) {
+		_, _, err := asyncCodec.DecodeCallbackClosure([]byte{0x00, 0x00})
+		require.ErrorContains(t, err, "cannot decode callback closure")
+
+		// "cb", no arguments, trailing byte
+		_, _, err = asyncCodec.DecodeCallbackClosure([]byte{0, 0, 0, 2, 'c', 'b', 0, 0, 0, 0, 0xff})
+		require.ErrorContains(t, err, "cannot decode callback closure")
+	})
+
+	t.Run("with unknown callback", func(t *testing.T) {
+		// "cb", no arguments
+		_, _, err := asyncCodec.DecodeCallbackClosure([]byte{0, 0, 0, 2, 'c', 'b', 0, 0, 0, 0})
+		require.ErrorContains(t, err, "callback not found: cb")
+	})
+
+	t.Run("with bad arguments", func(t *testing.T) {
+		// "onSumReceived", one argument (too few)
+		data := append([]byte{0, 0, 0, 13}, []byte("onSumReceived")...)
+		data = append(data, 0, 0, 0, 1, 0, 0, 0, 1, 0x2a)
+
+		_, _, err := asyncCodec.DecodeCallbackClosure(data)
+		require.ErrorContains(t, err, "cannot decode arguments of callback 'onSumReceived'")
+	})
+}
+
+func TestAsyncCallCodec_EncodePromiseCallbackClosure(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{})
+		require.NoError(t, err)
+
+		// No callback name, no count of arguments
+		data, err := asyncCodec.EncodePromiseCallbackClosure("cb", []any{&U8Value{Value: 42}, &StringValue{Value: "hi"}})
+		require.NoError(t, err)
+		require.Equal(t, "00000001"+"2a"+"00000002"+"6869", hex.EncodeToString(data))
+
+		closure, values, err := asyncCodec.DecodePromiseCallbackClosure("cb", data)
+		require.NoError(t, err)
+		require.Equal(t, &CallbackClosure{CallbackName: "cb", Arguments: [][]byte{{0x2a}, []byte("hi")}}, closure)
+		require.Nil(t, values)
+
+		data, err = asyncCodec.EncodePromiseCallbackClosure("cb", []any{})
+		require.NoError(t, err)
+		require.Empty(t, data)
+
+		closure, _, err = asyncCodec.DecodePromiseCallbackClosure("cb", data)
+		require.NoError(t, err)
+		require.Equal(t, &CallbackClosure{CallbackName: "cb", Arguments: [][]byte{}}, closure)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		requester := &AddressValue{Value: make([]byte, 32)}
+		requester.Value[31] = 0x01
+
+		data, err := asyncCodec.EncodePromiseCallbackClosure("onSumReceived", []any{requester, &StringValue{Value: "hello"}})
+		require.NoError(t, err)
+
+		closure, values, err := asyncCodec.DecodePromiseCallbackClosure("onSumReceived", data)
+		require.NoError(t, err)
+		require.Equal(t, &CallbackClosure{
+			CallbackName: "onSumReceived",
+			Arguments:    [][]byte{requester.Value, []byte("hello")},
+		}, closure)
+		require.Equal(t, []NamedValue{
+			{Name: "requester", Value: requester},
+			{Name: "label", Value: &StringValue{Value: "hello"}},
+		}, values)
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = asyncCodec.EncodePromiseCallbackClosure("", []any{})
+		require.Error(t, err)
+
+		_, err = asyncCodec.EncodePromiseCallbackClosure("missing", []any{})
+		require.ErrorContains(t, err, "callback not found: missing")
+
+		_, err = asyncCodec.EncodePromiseCallbackClosure("onSumReceived", []any{&StringValue{Value: "hello"}})
+		require.ErrorContains(t, err, "bad arguments for callback 'onSumReceived'")
+	})
+}
+
+func TestAsyncCallCodec_DecodePromiseCallbackClosure(t *testing.T) {
+	asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("with bad data", func(t *testing.T) {
+		_, _, err := asyncCodec.DecodePromiseCallbackClosure("onSumReceived", []byte{0x00, 0x00})
+		require.ErrorContains(t, err, "cannot decode callback closure")
+
+		// One argument, truncated
+		_, _, err = asyncCodec.DecodePromiseCallbackClosure("onSumReceived", []byte{0, 0, 0, 2, 0x2a})
+		require.ErrorContains(t, err, "cannot decode callback closure")
+
+		_, _, err = asyncCodec.DecodePromiseCallbackClosure("", []byte{})
+		require.Error(t, err)
+	})
+
+	t.Run("with unknown callback", func(t *testing.T) {
+		_, _, err := asyncCodec.DecodePromiseCallbackClosure("cb", []byte{})
+		require.ErrorContains(t, err, "callback not found: cb")
+	})
+
+	t.Run("with bad arguments", func(t *testing.T) {
+		// One argument (too few)
+		_, _, err := asyncCodec.DecodePromiseCallbackClosure("onSumReceived", []byte{0, 0, 0, 1, 0x2a})
+		require.ErrorContains(t, err, "cannot decode arguments of callback 'onSumReceived'")
+	})
+}
+
+func TestAsyncCallCodec_EncodeCallbackData(t *testing.T) {
+	asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{})
+	require.NoError(t, err)
+
+	t.Run("on success", func(t *testing.T) {
+		data, err := asyncCodec.EncodeCallbackData(ReturnCodeOk, "", []any{&U8Value{Value: 42}, &StringValue{Value: "abc"}})
+		require.NoError(t, err)
+		require.Equal(t, "@00@2a@616263", data)
+
+		data, err = asyncCodec.EncodeCallbackData(ReturnCodeOk, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "@00", data)
+	})
+
+	t.Run("on failure", func(t *testing.T) {
+		data, err := asyncCodec.EncodeCallbackData(ReturnCodeUserError, "not enough funds", nil)
+		require.NoError(t, err)
+		require.Equal(t, "@04@6e6f7420656e6f7567682066756e6473", data)
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		_, err := asyncCodec.EncodeCallbackData(ReturnCodeUnknown, "", nil)
+		require.ErrorContains(t, err, "bad return code: -1")
+
+		_, err = asyncCodec.EncodeCallbackData(ReturnCodeOk, "message", nil)
+		require.ErrorContains(t, err, "message is not allowed on success")
+
+		_, err = asyncCodec.EncodeCallbackData(ReturnCodeUserError, "message", []any{&U8Value{Value: 42}})
+		require.ErrorContains(t, err, "results are not allowed on failure")
+	})
+}
+
+func TestAsyncCallCodec_DecodeCallbackData(t *testing.T) {
+	asyncCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("with numeric return code", func(t *testing.T) {
+		parsed, err := asyncCodec.DecodeCallbackData("@00@2a", "")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedContractResult{
+			ReturnCode:     ReturnCodeOk,
+			ReturnCodeText: "ok",
+			RawValues:      [][]byte{{0x2a}},
+		}, parsed)
+
+		parsed, err = asyncCodec.DecodeCallbackData("@04@6e6f7420656e6f7567682066756e6473", "getSum")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedContractResult{
+			ReturnCode:     ReturnCodeUserError,
+			ReturnCodeText: "user error",
+			ReturnMessage:  "not enough funds",
+			RawValues:      [][]byte{},
+		}, parsed)
+	})
+
+	t.Run("with textual return code", func(t *testing.T) {
+		parsed, err := asyncCodec.DecodeCallbackData("@6f6b@2a", "getSum")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeOk, parsed.ReturnCode)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Values)
+	})
+
+	t.Run("with unknown return code", func(t *testing.T) {
+		parsed, err := asyncCodec.DecodeCallbackData("@ff", "")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeUnknown, parsed.ReturnCode)
+		require.False(t, parsed.IsSuccess())
+	})
+
+	t.Run("with outputs of called endpoint", func(t *testing.T) {
+		parsed, err := asyncCodec.DecodeCallbackData("@00@2a", "getSum")
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Values)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		_, err := asyncCodec.DecodeCallbackData("00@2a", "")
+		require.ErrorContains(t, err, `missing leading separator: "00@2a"`)
+
+		_, err = asyncCodec.DecodeCallbackData("@00@2", "")
+		require.ErrorContains(t, err, "cannot decode callback data")
+
+		_, err = asyncCodec.DecodeCallbackData("@00@2a", "missing")
+		require.ErrorContains(t, err, "endpoint not found: missing")
+
+		_, err = asyncCodec.DecodeCallbackData("@00@2a@2b", "getSum")
+		require.ErrorContains(t, err, "cannot decode outputs of endpoint 'getSum'")
+	})
+}
@@ -0,0 +1,97 @@
+package abi
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchItem is an item to be decoded within a batch (e.g. the return data of a contract call, or an event).
+// If Parts is set, it is decoded as raw parts; otherwise, Data (hex-encoded parts, separated by the parts separator) is decoded.
+type BatchItem struct {
+	Data  string
+	Parts [][]byte
+}
+
+// BatchResult is the outcome of decoding an item within a batch
+type BatchResult struct {
+	Values []any
+	Err    error
+}
+
+// DecodeBatch decodes many items in parallel, using the given decoding plan and a bounded number of workers.
+// Results are in the same order as the items. Failing items do not abort the batch: their errors are reported in the results.
+// If the context is cancelled, the items not yet decoded are reported with the context's error, which is also returned
+// (only if any item was actually skipped: if all items are decoded before the cancellation, no error is returned).
+// If "workers" is not positive, GOMAXPROCS workers are used.
+func DecodeBatch(ctx context.Context, items []BatchItem, plan *decodingPlan, workers int) ([]BatchResult, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	results := make([]BatchResult, len(items))
+	skipped := make([]bool, len(items))
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indices {
+				results[index], skipped[index] = decodeBatchItem(ctx, items[index], plan)
+			}
+		}()
+	}
+
+	cancelled := false
+
+	for index := range items {
+		if cancelled {
+			results[index], skipped[index] = BatchResult{Err: ctx.Err()}, true
+			continue
+		}
+
+		select {
+		case indices <- index:
+		case <-ctx.Done():
+			cancelled = true
+			results[index], skipped[index] = BatchResult{Err: ctx.Err()}, true
+		}
+	}
+
+	close(indices)
+	wg.Wait()
+
+	for index := range items {
+		if skipped[index] {
+			return results, results[index].Err
+		}
+	}
+
+	return results, nil
+}
+
+// decodeBatchItem decodes an item. It returns true if the item was skipped, because the context has been cancelled.
+func decodeBatchItem(ctx context.Context, item BatchItem, plan *decodingPlan) (BatchResult, bool) {
+	// The context might have been cancelled while the item was waiting for a worker.
+	err := ctx.Err()
+	if err != nil {
+		return BatchResult{Err: err}, true
+	}
+
+	var values []any
+
+	if item.Parts != nil {
+		values, err = plan.DecodeParts(item.Parts)
+	} else {
+		values, err = plan.Decode(item.Data)
+	}
+
+	return BatchResult{Values: values, Err: err}, false
+}
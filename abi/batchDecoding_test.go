@@ -0,0 +1,129 @@
+package abi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+		OutputValues:   []any{&U32Value{}, &StringValue{}},
+		PartsSeparator: "@",
+	})
+	require.NoError(t, err)
+
+	createItems := func(numItems int) []BatchItem {
+		items := make([]BatchItem, numItems)
+
+		for i := range items {
+			items[i] = BatchItem{Data: fmt.Sprintf("%08x@616263", i)}
+		}
+
+		return items
+	}
+
+	t.Run("should decode, preserving order", func(t *testing.T) {
+		items := createItems(1000)
+
+		results, err := DecodeBatch(context.Background(), items, plan, 4)
+		require.NoError(t, err)
+		require.Len(t, results, len(items))
+
+		for i, result := range results {
+			require.NoError(t, result.Err)
+			require.Equal(t, uint32(i), result.Values[0].(*U32Value).Value)
+			require.Equal(t, "abc", result.Values[1].(*StringValue).Value)
+		}
+	})
+
+	t.Run("should decode raw parts", func(t *testing.T) {
+		items := []BatchItem{{Parts: [][]byte{{0x2a}, []byte("abc")}}}
+
+		results, err := DecodeBatch(context.Background(), items, plan, 0)
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		require.Equal(t, uint32(42), results[0].Values[0].(*U32Value).Value)
+	})
+
+	t.Run("should report errors per item", func(t *testing.T) {
+		items := createItems(10)
+		items[3].Data = "zz@616263"
+		items[7].Data = "0102030405@616263"
+
+		results, err := DecodeBatch(context.Background(), items, plan, 3)
+		require.NoError(t, err)
+
+		for i, result := range results {
+			switch i {
+			case 3:
+				require.ErrorContains(t, result.Err, "invalid byte")
+			case 7:
+				require.ErrorContains(t, result.Err, "cannot decode (top-level) *abi.U32Value")
+			default:
+				require.NoError(t, result.Err)
+				require.Equal(t, uint32(i), result.Values[0].(*U32Value).Value)
+			}
+		}
+	})
+
+	t.Run("should handle empty batch", func(t *testing.T) {
+		results, err := DecodeBatch(context.Background(), nil, plan, 4)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("should honor cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		items := createItems(100)
+
+		results, err := DecodeBatch(ctx, items, plan, 2)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, results, len(items))
+
+		for _, result := range results {
+			require.ErrorIs(t, result.Err, context.Canceled)
+			require.Nil(t, result.Values)
+		}
+	})
+
+	t.Run("should not err if cancelled after all items are decoded", func(t *testing.T) {
+		items := createItems(3)
+
+		// Cancelled right after the items are decoded (each item checks the context once)
+		ctx := &cancelledAfterChecksContext{Context: context.Background(), numChecks: len(items)}
+
+		results, err := DecodeBatch(ctx, items, plan, 1)
+		require.NoError(t, err)
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+		for i, result := range results {
+			require.NoError(t, result.Err)
+			require.Equal(t, []any{&U32Value{Value: uint32(i)}, &StringValue{Value: "abc"}}, result.Values)
+		}
+	})
+}
+
+// cancelledAfterChecksContext is a context which reports cancellation once checked (by means of Err) a number of times
+type cancelledAfterChecksContext struct {
+	context.Context
+	numChecks int
+	mutex     sync.Mutex
+}
+
+func (ctx *cancelledAfterChecksContext) Err() error {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.numChecks > 0 {
+		ctx.numChecks--
+		return nil
+	}
+
+	return context.Canceled
+}
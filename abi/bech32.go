@@ -0,0 +1,116 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Bech32 encoding of addresses, as specified in BIP-173:
+// https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// encodeBech32 encodes the given data (e.g. a public key) using the given human-readable part (e.g. "erd")
+func encodeBech32(hrp string, data []byte) (string, error) {
+	if hrp == "" {
+		return "", errors.New("cannot encode bech32: human-readable part must not be empty")
+	}
+
+	converted, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := createBech32Checksum(hrp, converted)
+
+	builder := strings.Builder{}
+	builder.WriteString(hrp)
+	builder.WriteString("1")
+
+	for _, item := range append(converted, checksum...) {
+		builder.WriteByte(bech32Charset[item])
+	}
+
+	return builder.String(), nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	checksum := uint32(1)
+
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= bech32Generator[i]
+			}
+		}
+	}
+
+	return checksum
+}
+
+func bech32ExpandHrp(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+
+	expanded = append(expanded, 0)
+
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+
+	return expanded
+}
+
+func createBech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32ExpandHrp(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	checksum := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		checksum[i] = byte((polymod >> uint(5*(5-i))) & 31)
+	}
+
+	return checksum
+}
+
+// convertBits regroups the given data, from groups of "fromBits" bits to groups of "toBits" bits
+func convertBits(data []byte, fromBits uint8, toBits uint8, pad bool) ([]byte, error) {
+	accumulator := uint32(0)
+	numBits := uint8(0)
+	maxValue := uint32(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, item := range data {
+		if uint32(item)>>fromBits != 0 {
+			return nil, fmt.Errorf("cannot convert bits: invalid data byte %d", item)
+		}
+
+		accumulator = accumulator<<fromBits | uint32(item)
+		numBits += fromBits
+
+		for numBits >= toBits {
+			numBits -= toBits
+			result = append(result, byte(accumulator>>numBits&maxValue))
+		}
+	}
+
+	if pad {
+		if numBits > 0 {
+			result = append(result, byte(accumulator<<(toBits-numBits)&maxValue))
+		}
+	} else if numBits >= fromBits || accumulator<<(toBits-numBits)&maxValue != 0 {
+		return nil, errors.New("cannot convert bits: invalid padding")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBech32(t *testing.T) {
+	t.Run("should encode", func(t *testing.T) {
+		alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+		bobPubKey, _ := hex.DecodeString("8049d639e5a6980d1cd2392abcce41029cda74a1563523a202f09641cc2618f8")
+
+		address, err := encodeBech32("erd", alicePubKey)
+		require.NoError(t, err)
+		require.Equal(t, "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", address)
+
+		address, err = encodeBech32("erd", bobPubKey)
+		require.NoError(t, err)
+		require.Equal(t, "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx", address)
+	})
+
+	t.Run("should err on empty HRP", func(t *testing.T) {
+		_, err := encodeBech32("", []byte{0x01})
+		require.ErrorContains(t, err, "human-readable part must not be empty")
+	})
+}
@@ -1,6 +1,8 @@
 package abi
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
@@ -14,11 +16,16 @@ type BigIntValue struct {
 
 // EncodeNested encodes the value in the nested form
 func (value *BigIntValue) EncodeNested(writer io.Writer) error {
+	err := value.checkValue()
+	if err != nil {
+		return err
+	}
+
 	data := twos.ToBytes(value.Value)
 	dataLength := len(data)
 
 	// Write the length of the payload
-	err := encodeLength(writer, uint32(dataLength))
+	err = encodeLength(writer, uint32(dataLength))
 	if err != nil {
 		return err
 	}
@@ -34,8 +41,13 @@ func (value *BigIntValue) EncodeNested(writer io.Writer) error {
 
 // EncodeTopLevel encodes the value in the top-level form
 func (value *BigIntValue) EncodeTopLevel(writer io.Writer) error {
+	err := value.checkValue()
+	if err != nil {
+		return err
+	}
+
 	data := twos.ToBytes(value.Value)
-	_, err := writer.Write(data)
+	_, err = writer.Write(data)
 	if err != nil {
 		return err
 	}
@@ -43,6 +55,53 @@ func (value *BigIntValue) EncodeTopLevel(writer io.Writer) error {
 	return nil
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *BigIntValue) SizeNested() int {
+	return lengthPrefixSize + value.SizeTopLevel()
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *BigIntValue) SizeTopLevel() int {
+	if value.Value == nil {
+		return 0
+	}
+
+	switch value.Value.Sign() {
+	case 0:
+		return 0
+	case 1:
+		// An extra byte is needed if the most significant bit is set (to disambiguate from a negative number)
+		return value.Value.BitLen()/8 + 1
+	default:
+		// For negative numbers, the minimal length is given by the magnitude of (-value - 1)
+		magnitude := big.NewInt(0).Neg(value.Value)
+		magnitude.Sub(magnitude, big.NewInt(1))
+		return magnitude.BitLen()/8 + 1
+	}
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *BigIntValue) AppendNested(dst []byte) ([]byte, error) {
+	err := value.checkValue()
+	if err != nil {
+		return nil, err
+	}
+
+	data := twos.ToBytes(value.Value)
+	dst = appendLength(dst, uint32(len(data)))
+	return append(dst, data...), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *BigIntValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	err := value.checkValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, twos.ToBytes(value.Value)...), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *BigIntValue) DecodeNested(reader io.Reader) error {
 	// Read the length of the payload
@@ -66,3 +125,36 @@ func (value *BigIntValue) DecodeTopLevel(data []byte) error {
 	value.Value = twos.FromBytes(data)
 	return nil
 }
+
+func (value *BigIntValue) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.readSlice(int(length))
+	if err != nil {
+		return err
+	}
+
+	value.Value = twos.FromBytes(data)
+	return nil
+}
+
+func (value *BigIntValue) decodeTopLevel(c *cursor) error {
+	value.Value = twos.FromBytes(c.readRemainingSlice())
+	return nil
+}
+
+func (value *BigIntValue) checkValue() error {
+	if value.Value == nil {
+		return errors.New("cannot encode big integer: value is nil")
+	}
+
+	return nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *BigIntValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
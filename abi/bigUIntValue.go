@@ -1,6 +1,8 @@
 package abi
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"math/big"
 )
@@ -12,11 +14,16 @@ type BigUIntValue struct {
 
 // EncodeNested encodes the value in the nested form
 func (value *BigUIntValue) EncodeNested(writer io.Writer) error {
+	err := value.checkValue()
+	if err != nil {
+		return err
+	}
+
 	data := value.Value.Bytes()
 	dataLength := len(data)
 
 	// Write the length of the payload
-	err := encodeLength(writer, uint32(dataLength))
+	err = encodeLength(writer, uint32(dataLength))
 	if err != nil {
 		return err
 	}
@@ -32,8 +39,13 @@ func (value *BigUIntValue) EncodeNested(writer io.Writer) error {
 
 // EncodeTopLevel encodes the value in the top-level form
 func (value *BigUIntValue) EncodeTopLevel(writer io.Writer) error {
+	err := value.checkValue()
+	if err != nil {
+		return err
+	}
+
 	data := value.Value.Bytes()
-	_, err := writer.Write(data)
+	_, err = writer.Write(data)
 	if err != nil {
 		return err
 	}
@@ -41,6 +53,45 @@ func (value *BigUIntValue) EncodeTopLevel(writer io.Writer) error {
 	return nil
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *BigUIntValue) SizeNested() int {
+	return lengthPrefixSize + value.SizeTopLevel()
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *BigUIntValue) SizeTopLevel() int {
+	if value.Value == nil {
+		return 0
+	}
+
+	return (value.Value.BitLen() + 7) / 8
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *BigUIntValue) AppendNested(dst []byte) ([]byte, error) {
+	err := value.checkValue()
+	if err != nil {
+		return nil, err
+	}
+
+	dst = appendLength(dst, uint32(value.SizeTopLevel()))
+	return value.AppendTopLevel(dst)
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *BigUIntValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	err := value.checkValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// Extend the buffer (without extra allocations, if capacity allows), then fill the new bytes in place
+	start := len(dst)
+	dst = append(dst, make([]byte, value.SizeTopLevel())...)
+	value.Value.FillBytes(dst[start:])
+	return dst, nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *BigUIntValue) DecodeNested(reader io.Reader) error {
 	// Read the length of the payload
@@ -64,3 +115,40 @@ func (value *BigUIntValue) DecodeTopLevel(data []byte) error {
 	value.Value = big.NewInt(0).SetBytes(data)
 	return nil
 }
+
+func (value *BigUIntValue) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.readSlice(int(length))
+	if err != nil {
+		return err
+	}
+
+	value.Value = big.NewInt(0).SetBytes(data)
+	return nil
+}
+
+func (value *BigUIntValue) decodeTopLevel(c *cursor) error {
+	value.Value = big.NewInt(0).SetBytes(c.readRemainingSlice())
+	return nil
+}
+
+func (value *BigUIntValue) checkValue() error {
+	if value.Value == nil {
+		return errors.New("cannot encode unsigned big integer: value is nil")
+	}
+
+	if value.Value.Sign() < 0 {
+		return fmt.Errorf("cannot encode unsigned big integer: value is negative: %s", value.Value)
+	}
+
+	return nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *BigUIntValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
@@ -32,6 +32,39 @@ func (value *BoolValue) EncodeTopLevel(writer io.Writer) error {
 	return err
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *BoolValue) SizeNested() int {
+	return 1
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *BoolValue) SizeTopLevel() int {
+	if !value.Value {
+		return 0
+	}
+
+	return 1
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *BoolValue) AppendNested(dst []byte) ([]byte, error) {
+	if value.Value {
+		return append(dst, trueAsByte), nil
+	}
+
+	return append(dst, falseAsByte), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *BoolValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	if !value.Value {
+		// For "false", write nothing.
+		return dst, nil
+	}
+
+	return append(dst, trueAsByte), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *BoolValue) DecodeNested(reader io.Reader) error {
 	data, err := readBytesExactly(reader, 1)
@@ -67,6 +100,29 @@ func (value *BoolValue) DecodeTopLevel(data []byte) error {
 	return fmt.Errorf("unexpected boolean value: %v", data)
 }
 
+func (value *BoolValue) decodeNested(c *cursor) error {
+	data, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	value.Value, err = value.byteToBool(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (value *BoolValue) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *BoolValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 func (value *BoolValue) byteToBool(data uint8) (bool, error) {
 	switch data {
 	case trueAsByte:
@@ -1,6 +1,7 @@
 package abi
 
 import (
+	"fmt"
 	"io"
 )
 
@@ -26,6 +27,27 @@ func (value *BytesValue) EncodeTopLevel(writer io.Writer) error {
 	return err
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *BytesValue) SizeNested() int {
+	return lengthPrefixSize + len(value.Value)
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *BytesValue) SizeTopLevel() int {
+	return len(value.Value)
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *BytesValue) AppendNested(dst []byte) ([]byte, error) {
+	dst = appendLength(dst, uint32(len(value.Value)))
+	return append(dst, value.Value...), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *BytesValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	return append(dst, value.Value...), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *BytesValue) DecodeNested(reader io.Reader) error {
 	length, err := decodeLength(reader)
@@ -47,3 +69,33 @@ func (value *BytesValue) DecodeTopLevel(data []byte) error {
 	value.Value = data
 	return nil
 }
+
+func (value *BytesValue) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.readBytes(int(length))
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+func (value *BytesValue) decodeTopLevel(c *cursor) error {
+	data, err := c.readBytes(c.remaining())
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *BytesValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
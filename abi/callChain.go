@@ -0,0 +1,296 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CallChainNode is a step of the call chain of a transaction: the transaction itself (the root) or a smart contract result
+type CallChainNode struct {
+	Hash     string
+	Sender   string
+	Receiver string
+	CallType CallType
+	Data     string
+	// Call is the parsed call (nil if the data holds results, e.g. "@6f6b..." or a callback's "@00...")
+	Call *ParsedCallData
+	// Transfer is the parsed token transfer, along with its inner call, if any (nil if the call isn't a token transfer)
+	Transfer *ParsedTransferData
+	// Result is the parsed result (nil if the data holds a call)
+	Result *ParsedContractResult
+	// Children are the smart contract results generated by this step
+	Children []*CallChainNode
+}
+
+// ArgsNewCallChainBuilder defines the arguments needed for a new call chain builder
+type ArgsNewCallChainBuilder struct {
+	// Abis are optional: the ABIs of the involved contracts, by (bech32) address.
+	// Calls to (and callbacks from) contracts with known ABIs have their arguments and results decoded.
+	Abis map[string]*Definition
+}
+
+// callChainBuilder reconstructs the call chain of a transaction (e.g. a cross-shard asynchronous call, along with its callback),
+// by linking the smart contract results to their parents (by means of the "prevTxHash" field).
+type callChainBuilder struct {
+	rawCallDataParser     *callDataParser
+	rawTransferDataParser *transferDataParser
+	rawAsyncCallCodec     *asyncCallCodec
+	callDataParsers       map[string]*callDataParser
+	asyncCallCodecs       map[string]*asyncCallCodec
+}
+
+// NewCallChainBuilder creates a new call chain builder
+func NewCallChainBuilder(args ArgsNewCallChainBuilder) (*callChainBuilder, error) {
+	rawCallDataParser, err := NewCallDataParser(ArgsNewCallDataParser{})
+	if err != nil {
+		return nil, err
+	}
+
+	rawTransferDataParser, err := NewTransferDataParser(ArgsNewTransferDataParser{})
+	if err != nil {
+		return nil, err
+	}
+
+	rawAsyncCallCodec, err := NewAsyncCallCodec(ArgsNewAsyncCallCodec{})
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &callChainBuilder{
+		rawCallDataParser:     rawCallDataParser,
+		rawTransferDataParser: rawTransferDataParser,
+		rawAsyncCallCodec:     rawAsyncCallCodec,
+		callDataParsers:       make(map[string]*callDataParser),
+		asyncCallCodecs:       make(map[string]*asyncCallCodec),
+	}
+
+	for address, abi := range args.Abis {
+		builder.callDataParsers[address], err = NewCallDataParser(ArgsNewCallDataParser{Abi: abi})
+		if err != nil {
+			return nil, err
+		}
+
+		builder.asyncCallCodecs[address], err = NewAsyncCallCodec(ArgsNewAsyncCallCodec{Abi: abi})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder, nil
+}
+
+// Build reconstructs the call chain of the given transaction. Smart contract results whose parent isn't found are attached to the root.
+// So are the smart contract results which aren't reachable from the root (e.g. referencing each other as parents, in a cycle).
+func (builder *callChainBuilder) Build(transaction *TransactionOnNetwork) (*CallChainNode, error) {
+	root := &CallChainNode{
+		Hash:     transaction.Hash,
+		Sender:   transaction.Sender,
+		Receiver: transaction.Receiver,
+		CallType: DirectCall,
+		Data:     string(transaction.Data),
+		Children: make([]*CallChainNode, 0),
+	}
+
+	nodesByHash := map[string]*CallChainNode{root.Hash: root}
+	nodes := make([]*CallChainNode, 0, len(transaction.SmartContractResults))
+
+	for _, result := range transaction.SmartContractResults {
+		node := &CallChainNode{
+			Hash:     result.Hash,
+			Sender:   result.Sender,
+			Receiver: result.Receiver,
+			CallType: result.CallType,
+			Data:     result.Data,
+			Children: make([]*CallChainNode, 0),
+		}
+
+		nodesByHash[node.Hash] = node
+		nodes = append(nodes, node)
+	}
+
+	parents := make([]*CallChainNode, len(nodes))
+
+	for i, result := range transaction.SmartContractResults {
+		parent, ok := nodesByHash[result.PrevTxHash]
+		if !ok || parent == nodes[i] {
+			parent = root
+		}
+
+		parent.Children = append(parent.Children, nodes[i])
+		parents[i] = parent
+	}
+
+	attachUnreachableNodes(root, nodes, parents)
+
+	err := builder.parseTree(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// attachUnreachableNodes moves the nodes which aren't reachable from the root (i.e. part of a cycle, or descending from one) under the root.
+// Detaching a node from its parent breaks the cycle, thus its descendants become reachable, as well.
+func attachUnreachableNodes(root *CallChainNode, nodes []*CallChainNode, parents []*CallChainNode) {
+	reachable := make(map[*CallChainNode]bool, len(nodes)+1)
+	markReachableNodes(root, reachable)
+
+	for i, node := range nodes {
+		if reachable[node] {
+			continue
+		}
+
+		parent := parents[i]
+		for j, child := range parent.Children {
+			if child == node {
+				parent.Children = append(parent.Children[:j], parent.Children[j+1:]...)
+				break
+			}
+		}
+
+		root.Children = append(root.Children, node)
+		markReachableNodes(node, reachable)
+	}
+}
+
+func markReachableNodes(node *CallChainNode, reachable map[*CallChainNode]bool) {
+	reachable[node] = true
+
+	for _, child := range node.Children {
+		markReachableNodes(child, reachable)
+	}
+}
+
+// parseTree parses the nodes parents-first, since the results of callbacks are decoded according to the asynchronous calls (their parents)
+func (builder *callChainBuilder) parseTree(node *CallChainNode, parent *CallChainNode) error {
+	err := builder.parseNode(node, parent)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range node.Children {
+		err = builder.parseTree(child, node)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseNode parses the data of a node: either a call ("function@args...") or a result ("@returnCode@...").
+// The results of a callback are decoded according to the outputs of the endpoint called by the parent (the asynchronous call),
+// if known. Otherwise (e.g. the parent called a built-in function), they are left undecoded.
+func (builder *callChainBuilder) parseNode(node *CallChainNode, parent *CallChainNode) error {
+	if node.Data == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(node.Data, callDataPartsSeparator) {
+		asyncCodec := builder.rawAsyncCallCodec
+		calledEndpoint := ""
+
+		if node.CallType == AsynchronousCallBack && parent != nil {
+			codec, ok := builder.asyncCallCodecs[parent.calledContract()]
+			functionName := parent.calledFunctionName()
+
+			if ok && codec.abi.hasEndpoint(functionName) {
+				asyncCodec = codec
+				calledEndpoint = functionName
+			}
+		}
+
+		result, err := asyncCodec.DecodeCallbackData(node.Data, calledEndpoint)
+		if err != nil {
+			return fmt.Errorf("cannot parse result %s, because of: %w", node.Hash, err)
+		}
+
+		node.Result = result
+		return nil
+	}
+
+	call, err := builder.rawCallDataParser.Parse(node.Data)
+	if err != nil {
+		// Not a call, e.g. a deployment (the data starts with the code)
+		return nil
+	}
+
+	// Built-in functions (e.g. token transfers) aren't endpoints of the contract, thus their arguments are left undecoded.
+	parser, ok := builder.callDataParsers[node.Receiver]
+	if ok && parser.abi.hasEndpoint(call.FunctionName) {
+		call, err = parser.parseCall(call.FunctionName, call.RawArguments)
+		if err != nil {
+			return fmt.Errorf("cannot parse call %s, because of: %w", node.Hash, err)
+		}
+	}
+
+	node.Call = call
+
+	if isTokenTransferFunction(call.FunctionName) {
+		return builder.parseTransfer(node)
+	}
+
+	return nil
+}
+
+// parseTransfer parses a token transfer, along with its inner call (decoded if the ABI of the called contract is known)
+func (builder *callChainBuilder) parseTransfer(node *CallChainNode) error {
+	transfer, err := builder.rawTransferDataParser.Parse(node.Data)
+	if err != nil {
+		// Malformed transfers are left as plain (undecoded) calls of built-in functions
+		return nil
+	}
+
+	node.Transfer = transfer
+
+	if transfer.Call == nil {
+		return nil
+	}
+
+	parser, ok := builder.callDataParsers[node.calledContract()]
+	if ok && parser.abi.hasEndpoint(transfer.Call.FunctionName) {
+		transfer.Call, err = parser.parseCall(transfer.Call.FunctionName, transfer.Call.RawArguments)
+		if err != nil {
+			return fmt.Errorf("cannot parse call %s, because of: %w", node.Hash, err)
+		}
+	}
+
+	return nil
+}
+
+// calledContract returns the address of the contract called by the node. For NFT transfers (sent to self), it's the receiver of the transfer.
+func (node *CallChainNode) calledContract() string {
+	if node.Transfer == nil || node.Transfer.Receiver == nil || node.Receiver != node.Sender {
+		return node.Receiver
+	}
+
+	address, err := encodeBech32(defaultAddressHrp, node.Transfer.Receiver)
+	if err != nil {
+		return node.Receiver
+	}
+
+	return address
+}
+
+// calledFunctionName returns the name of the function called by the node: the inner call of a token transfer, if any
+func (node *CallChainNode) calledFunctionName() string {
+	if node.Transfer != nil && node.Transfer.Call != nil {
+		return node.Transfer.Call.FunctionName
+	}
+
+	if node.Call != nil {
+		return node.Call.FunctionName
+	}
+
+	return ""
+}
+
+func isTokenTransferFunction(functionName string) bool {
+	switch functionName {
+	case ESDTTransferFunctionName, ESDTNFTTransferFunctionName, MultiESDTNFTTransferFunctionName:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,212 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAsyncCallTransaction() *TransactionOnNetwork {
+	return &TransactionOnNetwork{
+		Hash:     "aa",
+		Sender:   "alice",
+		Receiver: "first",
+		Data:     []byte("add@05"),
+		SmartContractResults: []*SmartContractResultOnNetwork{
+			// The callback (listed before its parent, on purpose)
+			{Hash: "cc", Sender: "second", Receiver: "first", Data: "@00@2a", CallType: AsynchronousCallBack, PrevTxHash: "bb"},
+			// The asynchronous call
+			{Hash: "bb", Sender: "first", Receiver: "second", Data: "getSum", CallType: AsynchronousCall, PrevTxHash: "aa"},
+			// The refund, after the callback
+			{Hash: "dd", Sender: "first", Receiver: "alice", Data: "@6f6b", CallType: DirectCall, PrevTxHash: "cc"},
+			// An orphan (its parent isn't known)
+			{Hash: "ee", Sender: "first", Receiver: "alice", Data: "ESDTTransfer@54455354@0a", PrevTxHash: "ff"},
+		},
+	}
+}
+
+func TestCallChainBuilder_Build(t *testing.T) {
+	t.Run("with ABIs", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{
+			Abis: map[string]*Definition{
+				"first":  createTestAbi(),
+				"second": createTestAbi(),
+			},
+		})
+		require.NoError(t, err)
+
+		root, err := builder.Build(createTestAsyncCallTransaction())
+		require.NoError(t, err)
+
+		require.Equal(t, "aa", root.Hash)
+		require.Equal(t, DirectCall, root.CallType)
+		require.Equal(t, "add", root.Call.FunctionName)
+		require.Equal(t, []NamedValue{{Name: "value", Value: &BigUIntValue{Value: big.NewInt(5)}}}, root.Call.Arguments)
+		require.Nil(t, root.Result)
+		require.Len(t, root.Children, 2)
+
+		asyncCall := root.Children[0]
+		require.Equal(t, "bb", asyncCall.Hash)
+		require.Equal(t, AsynchronousCall, asyncCall.CallType)
+		require.Equal(t, "getSum", asyncCall.Call.FunctionName)
+		require.Len(t, asyncCall.Children, 1)
+
+		callback := asyncCall.Children[0]
+		require.Equal(t, "cc", callback.Hash)
+		require.Equal(t, AsynchronousCallBack, callback.CallType)
+		require.Nil(t, callback.Call)
+		require.Equal(t, ReturnCodeOk, callback.Result.ReturnCode)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, callback.Result.Values)
+		require.Len(t, callback.Children, 1)
+
+		refund := callback.Children[0]
+		require.Equal(t, "dd", refund.Hash)
+		require.Equal(t, ReturnCodeOk, refund.Result.ReturnCode)
+		require.Empty(t, refund.Children)
+
+		// Built-in functions are left undecoded
+		orphan := root.Children[1]
+		require.Equal(t, "ee", orphan.Hash)
+		require.Equal(t, "ESDTTransfer", orphan.Call.FunctionName)
+		require.Equal(t, [][]byte{[]byte("TEST"), {0x0a}}, orphan.Call.RawArguments)
+		require.Nil(t, orphan.Call.Arguments)
+	})
+
+	t.Run("without ABIs", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{})
+		require.NoError(t, err)
+
+		root, err := builder.Build(createTestAsyncCallTransaction())
+		require.NoError(t, err)
+
+		require.Equal(t, "add", root.Call.FunctionName)
+		require.Equal(t, [][]byte{{0x05}}, root.Call.RawArguments)
+
+		callback := root.Children[0].Children[0]
+		require.Equal(t, ReturnCodeOk, callback.Result.ReturnCode)
+		require.Equal(t, [][]byte{{0x2a}}, callback.Result.RawValues)
+		require.Nil(t, callback.Result.Values)
+	})
+
+	t.Run("with failed asynchronous call", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{
+			Abis: map[string]*Definition{"second": createTestAbi()},
+		})
+		require.NoError(t, err)
+
+		transaction := createTestAsyncCallTransaction()
+		transaction.SmartContractResults[0].Data = "@04@6e6f7420656e6f7567682066756e6473"
+
+		root, err := builder.Build(transaction)
+		require.NoError(t, err)
+
+		callback := root.Children[0].Children[0]
+		require.Equal(t, ReturnCodeUserError, callback.Result.ReturnCode)
+		require.Equal(t, "not enough funds", callback.Result.ReturnMessage)
+	})
+
+	t.Run("with asynchronous call paid with tokens", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{
+			Abis: map[string]*Definition{"first": createTestAbi(), "second": createTestAbi()},
+		})
+		require.NoError(t, err)
+
+		transaction := createTestAsyncCallTransaction()
+		transaction.SmartContractResults[1].Data = "ESDTTransfer@54455354@0a@67657453756d"
+
+		root, err := builder.Build(transaction)
+		require.NoError(t, err)
+
+		asyncCall := root.Children[0]
+		require.Equal(t, "ESDTTransfer", asyncCall.Call.FunctionName)
+		require.Nil(t, asyncCall.Call.Arguments)
+		require.Equal(t, []TokenTransfer{{TokenIdentifier: "TEST", Amount: big.NewInt(10)}}, asyncCall.Transfer.Transfers)
+		require.Equal(t, "getSum", asyncCall.Transfer.Call.FunctionName)
+		require.Empty(t, asyncCall.Transfer.Call.Arguments)
+
+		// The results are decoded according to the outputs of the inner call
+		callback := asyncCall.Children[0]
+		require.Equal(t, ReturnCodeOk, callback.Result.ReturnCode)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, callback.Result.Values)
+	})
+
+	t.Run("with asynchronous call of unknown endpoint", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{
+			Abis: map[string]*Definition{"first": createTestAbi(), "second": createTestAbi()},
+		})
+		require.NoError(t, err)
+
+		// Token transfer without inner call
+		transaction := createTestAsyncCallTransaction()
+		transaction.SmartContractResults[1].Data = "ESDTTransfer@54455354@0a"
+
+		root, err := builder.Build(transaction)
+		require.NoError(t, err)
+
+		asyncCall := root.Children[0]
+		require.Nil(t, asyncCall.Transfer.Call)
+
+		// The results are left undecoded
+		callback := asyncCall.Children[0]
+		require.Equal(t, ReturnCodeOk, callback.Result.ReturnCode)
+		require.Equal(t, [][]byte{{0x2a}}, callback.Result.RawValues)
+		require.Nil(t, callback.Result.Values)
+
+		// Endpoint not found in the ABI
+		transaction = createTestAsyncCallTransaction()
+		transaction.SmartContractResults[1].Data = "ESDTTransfer@54455354@0a@756e6b6e6f776e"
+
+		root, err = builder.Build(transaction)
+		require.NoError(t, err)
+
+		asyncCall = root.Children[0]
+		require.Equal(t, "unknown", asyncCall.Transfer.Call.FunctionName)
+
+		callback = asyncCall.Children[0]
+		require.Equal(t, [][]byte{{0x2a}}, callback.Result.RawValues)
+		require.Nil(t, callback.Result.Values)
+	})
+
+	t.Run("with results referencing each other", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{})
+		require.NoError(t, err)
+
+		transaction := createTestAsyncCallTransaction()
+		transaction.SmartContractResults = append(transaction.SmartContractResults,
+			&SmartContractResultOnNetwork{Hash: "x1", Sender: "first", Receiver: "alice", Data: "@6f6b", PrevTxHash: "x2"},
+			&SmartContractResultOnNetwork{Hash: "x2", Sender: "first", Receiver: "alice", Data: "@6f6b", PrevTxHash: "x3"},
+			&SmartContractResultOnNetwork{Hash: "x3", Sender: "first", Receiver: "alice", Data: "@6f6b", PrevTxHash: "x1"},
+		)
+
+		root, err := builder.Build(transaction)
+		require.NoError(t, err)
+
+		// The cycle is broken at its first result, which is attached to the root
+		require.Len(t, root.Children, 3)
+		require.Equal(t, "x1", root.Children[2].Hash)
+		require.Equal(t, "x3", root.Children[2].Children[0].Hash)
+		require.Equal(t, "x2", root.Children[2].Children[0].Children[0].Hash)
+		require.Empty(t, root.Children[2].Children[0].Children[0].Children)
+		require.Equal(t, ReturnCodeOk, root.Children[2].Children[0].Children[0].Result.ReturnCode)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		builder, err := NewCallChainBuilder(ArgsNewCallChainBuilder{
+			Abis: map[string]*Definition{"first": createTestAbi(), "second": createTestAbi()},
+		})
+		require.NoError(t, err)
+
+		transaction := createTestAsyncCallTransaction()
+		transaction.Data = []byte("add@05@06")
+
+		_, err = builder.Build(transaction)
+		require.ErrorContains(t, err, "cannot parse call aa")
+
+		transaction = createTestAsyncCallTransaction()
+		transaction.SmartContractResults[0].Data = "@00@2a@2b"
+
+		_, err = builder.Build(transaction)
+		require.ErrorContains(t, err, "cannot parse result cc")
+	})
+}
@@ -0,0 +1,98 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const callDataPartsSeparator = "@"
+
+var functionNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// ArgsNewCallDataBuilder defines the arguments needed for a new call data builder
+type ArgsNewCallDataBuilder struct {
+	// Abi is optional: if set, the function must be an endpoint of the ABI, and the arguments must match its inputs
+	Abi *Definition
+}
+
+// callDataBuilder builds the data field of contract call transactions: "functionName@arg1@arg2..."
+type callDataBuilder struct {
+	serializer *serializer
+	abi        *Definition
+}
+
+// NewCallDataBuilder creates a new call data builder
+func NewCallDataBuilder(args ArgsNewCallDataBuilder) (*callDataBuilder, error) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: callDataPartsSeparator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &callDataBuilder{
+		serializer: serializer,
+		abi:        args.Abi,
+	}, nil
+}
+
+// Build builds the data field for calling the given function (with the given arguments)
+func (builder *callDataBuilder) Build(functionName string, args []any) (string, error) {
+	err := builder.checkCall(functionName, args)
+	if err != nil {
+		return "", err
+	}
+
+	return builder.buildWithPrefix(functionName, args)
+}
+
+// checkCall checks the function name and, if the ABI is available, the arguments (against the inputs of the endpoint)
+func (builder *callDataBuilder) checkCall(functionName string, args []any) error {
+	err := checkFunctionName(functionName)
+	if err != nil {
+		return err
+	}
+
+	if builder.abi == nil {
+		return nil
+	}
+
+	endpoint, err := builder.abi.GetEndpoint(functionName)
+	if err != nil {
+		return err
+	}
+
+	err = CheckValuesAgainstParameters(args, endpoint.Inputs)
+	if err != nil {
+		return fmt.Errorf("bad arguments for endpoint '%s': %w", functionName, err)
+	}
+
+	return nil
+}
+
+// buildWithPrefix builds "prefix@arg1@arg2...". With no arguments, the result is the prefix alone.
+func (builder *callDataBuilder) buildWithPrefix(prefix string, args []any) (string, error) {
+	parts, err := builder.serializer.serializeToParts(args)
+	if err != nil {
+		return "", err
+	}
+
+	if len(parts) == 0 {
+		return prefix, nil
+	}
+
+	return prefix + callDataPartsSeparator + builder.serializer.encodeParts(parts), nil
+}
+
+func checkFunctionName(functionName string) error {
+	if functionName == "" {
+		return errors.New("function name must not be empty")
+	}
+
+	if !functionNameRegex.MatchString(functionName) {
+		return fmt.Errorf("invalid function name: %q", functionName)
+	}
+
+	return nil
+}
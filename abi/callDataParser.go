@@ -0,0 +1,132 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamedValue is a value along with its name (e.g. a decoded argument, named after the input of the endpoint)
+type NamedValue struct {
+	Name  string
+	Value any
+}
+
+// ParsedCallData is the outcome of parsing the data field of a contract call transaction
+type ParsedCallData struct {
+	FunctionName string
+	// RawArguments holds the (hex-decoded) parts of the arguments
+	RawArguments [][]byte
+	// Arguments holds the decoded arguments, named after the inputs of the endpoint (only if the ABI is available)
+	Arguments []NamedValue
+}
+
+// ArgsNewCallDataParser defines the arguments needed for a new call data parser
+type ArgsNewCallDataParser struct {
+	// Abi is optional: if not set, only the function name and the raw arguments are parsed
+	Abi *Definition
+}
+
+// callDataParser parses the data field of contract call transactions: "functionName@arg1@arg2..."
+type callDataParser struct {
+	serializer *serializer
+	abi        *Definition
+}
+
+// NewCallDataParser creates a new call data parser
+func NewCallDataParser(args ArgsNewCallDataParser) (*callDataParser, error) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: callDataPartsSeparator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &callDataParser{
+		serializer: serializer,
+		abi:        args.Abi,
+	}, nil
+}
+
+// Parse parses the given data field. If the ABI is available, the function must be one of its endpoints,
+// and the arguments are decoded according to the inputs of the endpoint.
+func (parser *callDataParser) Parse(data string) (*ParsedCallData, error) {
+	functionName, rawArguments, err := parser.splitCallData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.parseCall(functionName, rawArguments)
+}
+
+// parseCall decodes the raw arguments of a call (if the ABI is available)
+func (parser *callDataParser) parseCall(functionName string, rawArguments [][]byte) (*ParsedCallData, error) {
+	parsed := &ParsedCallData{
+		FunctionName: functionName,
+		RawArguments: rawArguments,
+	}
+
+	if parser.abi == nil {
+		return parsed, nil
+	}
+
+	endpoint, err := parser.abi.GetEndpoint(functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parser.decodeExactly(rawArguments, endpoint.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode arguments of endpoint '%s': %w", functionName, err)
+	}
+
+	parsed.Arguments = nameValues(values, endpoint.Inputs)
+	return parsed, nil
+}
+
+// splitCallData splits "prefix@arg1@arg2..." into the prefix (e.g. function name) and the (hex-decoded) arguments
+func (parser *callDataParser) splitCallData(data string) (string, [][]byte, error) {
+	prefix, encodedArguments, hasArguments := strings.Cut(data, callDataPartsSeparator)
+
+	err := checkFunctionName(prefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !hasArguments {
+		return prefix, [][]byte{}, nil
+	}
+
+	arguments, err := parser.serializer.decodeIntoParts(encodedArguments)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot decode arguments: %w", err)
+	}
+
+	return prefix, arguments, nil
+}
+
+// decodeExactly decodes the parts according to the given parameters; all the parts must be consumed
+func (parser *callDataParser) decodeExactly(parts [][]byte, parameters []Parameter) ([]any, error) {
+	placeholders := CreatePlaceholders(parameters)
+	partsHolder := newPartsHolder(parts)
+
+	err := parser.serializer.doDeserialize(partsHolder, placeholders, positionOfLastValues)
+	if err != nil {
+		return nil, err
+	}
+
+	if !partsHolder.isFocusedBeyondLastPart() {
+		return nil, fmt.Errorf("too many parts: expected %d, got %d", partsHolder.focusedPartIndex, len(parts))
+	}
+
+	return placeholders, nil
+}
+
+func nameValues(values []any, parameters []Parameter) []NamedValue {
+	namedValues := make([]NamedValue, len(values))
+
+	for i, value := range values {
+		namedValues[i] = NamedValue{Name: parameters[i].Name, Value: value}
+	}
+
+	return namedValues
+}
@@ -0,0 +1,75 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallDataParser_Parse(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("add@07@616263@")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedCallData{
+			FunctionName: "add",
+			RawArguments: [][]byte{{0x07}, []byte("abc"), {}},
+		}, parsed)
+
+		parsed, err = parser.Parse("getSum")
+		require.NoError(t, err)
+		require.Equal(t, "getSum", parsed.FunctionName)
+		require.Empty(t, parsed.RawArguments)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{})
+		require.NoError(t, err)
+
+		_, err = parser.Parse("")
+		require.ErrorContains(t, err, "function name must not be empty")
+
+		_, err = parser.Parse("@07")
+		require.ErrorContains(t, err, "function name must not be empty")
+
+		_, err = parser.Parse("add me@07")
+		require.ErrorContains(t, err, `invalid function name: "add me"`)
+
+		_, err = parser.Parse("add@0")
+		require.ErrorContains(t, err, "cannot decode arguments: encoding/hex: odd length hex string")
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("distribute@55534443@" + "0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1" + "0000000164")
+		require.NoError(t, err)
+		require.Equal(t, "distribute", parsed.FunctionName)
+		require.Len(t, parsed.RawArguments, 2)
+		require.Len(t, parsed.Arguments, 3)
+		require.Equal(t, "token", parsed.Arguments[0].Name)
+		require.Equal(t, &StringValue{Value: "USDC"}, parsed.Arguments[0].Value)
+		require.Equal(t, "transfers", parsed.Arguments[1].Name)
+		require.Equal(t, big.NewInt(100), parsed.Arguments[1].Value.(*ListValue).Items[0].(*StructValue).Fields[1].Value.(*BigUIntValue).Value)
+		require.Equal(t, "memo", parsed.Arguments[2].Name)
+		require.Equal(t, &OptionalValue{}, parsed.Arguments[2].Value)
+	})
+
+	t.Run("with ABI, with bad arguments", func(t *testing.T) {
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = parser.Parse("missing@07")
+		require.ErrorContains(t, err, "endpoint not found: missing")
+
+		_, err = parser.Parse("add@07@08")
+		require.ErrorContains(t, err, "cannot decode arguments of endpoint 'add': too many parts: expected 1, got 2")
+
+		_, err = parser.Parse("add")
+		require.ErrorContains(t, err, "cannot decode arguments of endpoint 'add'")
+	})
+}
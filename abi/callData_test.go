@@ -0,0 +1,65 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallDataBuilder_Build(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{})
+		require.NoError(t, err)
+
+		data, err := builder.Build("add", []any{&BigUIntValue{Value: big.NewInt(7)}, &StringValue{Value: "abc"}})
+		require.NoError(t, err)
+		require.Equal(t, "add@07@616263", data)
+
+		data, err = builder.Build("getSum", nil)
+		require.NoError(t, err)
+		require.Equal(t, "getSum", data)
+
+		// A single, empty argument
+		data, err = builder.Build("add", []any{&BigUIntValue{Value: big.NewInt(0)}})
+		require.NoError(t, err)
+		require.Equal(t, "add@", data)
+	})
+
+	t.Run("with bad function name", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{})
+		require.NoError(t, err)
+
+		_, err = builder.Build("", nil)
+		require.ErrorContains(t, err, "function name must not be empty")
+
+		_, err = builder.Build("add@01", nil)
+		require.ErrorContains(t, err, `invalid function name: "add@01"`)
+
+		_, err = builder.Build("1add", nil)
+		require.ErrorContains(t, err, `invalid function name: "1add"`)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		data, err := builder.Build("add", []any{&BigUIntValue{Value: big.NewInt(7)}})
+		require.NoError(t, err)
+		require.Equal(t, "add@07", data)
+
+		_, err = builder.Build("missing", nil)
+		require.ErrorContains(t, err, "endpoint not found: missing")
+
+		_, err = builder.Build("add", []any{&U64Value{Value: 7}})
+		require.ErrorContains(t, err, "bad arguments for endpoint 'add': bad value for parameter 'value': $[0]: expected *abi.BigUIntValue, got *abi.U64Value")
+	})
+
+	t.Run("with bad arguments", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{})
+		require.NoError(t, err)
+
+		_, err = builder.Build("add", []any{nil})
+		require.ErrorContains(t, err, "cannot serialize nil value")
+	})
+}
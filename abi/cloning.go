@@ -0,0 +1,209 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CloneValue creates a deep copy of the given value (a single value or a multi-value).
+// Item creators and fields providers are shared between the original value and the copy.
+func CloneValue(value any) (any, error) {
+	if isNilPointer(value) {
+		return nil, fmt.Errorf("cannot clone nil value of type %T", value)
+	}
+
+	switch value := value.(type) {
+	case *OptionalValue:
+		return cloneOptionalValue(value)
+	case *MultiValue:
+		items, err := cloneItems(value.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		return &MultiValue{Items: items}, nil
+	case *VariadicValues:
+		items, err := cloneItems(value.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		return &VariadicValues{Items: items, ItemCreator: value.ItemCreator}, nil
+	case genericValue:
+		return value.clone()
+	case SingleValue:
+		return CloneSingleValue(value)
+	default:
+		return nil, fmt.Errorf("cannot clone value of type %T", value)
+	}
+}
+
+// CloneSingleValue creates a deep copy of the given single value.
+func CloneSingleValue(value SingleValue) (SingleValue, error) {
+	if isNilPointer(value) {
+		return nil, fmt.Errorf("cannot clone nil value of type %T", value)
+	}
+
+	switch value := value.(type) {
+	case *U8Value:
+		return &U8Value{Value: value.Value}, nil
+	case *U16Value:
+		return &U16Value{Value: value.Value}, nil
+	case *U32Value:
+		return &U32Value{Value: value.Value}, nil
+	case *U64Value:
+		return &U64Value{Value: value.Value}, nil
+	case *I8Value:
+		return &I8Value{Value: value.Value}, nil
+	case *I16Value:
+		return &I16Value{Value: value.Value}, nil
+	case *I32Value:
+		return &I32Value{Value: value.Value}, nil
+	case *I64Value:
+		return &I64Value{Value: value.Value}, nil
+	case *BigIntValue:
+		return &BigIntValue{Value: cloneBigInt(value.Value)}, nil
+	case *BigUIntValue:
+		return &BigUIntValue{Value: cloneBigInt(value.Value)}, nil
+	case *BoolValue:
+		return &BoolValue{Value: value.Value}, nil
+	case *StringValue:
+		return &StringValue{Value: value.Value}, nil
+	case *BytesValue:
+		return &BytesValue{Value: cloneBytes(value.Value)}, nil
+	case *AddressValue:
+		return &AddressValue{Value: cloneBytes(value.Value)}, nil
+	case *OptionValue:
+		if value.Value == nil {
+			return &OptionValue{}, nil
+		}
+
+		inner, err := CloneSingleValue(value.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &OptionValue{Value: inner}, nil
+	case *ListValue:
+		items, err := cloneSingleValues(value.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ListValue{Items: items, ItemCreator: value.ItemCreator}, nil
+	case *StructValue:
+		fields, err := cloneFields(value.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		return &StructValue{Fields: fields}, nil
+	case *EnumValue:
+		fields, err := cloneFields(value.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		return &EnumValue{
+			Discriminant:        value.Discriminant,
+			Fields:              fields,
+			FieldsProvider:      value.FieldsProvider,
+			VariantNameProvider: value.VariantNameProvider,
+		}, nil
+	case genericValue:
+		cloned, err := value.clone()
+		if err != nil {
+			return nil, err
+		}
+
+		return cloned.(SingleValue), nil
+	default:
+		return nil, fmt.Errorf("cannot clone value of type %T", value)
+	}
+}
+
+func cloneOptionalValue(value *OptionalValue) (*OptionalValue, error) {
+	if value.Value == nil {
+		return &OptionalValue{}, nil
+	}
+
+	inner, err := CloneValue(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OptionalValue{Value: inner}, nil
+}
+
+func cloneItems(items []any) ([]any, error) {
+	if items == nil {
+		return nil, nil
+	}
+
+	clonedItems := make([]any, 0, len(items))
+
+	for i, item := range items {
+		clonedItem, err := CloneValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("cannot clone item %d, because of: %w", i, err)
+		}
+
+		clonedItems = append(clonedItems, clonedItem)
+	}
+
+	return clonedItems, nil
+}
+
+func cloneSingleValues(items []SingleValue) ([]SingleValue, error) {
+	if items == nil {
+		return nil, nil
+	}
+
+	clonedItems := make([]SingleValue, 0, len(items))
+
+	for i, item := range items {
+		clonedItem, err := CloneSingleValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("cannot clone item %d, because of: %w", i, err)
+		}
+
+		clonedItems = append(clonedItems, clonedItem)
+	}
+
+	return clonedItems, nil
+}
+
+func cloneFields(fields []Field) ([]Field, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	clonedFields := make([]Field, 0, len(fields))
+
+	for _, field := range fields {
+		clonedValue, err := CloneSingleValue(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot clone field '%s', because of: %w", field.Name, err)
+		}
+
+		clonedFields = append(clonedFields, Field{Name: field.Name, Value: clonedValue})
+	}
+
+	return clonedFields, nil
+}
+
+func cloneBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+func cloneBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(value)), value...)
+}
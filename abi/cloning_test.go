@@ -0,0 +1,90 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneValue(t *testing.T) {
+	t.Run("should clone single values", func(t *testing.T) {
+		original := &StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U8Value{Value: 0x42}},
+				{Name: "b", Value: &BigUIntValue{Value: big.NewInt(1000)}},
+				{Name: "c", Value: &BytesValue{Value: []byte{0x01, 0x02}}},
+				{Name: "d", Value: &OptionValue{Value: &StringValue{Value: "hello"}}},
+				{Name: "e", Value: &ListValue{Items: []SingleValue{&BoolValue{Value: true}}}},
+				{Name: "f", Value: &EnumValue{Discriminant: 1, Fields: []Field{{Value: &I32Value{Value: -7}}}}},
+			},
+		}
+
+		cloned, err := CloneValue(original)
+		require.NoError(t, err)
+		require.Equal(t, original, cloned)
+
+		// Mutating the copy should not affect the original
+		clonedStruct := cloned.(*StructValue)
+		clonedStruct.Fields[0].Value.(*U8Value).Value = 0x43
+		clonedStruct.Fields[1].Value.(*BigUIntValue).Value.SetInt64(2000)
+		clonedStruct.Fields[2].Value.(*BytesValue).Value[0] = 0xff
+		clonedStruct.Fields[4].Value.(*ListValue).Items[0].(*BoolValue).Value = false
+
+		require.Equal(t, uint8(0x42), original.Fields[0].Value.(*U8Value).Value)
+		require.Equal(t, big.NewInt(1000), original.Fields[1].Value.(*BigUIntValue).Value)
+		require.Equal(t, []byte{0x01, 0x02}, original.Fields[2].Value.(*BytesValue).Value)
+		require.True(t, original.Fields[4].Value.(*ListValue).Items[0].(*BoolValue).Value)
+	})
+
+	t.Run("should clone multi-values", func(t *testing.T) {
+		itemCreator := func() any { return &U8Value{} }
+
+		original := &MultiValue{
+			Items: []any{
+				&OptionalValue{Value: &U16Value{Value: 0x4243}},
+				&VariadicValues{Items: []any{&U8Value{Value: 0x01}}, ItemCreator: itemCreator},
+			},
+		}
+
+		cloned, err := CloneValue(original)
+		require.NoError(t, err)
+		require.True(t, EqualValues(original, cloned))
+		require.NotNil(t, cloned.(*MultiValue).Items[1].(*VariadicValues).ItemCreator)
+
+		cloned.(*MultiValue).Items[0].(*OptionalValue).Value.(*U16Value).Value = 0
+		require.Equal(t, uint16(0x4243), original.Items[0].(*OptionalValue).Value.(*U16Value).Value)
+	})
+
+	t.Run("should preserve nil slices and nil big integers", func(t *testing.T) {
+		cloned, err := CloneValue(&BytesValue{})
+		require.NoError(t, err)
+		require.Nil(t, cloned.(*BytesValue).Value)
+
+		cloned, err = CloneValue(&BigIntValue{})
+		require.NoError(t, err)
+		require.Nil(t, cloned.(*BigIntValue).Value)
+	})
+
+	t.Run("should err on unsupported type", func(t *testing.T) {
+		_, err := CloneValue(&ListValue{Items: []SingleValue{nil}})
+		require.ErrorContains(t, err, "cannot clone item 0, because of: cannot clone value of type <nil>")
+
+		_, err = CloneValue(42)
+		require.ErrorContains(t, err, "cannot clone value of type int")
+	})
+
+	t.Run("should err on nil pointers", func(t *testing.T) {
+		_, err := CloneValue((*U8Value)(nil))
+		require.ErrorContains(t, err, "cannot clone nil value of type *abi.U8Value")
+
+		_, err = CloneSingleValue((*StructValue)(nil))
+		require.ErrorContains(t, err, "cannot clone nil value of type *abi.StructValue")
+
+		_, err = CloneValue(&OptionValue{Value: (*BigUIntValue)(nil)})
+		require.ErrorContains(t, err, "cannot clone nil value of type *abi.BigUIntValue")
+
+		_, err = CloneValue(&MultiValue{Items: []any{(*List[*U8Value])(nil)}})
+		require.ErrorContains(t, err, "cannot clone item 0, because of: cannot clone nil value of type *abi.List[*github.com/multiversx/mx-sdk-abi-go/abi.U8Value]")
+	})
+}
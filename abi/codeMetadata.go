@@ -0,0 +1,56 @@
+package abi
+
+import (
+	"fmt"
+)
+
+const codeMetadataLength = 2
+
+const (
+	codeMetadataUpgradeableFlag            = byte(0x01)
+	codeMetadataReadableFlag               = byte(0x04)
+	codeMetadataPayableFlag                = byte(0x02)
+	codeMetadataPayableBySmartContractFlag = byte(0x04)
+)
+
+// CodeMetadata holds the properties of a deployed contract (set at deployment, changeable at upgrade)
+type CodeMetadata struct {
+	Upgradeable            bool
+	Readable               bool
+	Payable                bool
+	PayableBySmartContract bool
+}
+
+// Bytes returns the (two bytes) encoding of the code metadata
+func (metadata CodeMetadata) Bytes() []byte {
+	data := make([]byte, codeMetadataLength)
+
+	if metadata.Upgradeable {
+		data[0] |= codeMetadataUpgradeableFlag
+	}
+	if metadata.Readable {
+		data[0] |= codeMetadataReadableFlag
+	}
+	if metadata.Payable {
+		data[1] |= codeMetadataPayableFlag
+	}
+	if metadata.PayableBySmartContract {
+		data[1] |= codeMetadataPayableBySmartContractFlag
+	}
+
+	return data
+}
+
+// NewCodeMetadataFromBytes decodes the code metadata from its (two bytes) encoding
+func NewCodeMetadataFromBytes(data []byte) (CodeMetadata, error) {
+	if len(data) != codeMetadataLength {
+		return CodeMetadata{}, fmt.Errorf("bad code metadata: expected %d bytes, got %d", codeMetadataLength, len(data))
+	}
+
+	return CodeMetadata{
+		Upgradeable:            data[0]&codeMetadataUpgradeableFlag != 0,
+		Readable:               data[0]&codeMetadataReadableFlag != 0,
+		Payable:                data[1]&codeMetadataPayableFlag != 0,
+		PayableBySmartContract: data[1]&codeMetadataPayableBySmartContractFlag != 0,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeMetadata(t *testing.T) {
+	t.Run("should encode", func(t *testing.T) {
+		require.Equal(t, []byte{0x00, 0x00}, CodeMetadata{}.Bytes())
+		require.Equal(t, []byte{0x01, 0x00}, CodeMetadata{Upgradeable: true}.Bytes())
+		require.Equal(t, []byte{0x05, 0x06}, CodeMetadata{Upgradeable: true, Readable: true, Payable: true, PayableBySmartContract: true}.Bytes())
+		require.Equal(t, []byte{0x04, 0x02}, CodeMetadata{Readable: true, Payable: true}.Bytes())
+	})
+
+	t.Run("should decode", func(t *testing.T) {
+		metadata, err := NewCodeMetadataFromBytes([]byte{0x05, 0x06})
+		require.NoError(t, err)
+		require.Equal(t, CodeMetadata{Upgradeable: true, Readable: true, Payable: true, PayableBySmartContract: true}, metadata)
+
+		metadata, err = NewCodeMetadataFromBytes([]byte{0x01, 0x04})
+		require.NoError(t, err)
+		require.Equal(t, CodeMetadata{Upgradeable: true, PayableBySmartContract: true}, metadata)
+	})
+
+	t.Run("should err on decode if bad length", func(t *testing.T) {
+		_, err := NewCodeMetadataFromBytes([]byte{0x01})
+		require.ErrorContains(t, err, "bad code metadata: expected 2 bytes, got 1")
+
+		_, err = NewCodeMetadataFromBytes(nil)
+		require.ErrorContains(t, err, "bad code metadata: expected 2 bytes, got 0")
+	})
+}
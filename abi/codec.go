@@ -8,10 +8,17 @@ import (
 // codec is a component which follows the rules of the MultiversX Serialization format:
 // https://docs.multiversx.com/developers/data/serialization-overview
 type codec struct {
+	// aliasInputData, if set, causes decoded byte slices and strings to share memory with the input data (instead of being copied).
+	aliasInputData bool
 }
 
-// EncodeNested encodes the given value following the nested encoding rules
+// EncodeNested encodes the given value following the nested encoding rules.
+// For values implementing AppendableValue, the output buffer is allocated exactly once.
 func (c *codec) EncodeNested(value SingleValue) ([]byte, error) {
+	if value, ok := value.(AppendableValue); ok {
+		return value.AppendNested(make([]byte, 0, value.SizeNested()))
+	}
+
 	buffer := bytes.NewBuffer(nil)
 	err := value.EncodeNested(buffer)
 	if err != nil {
@@ -21,8 +28,13 @@ func (c *codec) EncodeNested(value SingleValue) ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-// EncodeTopLevel encodes the given value following the top-level encoding rules
+// EncodeTopLevel encodes the given value following the top-level encoding rules.
+// For values implementing AppendableValue, the output buffer is allocated exactly once.
 func (c *codec) EncodeTopLevel(value SingleValue) ([]byte, error) {
+	if value, ok := value.(AppendableValue); ok {
+		return value.AppendTopLevel(make([]byte, 0, value.SizeTopLevel()))
+	}
+
 	buffer := bytes.NewBuffer(nil)
 	err := value.EncodeTopLevel(buffer)
 	if err != nil {
@@ -34,8 +46,7 @@ func (c *codec) EncodeTopLevel(value SingleValue) ([]byte, error) {
 
 // DecodeNested decodes the given data into the provided object following the nested decoding rules
 func (c *codec) DecodeNested(data []byte, value SingleValue) error {
-	reader := bytes.NewReader(data)
-	err := value.DecodeNested(reader)
+	err := decodeNestedFromCursor(newCursor(data, c.aliasInputData), value)
 	if err != nil {
 		return fmt.Errorf("cannot decode (nested) %T, because of: %w", value, err)
 	}
@@ -45,7 +56,7 @@ func (c *codec) DecodeNested(data []byte, value SingleValue) error {
 
 // DecodeTopLevel decodes the given data into the provided object following the top-level decoding rules
 func (c *codec) DecodeTopLevel(data []byte, value SingleValue) error {
-	err := value.DecodeTopLevel(data)
+	err := decodeTopLevelFromCursor(newCursor(data, c.aliasInputData), value)
 	if err != nil {
 		return fmt.Errorf("cannot decode (top-level) %T, because of: %w", value, err)
 	}
@@ -1,7 +1,10 @@
 package abi
 
 import (
+	"bytes"
 	"encoding/hex"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -56,3 +59,225 @@ func testDecodeTopLevelWithError(t *testing.T, codec *codec, encodedData string,
 
 	require.ErrorContains(t, err, expectedError)
 }
+
+func TestCodec_AppendableValues(t *testing.T) {
+	codec := &codec{}
+	pubKey := bytes.Repeat([]byte{0x42}, 32)
+
+	values := []SingleValue{
+		&U8Value{Value: 0}, &U8Value{Value: 0xff},
+		&U16Value{Value: 0}, &U16Value{Value: 0x0100}, &U16Value{Value: math.MaxUint16},
+		&U32Value{Value: 0x42}, &U32Value{Value: math.MaxUint32},
+		&U64Value{Value: 0}, &U64Value{Value: 0x0100000000}, &U64Value{Value: math.MaxUint64},
+		&I8Value{Value: 0}, &I8Value{Value: -1}, &I8Value{Value: math.MinInt8}, &I8Value{Value: math.MaxInt8},
+		&I16Value{Value: -129}, &I16Value{Value: 128}, &I16Value{Value: math.MinInt16},
+		&I32Value{Value: -256}, &I32Value{Value: 255}, &I32Value{Value: math.MaxInt32},
+		&I64Value{Value: math.MinInt64}, &I64Value{Value: math.MaxInt64}, &I64Value{Value: -32769},
+		&BigIntValue{Value: big.NewInt(0)}, &BigIntValue{Value: big.NewInt(-1)}, &BigIntValue{Value: big.NewInt(127)},
+		&BigIntValue{Value: big.NewInt(128)}, &BigIntValue{Value: big.NewInt(-128)}, &BigIntValue{Value: big.NewInt(-129)},
+		&BigIntValue{Value: big.NewInt(-256)}, &BigIntValue{Value: big.NewInt(-257)},
+		&BigIntValue{Value: big.NewInt(0).Lsh(big.NewInt(1), 100)}, &BigIntValue{Value: big.NewInt(0).Neg(big.NewInt(0).Lsh(big.NewInt(1), 100))},
+		&BigUIntValue{Value: big.NewInt(0)}, &BigUIntValue{Value: big.NewInt(255)}, &BigUIntValue{Value: big.NewInt(256)},
+		&BoolValue{Value: false}, &BoolValue{Value: true},
+		&StringValue{Value: ""}, &StringValue{Value: "hello"},
+		&BytesValue{}, &BytesValue{Value: []byte{0x01, 0x02}},
+		&AddressValue{Value: pubKey},
+		&OptionValue{}, &OptionValue{Value: &U32Value{Value: 0x42}},
+		&ListValue{}, &ListValue{Items: []SingleValue{&U16Value{Value: 1}, &StringValue{Value: "abc"}}},
+		&StructValue{Fields: []Field{{Value: &U8Value{Value: 1}}, {Value: &BigUIntValue{Value: big.NewInt(1000)}}}},
+		&EnumValue{}, &EnumValue{Discriminant: 3}, &EnumValue{Discriminant: 0, Fields: []Field{{Value: &BoolValue{Value: true}}}},
+	}
+
+	for _, value := range values {
+		appendable := value.(AppendableValue)
+
+		// Writer-based encoding is the reference
+		nestedBuffer := bytes.NewBuffer(nil)
+		require.NoError(t, value.EncodeNested(nestedBuffer))
+		topLevelBuffer := bytes.NewBuffer(nil)
+		require.NoError(t, value.EncodeTopLevel(topLevelBuffer))
+
+		require.Equal(t, nestedBuffer.Len(), appendable.SizeNested(), "%T: %v", value, value)
+		require.Equal(t, topLevelBuffer.Len(), appendable.SizeTopLevel(), "%T: %v", value, value)
+
+		nested, err := appendable.AppendNested([]byte{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{0xaa}, nestedBuffer.Bytes()...), nested, "%T: %v", value, value)
+
+		topLevel, err := appendable.AppendTopLevel([]byte{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{0xaa}, topLevelBuffer.Bytes()...), topLevel, "%T: %v", value, value)
+
+		encoded, err := codec.EncodeNested(value)
+		require.NoError(t, err)
+		require.Equal(t, nestedBuffer.Bytes(), encoded)
+		require.Equal(t, len(encoded), cap(encoded))
+	}
+
+	t.Run("should err on invalid nested values", func(t *testing.T) {
+		_, err := codec.EncodeNested(&StructValue{Fields: []Field{{Name: "to", Value: &AddressValue{Value: []byte{0x01}}}}})
+		require.ErrorContains(t, err, "cannot encode field 'to' of struct, because of: public key (address) has invalid length: 1")
+	})
+}
+
+func BenchmarkCodec_EncodeNested(b *testing.B) {
+	codec := &codec{}
+	value := createBenchmarkValue()
+
+	b.Run("with writer", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			buffer := bytes.NewBuffer(nil)
+			_ = value.EncodeNested(buffer)
+		}
+	})
+
+	b.Run("with codec (append)", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = codec.EncodeNested(value)
+		}
+	})
+
+	b.Run("with reused buffer (append)", func(b *testing.B) {
+		b.ReportAllocs()
+		buffer := make([]byte, 0, value.SizeNested())
+
+		for i := 0; i < b.N; i++ {
+			buffer, _ = value.AppendNested(buffer[:0])
+		}
+	})
+}
+
+// createBenchmarkValue creates a list of 100 structs (think of a list of token payments)
+func createBenchmarkValue() *ListValue {
+	items := make([]SingleValue, 0, 100)
+
+	for i := 0; i < 100; i++ {
+		items = append(items, &StructValue{
+			Fields: []Field{
+				{Name: "token_identifier", Value: &StringValue{Value: "TEST-abcdef"}},
+				{Name: "token_nonce", Value: &U64Value{Value: uint64(i)}},
+				{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(int64(i) * 1_000_000_000)}},
+			},
+		})
+	}
+
+	return &ListValue{Items: items}
+}
+
+func TestCodec_DecodeWithReaderAndCursor(t *testing.T) {
+	codec := &codec{}
+
+	// The io.Reader-based decoding (public API of values) and the cursor-based decoding (used by the codec) should be equivalent
+	createPlaceholder := func() SingleValue {
+		return &StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U16Value{}},
+				{Name: "b", Value: &I32Value{}},
+				{Name: "c", Value: &BigIntValue{}},
+				{Name: "d", Value: &StringValue{}},
+				{Name: "e", Value: &OptionValue{Value: &AddressValue{}}},
+				{Name: "f", Value: &ListValue{ItemCreator: func() SingleValue { return &BoolValue{} }}},
+				{Name: "g", Value: &EnumValue{FieldsProvider: func(uint8) []Field { return []Field{{Value: &BytesValue{}}} }}},
+			},
+		}
+	}
+
+	value := &StructValue{
+		Fields: []Field{
+			{Name: "a", Value: &U16Value{Value: 0x4243}},
+			{Name: "b", Value: &I32Value{Value: -42}},
+			{Name: "c", Value: &BigIntValue{Value: big.NewInt(-1000)}},
+			{Name: "d", Value: &StringValue{Value: "hello"}},
+			{Name: "e", Value: &OptionValue{Value: &AddressValue{Value: bytes.Repeat([]byte{0x42}, 32)}}},
+			{Name: "f", Value: &ListValue{Items: []SingleValue{&BoolValue{Value: true}, &BoolValue{Value: false}}}},
+			{Name: "g", Value: &EnumValue{Discriminant: 1, Fields: []Field{{Value: &BytesValue{Value: []byte{0x01}}}}}},
+		},
+	}
+
+	encoded, err := codec.EncodeNested(value)
+	require.NoError(t, err)
+
+	decodedWithReader := createPlaceholder()
+	err = decodedWithReader.DecodeNested(bytes.NewReader(encoded))
+	require.NoError(t, err)
+
+	decodedWithCursor := createPlaceholder()
+	err = codec.DecodeNested(encoded, decodedWithCursor)
+	require.NoError(t, err)
+
+	require.Empty(t, DiffValues(value, decodedWithReader))
+	require.Empty(t, DiffValues(value, decodedWithCursor))
+}
+
+func TestCodec_DecodeWithAliasing(t *testing.T) {
+	codec := &codec{aliasInputData: true}
+
+	data, _ := hex.DecodeString("000000034142430000000244450000000146")
+	value := &StructValue{
+		Fields: []Field{
+			{Value: &BytesValue{}},
+			{Value: &StringValue{}},
+			{Value: &BytesValue{}},
+		},
+	}
+
+	err := codec.DecodeNested(data, value)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ABC"), value.Fields[0].Value.(*BytesValue).Value)
+	require.Equal(t, "DE", value.Fields[1].Value.(*StringValue).Value)
+	require.Equal(t, []byte("F"), value.Fields[2].Value.(*BytesValue).Value)
+
+	// Decoded values share memory with the input data
+	data[4] = 'X'
+	data[17] = 'Y'
+	require.Equal(t, []byte("XBC"), value.Fields[0].Value.(*BytesValue).Value)
+	require.Equal(t, []byte("Y"), value.Fields[2].Value.(*BytesValue).Value)
+}
+
+func BenchmarkCodec_DecodeNested(b *testing.B) {
+	encoded, _ := (&codec{}).EncodeNested(createBenchmarkValue())
+
+	createPlaceholder := func() *ListValue {
+		return &ListValue{
+			ItemCreator: func() SingleValue {
+				return &StructValue{
+					Fields: []Field{
+						{Name: "token_identifier", Value: &StringValue{}},
+						{Name: "token_nonce", Value: &U64Value{}},
+						{Name: "amount", Value: &BigUIntValue{}},
+					},
+				}
+			},
+		}
+	}
+
+	b.Run("with reader", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = createPlaceholder().DecodeNested(bytes.NewReader(encoded))
+		}
+	})
+
+	b.Run("with codec (cursor)", func(b *testing.B) {
+		b.ReportAllocs()
+		codec := &codec{}
+
+		for i := 0; i < b.N; i++ {
+			_ = codec.DecodeNested(encoded, createPlaceholder())
+		}
+	})
+
+	b.Run("with codec (cursor, aliasing)", func(b *testing.B) {
+		b.ReportAllocs()
+		codec := &codec{aliasInputData: true}
+
+		for i := 0; i < b.N; i++ {
+			_ = codec.DecodeNested(encoded, createPlaceholder())
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// ValueDifference describes a difference between two value trees, at a given path.
+// The path is built from field names (e.g. ".amount"), item indices (e.g. "[2]") and
+// markers such as ".discriminant" (enums) or ".value" (options, optional values).
+type ValueDifference struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// String returns a human-readable description of the difference
+func (difference ValueDifference) String() string {
+	return fmt.Sprintf("%s: expected %s, actual %s", difference.Path, difference.Expected, difference.Actual)
+}
+
+// EqualValues returns true if the given value trees (single values or multi-values) hold the same data.
+// Big integers are compared by value, while nil and empty slices are considered equal.
+func EqualValues(expected any, actual any) bool {
+	return len(DiffValues(expected, actual)) == 0
+}
+
+// DiffValues compares the given value trees (single values or multi-values) and returns their differences.
+// Big integers are compared by value, while nil and empty slices are considered equal.
+func DiffValues(expected any, actual any) []ValueDifference {
+	differences := make([]ValueDifference, 0)
+	diffValues(&differences, "$", expected, actual)
+	return differences
+}
+
+func diffValues(differences *[]ValueDifference, path string, expected any, actual any) {
+	// Typed nil pointers (e.g. (*U8Value)(nil)) are handled as nil values (of the given type)
+	expectedIsNil := expected == nil || isNilPointer(expected)
+	actualIsNil := actual == nil || isNilPointer(actual)
+
+	if expectedIsNil || actualIsNil {
+		if expectedIsNil && actualIsNil && expected != nil && actual != nil && reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+			addDifference(differences, path, fmt.Sprintf("%T", expected), fmt.Sprintf("%T", actual))
+		} else if !expectedIsNil || !actualIsNil {
+			addDifference(differences, path, describeValueForDiff(expected), describeValueForDiff(actual))
+		}
+
+		return
+	}
+
+	// Generic values are compared by means of their untyped counterparts
+	expected = untypedView(expected)
+	actual = untypedView(actual)
+
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		addDifference(differences, path, fmt.Sprintf("%T", expected), fmt.Sprintf("%T", actual))
+		return
+	}
+
+	switch expected := expected.(type) {
+	case *OptionalValue:
+		diffValues(differences, path+".value", expected.Value, actual.(*OptionalValue).Value)
+	case *MultiValue:
+		diffItems(differences, path, expected.Items, actual.(*MultiValue).Items)
+	case *VariadicValues:
+		diffItems(differences, path, expected.Items, actual.(*VariadicValues).Items)
+	case *OptionValue:
+		diffValues(differences, path+".value", singleValueAsAny(expected.Value), singleValueAsAny(actual.(*OptionValue).Value))
+	case *ListValue:
+		diffItems(differences, path, singleValuesAsAny(expected.Items), singleValuesAsAny(actual.(*ListValue).Items))
+	case *StructValue:
+		diffFields(differences, path, expected.Fields, actual.(*StructValue).Fields)
+	case *EnumValue:
+		actual := actual.(*EnumValue)
+		if expected.Discriminant != actual.Discriminant {
+			addDifference(differences, path+".discriminant", fmt.Sprint(expected.Discriminant), fmt.Sprint(actual.Discriminant))
+			return
+		}
+
+		diffFields(differences, path, expected.Fields, actual.Fields)
+	default:
+		if !equalPrimitiveValues(expected, actual) {
+			addDifference(differences, path, describeValueForDiff(expected), describeValueForDiff(actual))
+		}
+	}
+}
+
+func diffItems(differences *[]ValueDifference, path string, expected []any, actual []any) {
+	if len(expected) != len(actual) {
+		addDifference(differences, path+".length", fmt.Sprint(len(expected)), fmt.Sprint(len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		diffValues(differences, fmt.Sprintf("%s[%d]", path, i), expected[i], actual[i])
+	}
+}
+
+func diffFields(differences *[]ValueDifference, path string, expected []Field, actual []Field) {
+	if len(expected) != len(actual) {
+		addDifference(differences, path+".fields.length", fmt.Sprint(len(expected)), fmt.Sprint(len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		fieldPath := pathOfField(path, i, expected[i].Name)
+
+		if expected[i].Name != actual[i].Name {
+			addDifference(differences, fieldPath+".name", fmt.Sprintf("%q", expected[i].Name), fmt.Sprintf("%q", actual[i].Name))
+		}
+
+		diffValues(differences, fieldPath, singleValueAsAny(expected[i].Value), singleValueAsAny(actual[i].Value))
+	}
+}
+
+// pathOfField returns the path of a field: by name, if available, or by index otherwise
+func pathOfField(path string, index int, name string) string {
+	if name != "" {
+		return fmt.Sprintf("%s.%s", path, name)
+	}
+
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// equalPrimitiveValues compares values of the same type, which do not hold other values
+func equalPrimitiveValues(expected any, actual any) bool {
+	switch expected := expected.(type) {
+	case *BigIntValue:
+		return equalBigInts(expected.Value, actual.(*BigIntValue).Value)
+	case *BigUIntValue:
+		return equalBigInts(expected.Value, actual.(*BigUIntValue).Value)
+	case *BytesValue:
+		return equalBytes(expected.Value, actual.(*BytesValue).Value)
+	case *AddressValue:
+		return equalBytes(expected.Value, actual.(*AddressValue).Value)
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
+
+func equalBigInts(expected *big.Int, actual *big.Int) bool {
+	if expected == nil || actual == nil {
+		return expected == nil && actual == nil
+	}
+
+	return expected.Cmp(actual) == 0
+}
+
+func equalBytes(expected []byte, actual []byte) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addDifference(differences *[]ValueDifference, path string, expected string, actual string) {
+	*differences = append(*differences, ValueDifference{
+		Path:     path,
+		Expected: expected,
+		Actual:   actual,
+	})
+}
+
+// describeValueForDiff returns a short description of a value, to be used in differences
+func describeValueForDiff(value any) string {
+	if isNilPointer(value) {
+		return "nil"
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return "nil"
+	case *U8Value:
+		return fmt.Sprint(value.Value)
+	case *U16Value:
+		return fmt.Sprint(value.Value)
+	case *U32Value:
+		return fmt.Sprint(value.Value)
+	case *U64Value:
+		return fmt.Sprint(value.Value)
+	case *I8Value:
+		return fmt.Sprint(value.Value)
+	case *I16Value:
+		return fmt.Sprint(value.Value)
+	case *I32Value:
+		return fmt.Sprint(value.Value)
+	case *I64Value:
+		return fmt.Sprint(value.Value)
+	case *BigIntValue:
+		return describeBigIntForDiff(value.Value)
+	case *BigUIntValue:
+		return describeBigIntForDiff(value.Value)
+	case *BoolValue:
+		return fmt.Sprint(value.Value)
+	case *StringValue:
+		return fmt.Sprintf("%q", value.Value)
+	case *BytesValue:
+		return "0x" + hex.EncodeToString(value.Value)
+	case *AddressValue:
+		return "0x" + hex.EncodeToString(value.Value)
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func describeBigIntForDiff(value *big.Int) string {
+	if value == nil {
+		return "nil"
+	}
+
+	return value.String()
+}
+
+// singleValueAsAny converts a (possibly nil) single value to "any", so that a nil single value becomes an untyped nil.
+func singleValueAsAny(value SingleValue) any {
+	if value == nil {
+		return nil
+	}
+
+	return value
+}
+
+func singleValuesAsAny(values []SingleValue) []any {
+	result := make([]any, len(values))
+
+	for i, value := range values {
+		result[i] = singleValueAsAny(value)
+	}
+
+	return result
+}
@@ -0,0 +1,115 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualValues(t *testing.T) {
+	t.Run("should compare big integers by value", func(t *testing.T) {
+		a := &BigUIntValue{Value: big.NewInt(42)}
+		b := &BigUIntValue{Value: big.NewInt(0).SetBytes([]byte{0x2a})}
+
+		require.True(t, EqualValues(a, b))
+		require.False(t, EqualValues(a, &BigUIntValue{Value: big.NewInt(43)}))
+		require.False(t, EqualValues(a, &BigUIntValue{}))
+		require.True(t, EqualValues(&BigIntValue{}, &BigIntValue{}))
+	})
+
+	t.Run("should consider nil and empty slices equal", func(t *testing.T) {
+		require.True(t, EqualValues(&BytesValue{}, &BytesValue{Value: []byte{}}))
+		require.True(t, EqualValues(&ListValue{}, &ListValue{Items: []SingleValue{}}))
+		require.True(t, EqualValues(&StructValue{}, &StructValue{Fields: []Field{}}))
+		require.True(t, EqualValues(&MultiValue{}, &MultiValue{Items: []any{}}))
+	})
+
+	t.Run("should ignore item creators and fields providers", func(t *testing.T) {
+		a := &ListValue{Items: []SingleValue{&U8Value{Value: 1}}}
+		b := &ListValue{Items: []SingleValue{&U8Value{Value: 1}}, ItemCreator: func() SingleValue { return &U8Value{} }}
+
+		require.True(t, EqualValues(a, b))
+	})
+
+	t.Run("should detect different types", func(t *testing.T) {
+		require.False(t, EqualValues(&U8Value{Value: 1}, &U16Value{Value: 1}))
+		require.False(t, EqualValues(&U8Value{Value: 1}, nil))
+		require.True(t, EqualValues(nil, nil))
+	})
+}
+
+func TestDiffValues(t *testing.T) {
+	t.Run("should return no differences for equal values", func(t *testing.T) {
+		value := &OptionalValue{Value: &U8Value{Value: 1}}
+		require.Empty(t, DiffValues(value, value))
+	})
+
+	t.Run("should return differences, with paths", func(t *testing.T) {
+		expected := []any{
+			&StructValue{
+				Fields: []Field{
+					{Name: "to", Value: &AddressValue{Value: []byte{0x01, 0x02}}},
+					{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1000)}},
+					{Name: "tokens", Value: &ListValue{Items: []SingleValue{&StringValue{Value: "FOO"}, &StringValue{Value: "BAR"}}}},
+					{Value: &OptionValue{Value: &BoolValue{Value: true}}},
+				},
+			},
+			&EnumValue{Discriminant: 1},
+		}
+
+		actual := []any{
+			&StructValue{
+				Fields: []Field{
+					{Name: "to", Value: &AddressValue{Value: []byte{0x01, 0x02}}},
+					{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(999)}},
+					{Name: "tokens", Value: &ListValue{Items: []SingleValue{&StringValue{Value: "FOO"}, &StringValue{Value: "BAZ"}, &StringValue{Value: "QUX"}}}},
+					{Value: &OptionValue{}},
+				},
+			},
+			&EnumValue{Discriminant: 2},
+		}
+
+		differences := DiffValues(&MultiValue{Items: expected}, &MultiValue{Items: actual})
+
+		require.Equal(t, []ValueDifference{
+			{Path: "$[0].amount", Expected: "1000", Actual: "999"},
+			{Path: "$[0].tokens.length", Expected: "2", Actual: "3"},
+			{Path: "$[0].tokens[1]", Expected: `"BAR"`, Actual: `"BAZ"`},
+			{Path: "$[0].3.value", Expected: "true", Actual: "nil"},
+			{Path: "$[1].discriminant", Expected: "1", Actual: "2"},
+		}, differences)
+
+		require.Equal(t, "$[0].amount: expected 1000, actual 999", differences[0].String())
+	})
+
+	t.Run("should report different types and field names", func(t *testing.T) {
+		differences := DiffValues(
+			&StructValue{Fields: []Field{{Name: "a", Value: &U8Value{}}, {Name: "b", Value: &BytesValue{Value: []byte{0xab}}}}},
+			&StructValue{Fields: []Field{{Name: "x", Value: &U8Value{}}, {Name: "b", Value: &StringValue{}}}},
+		)
+
+		require.Equal(t, []ValueDifference{
+			{Path: "$.a.name", Expected: `"a"`, Actual: `"x"`},
+			{Path: "$.b", Expected: "*abi.BytesValue", Actual: "*abi.StringValue"},
+		}, differences)
+	})
+
+	t.Run("should handle nil pointers", func(t *testing.T) {
+		require.Empty(t, DiffValues((*U8Value)(nil), (*U8Value)(nil)))
+		require.Empty(t, DiffValues((*U8Value)(nil), nil))
+
+		differences := DiffValues(
+			&StructValue{Fields: []Field{{Name: "a", Value: (*U8Value)(nil)}, {Name: "b", Value: &U8Value{Value: 1}}, {Name: "c", Value: (*U8Value)(nil)}}},
+			&StructValue{Fields: []Field{{Name: "a", Value: &U8Value{Value: 1}}, {Name: "b", Value: (*U8Value)(nil)}, {Name: "c", Value: (*U16Value)(nil)}}},
+		)
+
+		require.Equal(t, []ValueDifference{
+			{Path: "$.a", Expected: "nil", Actual: "1"},
+			{Path: "$.b", Expected: "1", Actual: "nil"},
+			{Path: "$.c", Expected: "*abi.U8Value", Actual: "*abi.U16Value"},
+		}, differences)
+
+		require.Len(t, DiffValues(&MultiValue{Items: []any{(*List[*U8Value])(nil)}}, &MultiValue{Items: []any{&List[*U8Value]{}}}), 1)
+	})
+}
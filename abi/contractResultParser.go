@@ -0,0 +1,118 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParsedContractResult is the outcome of parsing the data field of a smart contract result (SCR)
+type ParsedContractResult struct {
+	ReturnCode ReturnCode
+	// ReturnCodeText is the return code, as found in the data (useful if the return code is unknown)
+	ReturnCodeText string
+	// ReturnMessage is the error message (empty on success)
+	ReturnMessage string
+	// RawValues holds the (hex-decoded) parts following the return code (on success) or the message (on failure)
+	RawValues [][]byte
+	// Values holds the decoded outputs of the endpoint, named after them (only on success, and only if the endpoint is known)
+	Values []NamedValue
+}
+
+// IsSuccess returns whether the return code is "ok"
+func (result *ParsedContractResult) IsSuccess() bool {
+	return result.ReturnCode == ReturnCodeOk
+}
+
+// ArgsNewContractResultParser defines the arguments needed for a new contract result parser
+type ArgsNewContractResultParser struct {
+	// Abi is optional: it's only needed for decoding the outputs of endpoints
+	Abi *Definition
+}
+
+// contractResultParser parses the data field of smart contract results: "@6f6b@value1@value2..." on success,
+// "@returnCode@message" on failure (e.g. "@75736572206572726f72@..." for "user error").
+type contractResultParser struct {
+	callDataParser *callDataParser
+	abi            *Definition
+}
+
+// NewContractResultParser creates a new contract result parser
+func NewContractResultParser(args ArgsNewContractResultParser) (*contractResultParser, error) {
+	callDataParser, err := NewCallDataParser(ArgsNewCallDataParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &contractResultParser{
+		callDataParser: callDataParser,
+		abi:            args.Abi,
+	}, nil
+}
+
+// Parse splits the given data field into the return code, the error message (if any) and the raw values
+func (parser *contractResultParser) Parse(data string) (*ParsedContractResult, error) {
+	if !strings.HasPrefix(data, callDataPartsSeparator) {
+		return nil, fmt.Errorf("missing leading separator: %q", data)
+	}
+
+	parts, err := parser.callDataParser.serializer.decodeIntoParts(data[len(callDataPartsSeparator):])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode contract result: %w", err)
+	}
+
+	returnCodeText := string(parts[0])
+	parsed := &ParsedContractResult{
+		ReturnCode:     ParseReturnCode(returnCodeText),
+		ReturnCodeText: returnCodeText,
+		RawValues:      parts[1:],
+	}
+
+	if !parsed.IsSuccess() && len(parsed.RawValues) > 0 {
+		parsed.ReturnMessage = string(parsed.RawValues[0])
+		parsed.RawValues = parsed.RawValues[1:]
+	}
+
+	return parsed, nil
+}
+
+// ParseEndpointResult parses the given data field, then (on success) decodes the values according to the outputs of the endpoint.
+// On failure, the values are not decoded (and no error is returned): the return code and the message should be inspected.
+func (parser *contractResultParser) ParseEndpointResult(endpointName string, data string) (*ParsedContractResult, error) {
+	parsed, err := parser.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parsed.IsSuccess() {
+		return parsed, nil
+	}
+
+	parsed.Values, err = parser.decodeOutputs(endpointName, parsed.RawValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// decodeOutputs decodes the raw values according to the outputs of the endpoint; all the values must be consumed
+func (parser *contractResultParser) decodeOutputs(endpointName string, rawValues [][]byte) ([]NamedValue, error) {
+	if parser.abi == nil {
+		return nil, errors.New("cannot decode outputs without an ABI")
+	}
+
+	endpoint, err := parser.abi.GetEndpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parser.callDataParser.decodeExactly(rawValues, endpoint.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode outputs of endpoint '%s': %w", endpointName, err)
+	}
+
+	return nameValues(values, endpoint.Outputs), nil
+}
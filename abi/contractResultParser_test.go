@@ -0,0 +1,94 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractResultParser_Parse(t *testing.T) {
+	parser, err := NewContractResultParser(ArgsNewContractResultParser{})
+	require.NoError(t, err)
+
+	t.Run("on success", func(t *testing.T) {
+		parsed, err := parser.Parse("@6f6b@2a@616263")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedContractResult{
+			ReturnCode:     ReturnCodeOk,
+			ReturnCodeText: "ok",
+			RawValues:      [][]byte{{0x2a}, []byte("abc")},
+		}, parsed)
+		require.True(t, parsed.IsSuccess())
+
+		parsed, err = parser.Parse("@6f6b")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeOk, parsed.ReturnCode)
+		require.Empty(t, parsed.RawValues)
+	})
+
+	t.Run("on failure", func(t *testing.T) {
+		// "user error", "not enough funds"
+		parsed, err := parser.Parse("@75736572206572726f72@6e6f7420656e6f7567682066756e6473")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedContractResult{
+			ReturnCode:     ReturnCodeUserError,
+			ReturnCodeText: "user error",
+			ReturnMessage:  "not enough funds",
+			RawValues:      [][]byte{},
+		}, parsed)
+		require.False(t, parsed.IsSuccess())
+
+		// "out of funds", without message
+		parsed, err = parser.Parse("@6f7574206f662066756e6473")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeOutOfFunds, parsed.ReturnCode)
+		require.Equal(t, "", parsed.ReturnMessage)
+
+		// "something else", "message"
+		parsed, err = parser.Parse("@736f6d657468696e6720656c7365@6d657373616765")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeUnknown, parsed.ReturnCode)
+		require.Equal(t, "something else", parsed.ReturnCodeText)
+		require.Equal(t, "message", parsed.ReturnMessage)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		_, err := parser.Parse("6f6b@2a")
+		require.ErrorContains(t, err, `missing leading separator: "6f6b@2a"`)
+
+		_, err = parser.Parse("@6f6b@2")
+		require.ErrorContains(t, err, "cannot decode contract result")
+	})
+}
+
+func TestContractResultParser_ParseEndpointResult(t *testing.T) {
+	t.Run("with ABI", func(t *testing.T) {
+		parser, err := NewContractResultParser(ArgsNewContractResultParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseEndpointResult("getSum", "@6f6b@2a")
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Values)
+
+		// On failure, outputs aren't decoded.
+		parsed, err = parser.ParseEndpointResult("getSum", "@75736572206572726f72@6d657373616765")
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeUserError, parsed.ReturnCode)
+		require.Nil(t, parsed.Values)
+
+		_, err = parser.ParseEndpointResult("getSum", "@6f6b@2a@2b")
+		require.ErrorContains(t, err, "cannot decode outputs of endpoint 'getSum': too many parts: expected 1, got 2")
+
+		_, err = parser.ParseEndpointResult("missing", "@6f6b@2a")
+		require.ErrorContains(t, err, "endpoint not found: missing")
+	})
+
+	t.Run("without ABI", func(t *testing.T) {
+		parser, err := NewContractResultParser(ArgsNewContractResultParser{})
+		require.NoError(t, err)
+
+		_, err = parser.ParseEndpointResult("getSum", "@6f6b@2a")
+		require.ErrorContains(t, err, "cannot decode outputs without an ABI")
+	})
+}
@@ -0,0 +1,153 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// cursor reads from a byte slice, by keeping track of an offset (the data itself is never copied by the cursor).
+// If aliasing is enabled, the decoded byte slices and strings share memory with the input data (instead of being copied).
+// The cursor also implements io.Reader, so that it can be handed to values which only support io.Reader-based decoding.
+type cursor struct {
+	data     []byte
+	offset   int
+	aliasing bool
+}
+
+// cursorDecodableValue is implemented by single values which support slice-based (cursor-based) decoding.
+type cursorDecodableValue interface {
+	SingleValue
+	decodeNested(c *cursor) error
+	decodeTopLevel(c *cursor) error
+}
+
+func newCursor(data []byte, aliasing bool) *cursor {
+	return &cursor{
+		data:     data,
+		offset:   0,
+		aliasing: aliasing,
+	}
+}
+
+// Read implements io.Reader
+func (c *cursor) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if c.isEmpty() {
+		return 0, io.EOF
+	}
+
+	n := copy(p, c.data[c.offset:])
+	c.offset += n
+	return n, nil
+}
+
+func (c *cursor) remaining() int {
+	return len(c.data) - c.offset
+}
+
+func (c *cursor) isEmpty() bool {
+	return c.remaining() == 0
+}
+
+// readSlice reads the next "numBytes" bytes, as a sub-slice of the underlying data (regardless of aliasing).
+func (c *cursor) readSlice(numBytes int) ([]byte, error) {
+	if numBytes < 0 || numBytes > c.remaining() {
+		return nil, fmt.Errorf("cannot read exactly %d bytes", numBytes)
+	}
+
+	slice := c.data[c.offset : c.offset+numBytes : c.offset+numBytes]
+	c.offset += numBytes
+	return slice, nil
+}
+
+// readRemainingSlice reads all the remaining bytes, as a sub-slice of the underlying data (regardless of aliasing).
+func (c *cursor) readRemainingSlice() []byte {
+	slice, _ := c.readSlice(c.remaining())
+	return slice
+}
+
+// readBytes reads the next "numBytes" bytes: they are copied, unless aliasing is enabled.
+func (c *cursor) readBytes(numBytes int) ([]byte, error) {
+	slice, err := c.readSlice(numBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.aliasing {
+		return slice, nil
+	}
+
+	return append(make([]byte, 0, numBytes), slice...), nil
+}
+
+// readString reads the next "numBytes" bytes, as a string: they are copied, unless aliasing is enabled.
+func (c *cursor) readString(numBytes int) (string, error) {
+	slice, err := c.readSlice(numBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return c.sliceToString(slice), nil
+}
+
+func (c *cursor) sliceToString(slice []byte) string {
+	if c.aliasing && len(slice) > 0 {
+		return unsafe.String(&slice[0], len(slice))
+	}
+
+	return string(slice)
+}
+
+func (c *cursor) readByte() (byte, error) {
+	if c.isEmpty() {
+		return 0, fmt.Errorf("cannot read exactly %d bytes", 1)
+	}
+
+	b := c.data[c.offset]
+	c.offset++
+	return b, nil
+}
+
+func (c *cursor) readLength() (uint32, error) {
+	slice, err := c.readSlice(lengthPrefixSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(slice), nil
+}
+
+// capacityForItems returns the capacity to preallocate for a list of "length" items (as read from the data).
+// Since the length might be bogus (e.g. when analyzing data of unknown type), it is bounded by the number of remaining bytes.
+func (c *cursor) capacityForItems(length uint32) int {
+	if int64(length) > int64(c.remaining()) {
+		return c.remaining()
+	}
+
+	return int(length)
+}
+
+// decodeNestedFromCursor decodes a value (nested form) from the cursor.
+// For values which do not support cursor-based decoding, it falls back to io.Reader-based decoding.
+func decodeNestedFromCursor(c *cursor, value SingleValue) error {
+	if value, ok := value.(cursorDecodableValue); ok {
+		return value.decodeNested(c)
+	}
+
+	return value.DecodeNested(c)
+}
+
+// decodeTopLevelFromCursor decodes a value (top-level form) from the remaining data of the cursor.
+// For values which do not support cursor-based decoding, it falls back to slice-based decoding (without aliasing control).
+func decodeTopLevelFromCursor(c *cursor, value SingleValue) error {
+	if value, ok := value.(cursorDecodableValue); ok {
+		return value.decodeTopLevel(c)
+	}
+
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
@@ -0,0 +1,93 @@
+package abi
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("should read slices, bytes and strings", func(t *testing.T) {
+		data := []byte{0x00, 0x00, 0x00, 0x02, 'h', 'i', 0x07, 'a', 'b'}
+		c := newCursor(data, false)
+
+		length, err := c.readLength()
+		require.NoError(t, err)
+		require.Equal(t, uint32(2), length)
+
+		str, err := c.readString(int(length))
+		require.NoError(t, err)
+		require.Equal(t, "hi", str)
+
+		b, err := c.readByte()
+		require.NoError(t, err)
+		require.Equal(t, byte(0x07), b)
+		require.Equal(t, 2, c.remaining())
+
+		readBytes, err := c.readBytes(2)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ab"), readBytes)
+		require.True(t, c.isEmpty())
+
+		// Bytes have been copied
+		data[7] = 'x'
+		require.Equal(t, []byte("ab"), readBytes)
+	})
+
+	t.Run("should alias input data, if enabled", func(t *testing.T) {
+		data := []byte("abcd")
+		c := newCursor(data, true)
+
+		readBytes, err := c.readBytes(2)
+		require.NoError(t, err)
+		str, err := c.readString(2)
+		require.NoError(t, err)
+
+		data[0] = 'x'
+		data[2] = 'y'
+		require.Equal(t, []byte("xb"), readBytes)
+		require.Equal(t, "yd", str)
+	})
+
+	t.Run("should err when reading beyond the end of data", func(t *testing.T) {
+		c := newCursor([]byte{0x01, 0x02}, false)
+
+		_, err := c.readSlice(3)
+		require.ErrorContains(t, err, "cannot read exactly 3 bytes")
+
+		_, err = c.readLength()
+		require.ErrorContains(t, err, "cannot read exactly 4 bytes")
+
+		_, _ = c.readSlice(2)
+		_, err = c.readByte()
+		require.ErrorContains(t, err, "cannot read exactly 1 bytes")
+	})
+
+	t.Run("should implement io.Reader", func(t *testing.T) {
+		c := newCursor([]byte{0x01, 0x02, 0x03}, false)
+		buffer := make([]byte, 2)
+
+		n, err := c.Read(buffer)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		n, err = c.Read(buffer)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		_, err = c.Read(buffer)
+		require.Equal(t, io.EOF, err)
+	})
+
+	t.Run("should bound capacity for items by the remaining bytes", func(t *testing.T) {
+		c := newCursor([]byte{0x01, 0x02, 0x03}, false)
+		require.Equal(t, 2, c.capacityForItems(2))
+		require.Equal(t, 3, c.capacityForItems(0xffffffff))
+
+		// A bogus length must not cause a huge allocation
+		list := &ListValue{ItemCreator: func() SingleValue { return &U8Value{} }}
+		err := (&codec{}).DecodeNested([]byte{0xff, 0xff, 0xff, 0xff, 0x01}, list)
+		require.ErrorContains(t, err, "cannot read exactly 1 bytes")
+	})
+}
@@ -0,0 +1,121 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type decodingPlan struct {
+	roots          []multiValuePlan
+	partsSeparator string
+	aliasInputData bool
+}
+
+// ArgsNewDecodingPlan defines the arguments needed for a new decoding plan
+type ArgsNewDecodingPlan struct {
+	// OutputValues are placeholders describing the shape of the values to decode (as for serializer.Deserialize).
+	// They are only inspected when creating the plan, and are never modified.
+	OutputValues   []any
+	PartsSeparator string
+	// AliasInputData, if set, causes decoded byte slices and strings to share memory with the parts given to DecodeParts.
+	// Parts must not be modified afterwards, if this option is set.
+	// Decode always aliases its own (internal) buffer, since it is not visible to the caller.
+	AliasInputData bool
+}
+
+// NewDecodingPlan creates a decoding plan, which is a compiled form of the given output values (placeholders).
+// The plan can be used to repeatedly decode data of the same shape, with fewer allocations than serializer.Deserialize.
+// Each decoding produces new values. The plan is safe for concurrent use.
+func NewDecodingPlan(args ArgsNewDecodingPlan) (*decodingPlan, error) {
+	if args.PartsSeparator == "" {
+		return nil, errors.New("cannot create decoding plan: parts separator must not be empty")
+	}
+
+	roots, err := compileMultiValuePlans(args.OutputValues, positionOfLastValues)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create decoding plan: %w", err)
+	}
+
+	return &decodingPlan{
+		roots:          roots,
+		partsSeparator: args.PartsSeparator,
+		aliasInputData: args.AliasInputData,
+	}, nil
+}
+
+// Decode decodes the given data (hex-encoded parts, separated by the parts separator) into new values
+func (plan *decodingPlan) Decode(data string) ([]any, error) {
+	parts, err := plan.decodeIntoParts(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.decodeParts(parts, true)
+}
+
+// DecodeParts decodes the given (raw) parts into new values
+func (plan *decodingPlan) DecodeParts(parts [][]byte) ([]any, error) {
+	return plan.decodeParts(parts, plan.aliasInputData)
+}
+
+func (plan *decodingPlan) decodeParts(parts [][]byte, aliasInputData bool) ([]any, error) {
+	partsHolder := newPartsHolder(parts)
+
+	// A shallow copy of the plan, so that the aliasing flag is specific to this decoding.
+	planForDecoding := *plan
+	planForDecoding.aliasInputData = aliasInputData
+
+	return planForDecoding.decodeItems(plan.roots, partsHolder)
+}
+
+func (plan *decodingPlan) decodeItems(items []multiValuePlan, partsHolder *partsHolder) ([]any, error) {
+	values := make([]any, len(items))
+
+	for i, item := range items {
+		value, err := item.decodeParts(plan, partsHolder)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// decodeIntoParts decodes the hex-encoded parts into a single (shared) buffer, to save allocations
+func (plan *decodingPlan) decodeIntoParts(encoded string) ([][]byte, error) {
+	numParts := strings.Count(encoded, plan.partsSeparator) + 1
+	parts := make([][]byte, 0, numParts)
+
+	source := []byte(encoded)
+	buffer := make([]byte, len(encoded)/2)
+	offset := 0
+
+	for {
+		end := strings.Index(encoded, plan.partsSeparator)
+		if end < 0 {
+			end = len(encoded)
+		}
+
+		partHex := source[:end]
+		n, err := hex.Decode(buffer[offset:], partHex)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, buffer[offset:offset+n:offset+n])
+		offset += n
+
+		if end == len(encoded) {
+			break
+		}
+
+		encoded = encoded[end+len(plan.partsSeparator):]
+		source = source[end+len(plan.partsSeparator):]
+	}
+
+	return parts, nil
+}
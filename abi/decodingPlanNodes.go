@@ -0,0 +1,751 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// singleValuePlan knows how to decode a single value (of a given shape), creating a new value on each decoding.
+type singleValuePlan interface {
+	decodeNested(c *cursor) (SingleValue, error)
+	decodeTopLevel(c *cursor) (SingleValue, error)
+}
+
+// multiValuePlan knows how to decode a (possibly multi-part) value from parts, creating a new value on each decoding.
+type multiValuePlan interface {
+	decodeParts(plan *decodingPlan, partsHolder *partsHolder) (any, error)
+}
+
+// compileSingleValuePlan creates the plan of a single value, given a placeholder (as used for regular decoding)
+func compileSingleValuePlan(placeholder SingleValue) (singleValuePlan, error) {
+	switch placeholder := placeholder.(type) {
+	case *U8Value:
+		return newLeafPlan[U8Value](), nil
+	case *U16Value:
+		return newLeafPlan[U16Value](), nil
+	case *U32Value:
+		return newLeafPlan[U32Value](), nil
+	case *U64Value:
+		return newLeafPlan[U64Value](), nil
+	case *I8Value:
+		return newLeafPlan[I8Value](), nil
+	case *I16Value:
+		return newLeafPlan[I16Value](), nil
+	case *I32Value:
+		return newLeafPlan[I32Value](), nil
+	case *I64Value:
+		return newLeafPlan[I64Value](), nil
+	case *BigIntValue:
+		return newLeafPlan[BigIntValue](), nil
+	case *BigUIntValue:
+		return newLeafPlan[BigUIntValue](), nil
+	case *BoolValue:
+		return newLeafPlan[BoolValue](), nil
+	case *StringValue:
+		return newLeafPlan[StringValue](), nil
+	case *BytesValue:
+		return newLeafPlan[BytesValue](), nil
+	case *AddressValue:
+		return newLeafPlan[AddressValue](), nil
+	case *OptionValue:
+		if placeholder.Value == nil {
+			return nil, errors.New("placeholder value of option should be set before decoding")
+		}
+
+		inner, err := compileSingleValuePlan(placeholder.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &optionPlan{inner: inner}, nil
+	case *ListValue:
+		if placeholder.ItemCreator == nil {
+			return nil, errors.New("cannot compile list: item creator is nil")
+		}
+
+		item, err := compileSingleValuePlan(placeholder.ItemCreator())
+		if err != nil {
+			return nil, err
+		}
+
+		return &listPlan{item: item, itemCreator: placeholder.ItemCreator}, nil
+	case *StructValue:
+		fields, err := compileFieldsPlan(placeholder.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compile struct, because of: %w", err)
+		}
+
+		return &structPlan{fields: fields}, nil
+	case *EnumValue:
+		if placeholder.FieldsProvider == nil {
+			return nil, errors.New("cannot compile enum: fields provider is nil")
+		}
+
+		return &enumPlan{
+			fieldsProvider:      placeholder.FieldsProvider,
+			variantNameProvider: placeholder.VariantNameProvider,
+			variants:            make(map[uint8]*fieldsPlan),
+		}, nil
+	case nil:
+		return nil, errors.New("cannot compile nil value")
+	default:
+		// Other single values (e.g. generic containers, or values defined outside this package)
+		// are decoded into copies of the placeholder.
+		_, err := CloneSingleValue(placeholder)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported type for decoding plan: %T", placeholder)
+		}
+
+		return &opaquePlan{prototype: placeholder}, nil
+	}
+}
+
+// minChunkSizeForTopLevelLists is the size of the first block of items allocated when decoding top-level lists
+const minChunkSizeForTopLevelLists = 8
+
+// leafPlan decodes single values which do not hold other values (e.g. integers, strings, addresses).
+// When used as items of lists, such values are allocated in blocks.
+type leafPlan[V any, PV interface {
+	*V
+	cursorDecodableValue
+}] struct {
+}
+
+func newLeafPlan[V any, PV interface {
+	*V
+	cursorDecodableValue
+}]() *leafPlan[V, PV] {
+	return &leafPlan[V, PV]{}
+}
+
+func (plan *leafPlan[V, PV]) decodeNested(c *cursor) (SingleValue, error) {
+	value := PV(new(V))
+	err := value.decodeNested(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (plan *leafPlan[V, PV]) decodeTopLevel(c *cursor) (SingleValue, error) {
+	value := PV(new(V))
+	err := value.decodeTopLevel(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// newValues creates "numValues" (empty) values, allocated as a single block
+func (plan *leafPlan[V, PV]) newValues(numValues int) []SingleValue {
+	block := make([]V, numValues)
+	values := make([]SingleValue, numValues)
+
+	for i := range block {
+		values[i] = PV(&block[i])
+	}
+
+	return values
+}
+
+func (plan *leafPlan[V, PV]) decodeNestedItems(c *cursor, maxItems int) ([]SingleValue, error) {
+	// Each leaf value takes at least one byte: guard against bogus lengths, before allocating the block.
+	if maxItems > c.remaining() {
+		maxItems = c.remaining()
+	}
+
+	items := plan.newValues(maxItems)
+
+	for i, item := range items {
+		if c.isEmpty() {
+			return items[:i], nil
+		}
+
+		err := item.(cursorDecodableValue).decodeNested(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// blockDecodingPlan is implemented by plans which are able to decode many items (of a list) at once,
+// allocating them in blocks (e.g. leaf plans, struct plans).
+// At most "maxItems" items are decoded: decoding stops early if there is no data left.
+type blockDecodingPlan interface {
+	decodeNestedItems(c *cursor, maxItems int) ([]SingleValue, error)
+}
+
+// blockAllocatingPlan is implemented by plans which are able to allocate many (empty) values at once
+type blockAllocatingPlan interface {
+	newValues(numValues int) []SingleValue
+}
+
+type optionPlan struct {
+	inner singleValuePlan
+}
+
+func (plan *optionPlan) decodeNested(c *cursor) (SingleValue, error) {
+	firstByte, err := c.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if firstByte == optionMarkerForAbsentValue {
+		return &OptionValue{}, nil
+	}
+
+	if firstByte == optionMarkerForPresentValue {
+		return plan.decodeInnerValue(c)
+	}
+
+	return nil, fmt.Errorf("invalid first byte for nested encoded option: %d", firstByte)
+}
+
+func (plan *optionPlan) decodeTopLevel(c *cursor) (SingleValue, error) {
+	if c.isEmpty() {
+		return &OptionValue{}, nil
+	}
+
+	firstByte, err := c.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if firstByte != optionMarkerForPresentValue {
+		return nil, fmt.Errorf("invalid first byte for top-level encoded option: %d", firstByte)
+	}
+
+	return plan.decodeInnerValue(c)
+}
+
+func (plan *optionPlan) decodeInnerValue(c *cursor) (SingleValue, error) {
+	inner, err := plan.inner.decodeNested(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OptionValue{Value: inner}, nil
+}
+
+type listPlan struct {
+	item        singleValuePlan
+	itemCreator func() SingleValue
+}
+
+func (plan *listPlan) decodeNested(c *cursor) (SingleValue, error) {
+	length, err := c.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []SingleValue
+
+	if blockPlan, ok := plan.item.(blockDecodingPlan); ok {
+		items, err = blockPlan.decodeNestedItems(c, int(length))
+		if err != nil {
+			return nil, err
+		}
+
+		// Decoding stops early if there's no data left, but the remaining items might take no bytes (e.g. structs with empty fields)
+		for i := len(items); i < int(length); i++ {
+			item, err := plan.item.decodeNested(c)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode list of %d items: not enough data", length)
+			}
+
+			items = append(items, item)
+		}
+	} else {
+		items = make([]SingleValue, 0, c.capacityForItems(length))
+
+		for i := uint32(0); i < length; i++ {
+			item, err := plan.item.decodeNested(c)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return &ListValue{Items: items, ItemCreator: plan.itemCreator}, nil
+}
+
+func (plan *listPlan) decodeTopLevel(c *cursor) (SingleValue, error) {
+	items := make([]SingleValue, 0)
+
+	if blockPlan, ok := plan.item.(blockDecodingPlan); ok {
+		// The number of items isn't known in advance: they are decoded in chunks of increasing size.
+		chunkSize := minChunkSizeForTopLevelLists
+
+		for !c.isEmpty() {
+			remaining := c.remaining()
+
+			chunk, err := blockPlan.decodeNestedItems(c, chunkSize)
+			if err != nil {
+				return nil, err
+			}
+
+			// Items which take no bytes (e.g. structs without fields) would never exhaust the data
+			if c.remaining() == remaining {
+				return nil, fmt.Errorf("cannot decode list: %d bytes left, but items take no bytes", remaining)
+			}
+
+			items = append(items, chunk...)
+			chunkSize *= 2
+		}
+
+		return &ListValue{Items: items, ItemCreator: plan.itemCreator}, nil
+	}
+
+	for !c.isEmpty() {
+		item, err := plan.item.decodeNested(c)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return &ListValue{Items: items, ItemCreator: plan.itemCreator}, nil
+}
+
+// fieldsPlan decodes the fields of a struct (or of an enum variant)
+type fieldsPlan struct {
+	names []string
+	plans []singleValuePlan
+}
+
+func compileFieldsPlan(fields []Field) (*fieldsPlan, error) {
+	plan := &fieldsPlan{
+		names: make([]string, len(fields)),
+		plans: make([]singleValuePlan, len(fields)),
+	}
+
+	for i, field := range fields {
+		fieldPlan, err := compileSingleValuePlan(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compile field '%s', because of: %w", field.Name, err)
+		}
+
+		plan.names[i] = field.Name
+		plan.plans[i] = fieldPlan
+	}
+
+	return plan, nil
+}
+
+func (plan *fieldsPlan) decodeFields(c *cursor, kind string) ([]Field, error) {
+	if len(plan.plans) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]Field, len(plan.plans))
+
+	for i, fieldPlan := range plan.plans {
+		value, err := fieldPlan.decodeNested(c)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode field '%s' of %s, because of: %w", plan.names[i], kind, err)
+		}
+
+		fields[i] = Field{Name: plan.names[i], Value: value}
+	}
+
+	return fields, nil
+}
+
+type structPlan struct {
+	fields *fieldsPlan
+}
+
+func (plan *structPlan) decodeNested(c *cursor) (SingleValue, error) {
+	fields, err := plan.fields.decodeFields(c, "struct")
+	if err != nil {
+		return nil, err
+	}
+
+	return &StructValue{Fields: fields}, nil
+}
+
+func (plan *structPlan) decodeTopLevel(c *cursor) (SingleValue, error) {
+	return plan.decodeNested(c)
+}
+
+// decodeNestedItems decodes (at most) "maxItems" structs, in blocks of increasing size.
+// Since the number of items might be bogus, the size of the first block is bounded by the remaining bytes
+// (each leaf value takes at least one byte). Structs without leaf fields might take no bytes at all (e.g. structs without fields),
+// thus the blocks grow as the items are decoded, instead of being allocated upfront.
+func (plan *structPlan) decodeNestedItems(c *cursor, maxItems int) ([]SingleValue, error) {
+	numLeafFields := 0
+
+	for _, fieldPlan := range plan.fields.plans {
+		if _, ok := fieldPlan.(blockAllocatingPlan); ok {
+			numLeafFields++
+		}
+	}
+
+	blockSize := minChunkSizeForTopLevelLists
+	if numLeafFields > 0 && c.remaining()/numLeafFields > blockSize {
+		blockSize = c.remaining() / numLeafFields
+	}
+
+	if blockSize > maxItems {
+		blockSize = maxItems
+	}
+
+	items := make([]SingleValue, 0, blockSize)
+
+	for len(items) < maxItems {
+		if blockSize > maxItems-len(items) {
+			blockSize = maxItems - len(items)
+		}
+
+		block, err := plan.decodeNestedBlock(c, blockSize)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, block...)
+
+		if c.isEmpty() && len(plan.fields.plans) > 0 {
+			break
+		}
+
+		blockSize *= 2
+	}
+
+	return items, nil
+}
+
+// decodeNestedBlock decodes (at most) "numItems" structs. The structs, their fields and their leaf values (column by column)
+// are allocated as blocks.
+func (plan *structPlan) decodeNestedBlock(c *cursor, numItems int) ([]SingleValue, error) {
+	numFields := len(plan.fields.plans)
+	columns := make([][]SingleValue, numFields)
+
+	for j, fieldPlan := range plan.fields.plans {
+		if allocatingPlan, ok := fieldPlan.(blockAllocatingPlan); ok {
+			columns[j] = allocatingPlan.newValues(numItems)
+		}
+	}
+
+	structs := make([]StructValue, numItems)
+	fields := make([]Field, numItems*numFields)
+	items := make([]SingleValue, numItems)
+
+	for i := range structs {
+		if c.isEmpty() && numFields > 0 {
+			return items[:i], nil
+		}
+
+		structFields := fields[i*numFields : (i+1)*numFields : (i+1)*numFields]
+
+		for j, fieldPlan := range plan.fields.plans {
+			var value SingleValue
+			var err error
+
+			if columns[j] != nil {
+				value = columns[j][i]
+				err = value.(cursorDecodableValue).decodeNested(c)
+			} else {
+				value, err = fieldPlan.decodeNested(c)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode field '%s' of struct, because of: %w", plan.fields.names[j], err)
+			}
+
+			structFields[j] = Field{Name: plan.fields.names[j], Value: value}
+		}
+
+		if numFields > 0 {
+			structs[i].Fields = structFields
+		}
+
+		items[i] = &structs[i]
+	}
+
+	return items, nil
+}
+
+// enumPlan decodes enums. The plans of the variants are compiled on first use (in a concurrency-safe manner).
+type enumPlan struct {
+	fieldsProvider      func(uint8) []Field
+	variantNameProvider func(uint8) string
+	variants            map[uint8]*fieldsPlan
+	mutex               sync.RWMutex
+}
+
+func (plan *enumPlan) decodeNested(c *cursor) (SingleValue, error) {
+	discriminant, err := c.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := plan.getVariant(discriminant)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := variant.decodeFields(c, "enum")
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnumValue{
+		Discriminant:        discriminant,
+		Fields:              fields,
+		FieldsProvider:      plan.fieldsProvider,
+		VariantNameProvider: plan.variantNameProvider,
+	}, nil
+}
+
+func (plan *enumPlan) decodeTopLevel(c *cursor) (SingleValue, error) {
+	if c.isEmpty() {
+		return &EnumValue{
+			Discriminant:        0,
+			FieldsProvider:      plan.fieldsProvider,
+			VariantNameProvider: plan.variantNameProvider,
+		}, nil
+	}
+
+	return plan.decodeNested(c)
+}
+
+func (plan *enumPlan) getVariant(discriminant uint8) (*fieldsPlan, error) {
+	plan.mutex.RLock()
+	variant, ok := plan.variants[discriminant]
+	plan.mutex.RUnlock()
+
+	if ok {
+		return variant, nil
+	}
+
+	variant, err := compileFieldsPlan(plan.fieldsProvider(discriminant))
+	if err != nil {
+		return nil, fmt.Errorf("cannot compile variant %d of enum, because of: %w", discriminant, err)
+	}
+
+	plan.mutex.Lock()
+	plan.variants[discriminant] = variant
+	plan.mutex.Unlock()
+
+	return variant, nil
+}
+
+// opaquePlan decodes values by copying a prototype (placeholder), then decoding into the copy
+type opaquePlan struct {
+	prototype SingleValue
+}
+
+func (plan *opaquePlan) decodeNested(c *cursor) (SingleValue, error) {
+	value, err := CloneSingleValue(plan.prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeNestedFromCursor(c, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (plan *opaquePlan) decodeTopLevel(c *cursor) (SingleValue, error) {
+	value, err := CloneSingleValue(plan.prototype)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeTopLevelFromCursor(c, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// compileMultiValuePlan creates the plan of a (possibly multi-part) value, given a placeholder (as used for regular decoding).
+// Optional and variadic values are checked against their position within the whole tree of output values.
+func compileMultiValuePlan(placeholder any, position valuesPosition) (multiValuePlan, error) {
+	switch placeholder := placeholder.(type) {
+	case nil:
+		return nil, errors.New("cannot deserialize into nil value")
+	case *OptionalValue:
+		err := position.checkOptionalValue(outputValuesKind)
+		if err != nil {
+			return nil, err
+		}
+
+		if placeholder.Value == nil {
+			return nil, errors.New("placeholder value of optional value should be set before decoding")
+		}
+
+		inner, err := compileMultiValuePlan(placeholder.Value, position)
+		if err != nil {
+			return nil, err
+		}
+
+		return &optionalPlan{inner: inner}, nil
+	case *MultiValue:
+		items, err := compileMultiValuePlans(placeholder.Items, position)
+		if err != nil {
+			return nil, err
+		}
+
+		return &multiPlan{items: items}, nil
+	case *VariadicValues:
+		err := position.checkVariadicValues(outputValuesKind)
+		if err != nil {
+			return nil, err
+		}
+
+		if placeholder.ItemCreator == nil {
+			return nil, errors.New("cannot deserialize variadic values: item creator is nil")
+		}
+
+		// Each item of the variadic values is deserialized as if it was the last among output values.
+		item, err := compileMultiValuePlan(placeholder.ItemCreator(), positionOfLastValues)
+		if err != nil {
+			return nil, err
+		}
+
+		return &variadicPlan{item: item, itemCreator: placeholder.ItemCreator}, nil
+	case SingleValue:
+		plan, err := compileSingleValuePlan(placeholder)
+		if err != nil {
+			return nil, err
+		}
+
+		return &singlePartPlan{plan: plan, typeName: fmt.Sprintf("%T", placeholder)}, nil
+	case genericValue:
+		inner, err := compileMultiValuePlan(placeholder.toUntyped(), position)
+		if err != nil {
+			return nil, err
+		}
+
+		return &genericMultiValuePlan{inner: inner, prototype: placeholder}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type for deserialization: %T", placeholder)
+	}
+}
+
+func compileMultiValuePlans(placeholders []any, position valuesPosition) ([]multiValuePlan, error) {
+	plans := make([]multiValuePlan, len(placeholders))
+
+	for i, placeholder := range placeholders {
+		plan, err := compileMultiValuePlan(placeholder, position.ofItem(placeholders, i))
+		if err != nil {
+			return nil, err
+		}
+
+		plans[i] = plan
+	}
+
+	return plans, nil
+}
+
+// singlePartPlan decodes a single value (top-level), from a whole part
+type singlePartPlan struct {
+	plan     singleValuePlan
+	typeName string
+}
+
+func (plan *singlePartPlan) decodeParts(decodingPlan *decodingPlan, partsHolder *partsHolder) (any, error) {
+	part, err := partsHolder.readWholeFocusedPart()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := plan.plan.decodeTopLevel(newCursor(part, decodingPlan.aliasInputData))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode (top-level) %s, because of: %w", plan.typeName, err)
+	}
+
+	err = partsHolder.focusOnNextPart()
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+type multiPlan struct {
+	items []multiValuePlan
+}
+
+func (plan *multiPlan) decodeParts(decodingPlan *decodingPlan, partsHolder *partsHolder) (any, error) {
+	items, err := decodingPlan.decodeItems(plan.items, partsHolder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiValue{Items: items}, nil
+}
+
+type variadicPlan struct {
+	item        multiValuePlan
+	itemCreator func() any
+}
+
+func (plan *variadicPlan) decodeParts(decodingPlan *decodingPlan, partsHolder *partsHolder) (any, error) {
+	items := make([]any, 0)
+
+	for !partsHolder.isFocusedBeyondLastPart() {
+		item, err := plan.item.decodeParts(decodingPlan, partsHolder)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return &VariadicValues{Items: items, ItemCreator: plan.itemCreator}, nil
+}
+
+type optionalPlan struct {
+	inner multiValuePlan
+}
+
+func (plan *optionalPlan) decodeParts(decodingPlan *decodingPlan, partsHolder *partsHolder) (any, error) {
+	if partsHolder.isFocusedBeyondLastPart() {
+		return &OptionalValue{}, nil
+	}
+
+	inner, err := plan.inner.decodeParts(decodingPlan, partsHolder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OptionalValue{Value: inner}, nil
+}
+
+// genericMultiValuePlan decodes generic multi-values (e.g. Variadic[T]), by means of their untyped counterparts
+type genericMultiValuePlan struct {
+	inner     multiValuePlan
+	prototype genericValue
+}
+
+func (plan *genericMultiValuePlan) decodeParts(decodingPlan *decodingPlan, partsHolder *partsHolder) (any, error) {
+	untyped, err := plan.inner.decodeParts(decodingPlan, partsHolder)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := plan.prototype.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	err = value.(genericValue).fromUntyped(untyped)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
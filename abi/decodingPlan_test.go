@@ -0,0 +1,367 @@
+package abi
+
+import (
+	"math/big"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDecodingPlan(t *testing.T) {
+	t.Run("should err if parts separator is empty", func(t *testing.T) {
+		_, err := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: []any{&U8Value{}}})
+		require.ErrorContains(t, err, "parts separator must not be empty")
+	})
+
+	t.Run("should err on nil placeholder", func(t *testing.T) {
+		_, err := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: []any{nil}, PartsSeparator: "@"})
+		require.ErrorContains(t, err, "cannot deserialize into nil value")
+	})
+
+	t.Run("should err if optional is not last", func(t *testing.T) {
+		_, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&OptionalValue{Value: &U8Value{}}, &U8Value{}},
+			PartsSeparator: "@",
+		})
+		require.ErrorContains(t, err, "an optional value must be last among output values")
+	})
+
+	t.Run("should err if variadic is not last", func(t *testing.T) {
+		_, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues: []any{
+				&VariadicValues{ItemCreator: func() any { return &U8Value{} }},
+				&U8Value{},
+			},
+			PartsSeparator: "@",
+		})
+		require.ErrorContains(t, err, "variadic values must be last among output values")
+	})
+
+	t.Run("should err on list without item creator", func(t *testing.T) {
+		_, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues: []any{
+				&StructValue{Fields: []Field{{Name: "a", Value: &ListValue{}}}},
+			},
+			PartsSeparator: "@",
+		})
+		require.ErrorContains(t, err, "cannot compile field 'a', because of: cannot compile list: item creator is nil")
+	})
+}
+
+func TestDecodingPlan_Decode(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	createPlaceholders := func() []any {
+		return []any{
+			&U8Value{},
+			&StructValue{
+				Fields: []Field{
+					{Name: "a", Value: &U16Value{}},
+					{Name: "b", Value: &BigIntValue{}},
+					{Name: "c", Value: &StringValue{}},
+					{Name: "d", Value: &OptionValue{Value: &AddressValue{}}},
+					{Name: "e", Value: &ListValue{ItemCreator: func() SingleValue { return &BigUIntValue{} }}},
+					{Name: "f", Value: &List[*U32Value]{}},
+					{Name: "g", Value: &EnumValue{
+						FieldsProvider: func(discriminant uint8) []Field {
+							if discriminant == 1 {
+								return []Field{{Name: "x", Value: &BytesValue{}}}
+							}
+
+							return nil
+						},
+					}},
+				},
+			},
+			&MultiValue{Items: []any{&BoolValue{}, &I64Value{}}},
+			&VariadicValues{ItemCreator: func() any {
+				return &MultiValue{Items: []any{&U8Value{}, &ListValue{ItemCreator: func() SingleValue { return &U16Value{} }}}}
+			}},
+		}
+	}
+
+	values := []any{
+		&U8Value{Value: 0x42},
+		&StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U16Value{Value: 0x1234}},
+				{Name: "b", Value: &BigIntValue{Value: big.NewInt(-42)}},
+				{Name: "c", Value: &StringValue{Value: "hello"}},
+				{Name: "d", Value: &OptionValue{Value: &AddressValue{Value: make([]byte, 32)}}},
+				{Name: "e", Value: &ListValue{Items: []SingleValue{
+					&BigUIntValue{Value: big.NewInt(1)},
+					&BigUIntValue{Value: big.NewInt(1_000_000)},
+				}}},
+				{Name: "f", Value: &List[*U32Value]{Items: []*U32Value{{Value: 7}, {Value: 8}}}},
+				{Name: "g", Value: &EnumValue{Discriminant: 1, Fields: []Field{{Name: "x", Value: &BytesValue{Value: []byte{0xab}}}}}},
+			},
+		},
+		&MultiValue{Items: []any{&BoolValue{Value: true}, &I64Value{Value: -1}}},
+		&VariadicValues{Items: []any{
+			&MultiValue{Items: []any{&U8Value{Value: 1}, &ListValue{Items: []SingleValue{&U16Value{Value: 2}}}}},
+			&MultiValue{Items: []any{&U8Value{Value: 3}, &ListValue{Items: []SingleValue{}}}},
+		}},
+	}
+
+	data, err := serializer.Serialize(values)
+	require.NoError(t, err)
+
+	plan, err := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: createPlaceholders(), PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should decode like the serializer", func(t *testing.T) {
+		expected := createPlaceholders()
+		err := serializer.Deserialize(data, expected)
+		require.NoError(t, err)
+
+		actual, err := plan.Decode(data)
+		require.NoError(t, err)
+		require.Empty(t, DiffValues(&MultiValue{Items: expected}, &MultiValue{Items: actual}))
+		require.Empty(t, DiffValues(&MultiValue{Items: values}, &MultiValue{Items: actual}))
+	})
+
+	t.Run("should produce new values on each decoding", func(t *testing.T) {
+		first, err := plan.Decode(data)
+		require.NoError(t, err)
+
+		second, err := plan.Decode(data)
+		require.NoError(t, err)
+
+		first[0].(*U8Value).Value = 0xff
+		require.Equal(t, uint8(0x42), second[0].(*U8Value).Value)
+	})
+
+	t.Run("should decode parts", func(t *testing.T) {
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&StringValue{}, &OptionalValue{Value: &U8Value{}}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		actual, err := plan.DecodeParts([][]byte{[]byte("hello")})
+		require.NoError(t, err)
+		require.Empty(t, DiffValues(&MultiValue{Items: []any{&StringValue{Value: "hello"}, &OptionalValue{}}}, &MultiValue{Items: actual}))
+	})
+
+	t.Run("should decode parts, with aliasing", func(t *testing.T) {
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&BytesValue{}},
+			PartsSeparator: "@",
+			AliasInputData: true,
+		})
+		require.NoError(t, err)
+
+		parts := [][]byte{{0x41, 0x42}}
+		actual, err := plan.DecodeParts(parts)
+		require.NoError(t, err)
+
+		parts[0][0] = 0x43
+		require.Equal(t, []byte{0x43, 0x42}, actual[0].(*BytesValue).Value)
+	})
+
+	t.Run("should decode generic variadic values", func(t *testing.T) {
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&Variadic[*U16Value]{}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		actual, err := plan.Decode("4243@4445")
+		require.NoError(t, err)
+		require.Equal(t, []*U16Value{{Value: 0x4243}, {Value: 0x4445}}, actual[0].(*Variadic[*U16Value]).Items)
+	})
+
+	t.Run("should decode lists, in chunks", func(t *testing.T) {
+		for _, numItems := range []int{0, 1, 7, 8, 9, 24, 25, 100} {
+			leaves := make([]SingleValue, numItems)
+			structs := make([]SingleValue, numItems)
+
+			for i := 0; i < numItems; i++ {
+				leaves[i] = &U32Value{Value: uint32(i)}
+				structs[i] = &StructValue{Fields: []Field{
+					{Name: "a", Value: &U8Value{Value: uint8(i)}},
+					{Name: "b", Value: &OptionValue{Value: &StringValue{Value: "x"}}},
+				}}
+			}
+
+			// Top-level lists, then nested lists (as fields of a struct)
+			values := []any{
+				&ListValue{Items: leaves},
+				&ListValue{Items: structs},
+				&StructValue{Fields: []Field{
+					{Name: "leaves", Value: &ListValue{Items: leaves}},
+					{Name: "structs", Value: &ListValue{Items: structs}},
+				}},
+			}
+			data, err := serializer.Serialize(values)
+			require.NoError(t, err)
+
+			createLeaf := func() SingleValue { return &U32Value{} }
+			createStruct := func() SingleValue {
+				return &StructValue{Fields: []Field{{Name: "a", Value: &U8Value{}}, {Name: "b", Value: &OptionValue{Value: &StringValue{}}}}}
+			}
+			placeholders := []any{
+				&ListValue{ItemCreator: createLeaf},
+				&ListValue{ItemCreator: createStruct},
+				&StructValue{Fields: []Field{
+					{Name: "leaves", Value: &ListValue{ItemCreator: createLeaf}},
+					{Name: "structs", Value: &ListValue{ItemCreator: createStruct}},
+				}},
+			}
+
+			plan, err := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: placeholders, PartsSeparator: "@"})
+			require.NoError(t, err)
+
+			actual, err := plan.Decode(data)
+			require.NoError(t, err)
+			require.Empty(t, DiffValues(&MultiValue{Items: values}, &MultiValue{Items: actual}))
+		}
+	})
+
+	t.Run("should err on bad hex", func(t *testing.T) {
+		_, err := plan.Decode("4@00")
+		require.ErrorContains(t, err, "odd length hex string")
+
+		_, err = plan.Decode("zz")
+		require.ErrorContains(t, err, "invalid byte")
+	})
+
+	t.Run("should err on bad data", func(t *testing.T) {
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&ListValue{ItemCreator: func() SingleValue { return &U8Value{} }}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		_, err = plan.Decode("")
+		require.NoError(t, err)
+
+		plan, err = NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&StructValue{Fields: []Field{{Name: "a", Value: &ListValue{ItemCreator: func() SingleValue { return &U8Value{} }}}}}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		_, err = plan.Decode("ffffffff01")
+		require.ErrorContains(t, err, "cannot decode (top-level) *abi.StructValue, because of: cannot decode field 'a' of struct, because of: cannot decode list of 4294967295 items: not enough data")
+	})
+
+	t.Run("should not preallocate items on bogus lengths", func(t *testing.T) {
+		createStructOfList := func() SingleValue {
+			return &StructValue{Fields: []Field{{Name: "a", Value: &ListValue{ItemCreator: func() SingleValue { return &U8Value{} }}}}}
+		}
+
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&ListValue{ItemCreator: func() SingleValue { return &ListValue{ItemCreator: createStructOfList} }}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		// A list of 268435456 structs (without leaf fields), but only one of them is actually encoded
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		_, err = plan.Decode("1000000000000000")
+		require.Error(t, err)
+
+		runtime.ReadMemStats(&after)
+		require.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1_000_000))
+	})
+
+	t.Run("should decode structs without fields, like the serializer", func(t *testing.T) {
+		createOutputValue := func() *StructValue {
+			return &StructValue{Fields: []Field{{Name: "a", Value: &ListValue{ItemCreator: func() SingleValue { return &StructValue{} }}}}}
+		}
+
+		plan, err := NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{createOutputValue()},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		values, err := plan.Decode("00000003")
+		require.NoError(t, err)
+
+		expected := createOutputValue()
+		err = serializer.Deserialize("00000003", []any{expected})
+		require.NoError(t, err)
+		require.Len(t, expected.Fields[0].Value.(*ListValue).Items, 3)
+		require.Equal(t, expected.Fields[0].Value.(*ListValue).Items, values[0].(*StructValue).Fields[0].Value.(*ListValue).Items)
+
+		// Top-level lists of items which take no bytes cannot be decoded (they would never exhaust the data)
+		plan, err = NewDecodingPlan(ArgsNewDecodingPlan{
+			OutputValues:   []any{&ListValue{ItemCreator: func() SingleValue { return &StructValue{} }}},
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		_, err = plan.Decode("00")
+		require.ErrorContains(t, err, "cannot decode list: 1 bytes left, but items take no bytes")
+	})
+
+	t.Run("should err on missing parts", func(t *testing.T) {
+		_, err := plan.Decode("42")
+		require.Error(t, err)
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					actual, err := plan.Decode(data)
+					require.NoError(t, err)
+					require.Empty(t, DiffValues(&MultiValue{Items: values}, &MultiValue{Items: actual}))
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
+func BenchmarkDecodingPlan_Decode(b *testing.B) {
+	serializer, _ := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	data, _ := serializer.Serialize([]any{createBenchmarkValue()})
+
+	createPlaceholders := func() []any {
+		return []any{
+			&ListValue{
+				ItemCreator: func() SingleValue {
+					return &StructValue{
+						Fields: []Field{
+							{Name: "token_identifier", Value: &StringValue{}},
+							{Name: "token_nonce", Value: &U64Value{}},
+							{Name: "amount", Value: &BigUIntValue{}},
+						},
+					}
+				},
+			},
+		}
+	}
+
+	b.Run("with serializer", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = serializer.Deserialize(data, createPlaceholders())
+		}
+	})
+
+	b.Run("with decoding plan", func(b *testing.B) {
+		plan, _ := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: createPlaceholders(), PartsSeparator: "@"})
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = plan.Decode(data)
+		}
+	})
+}
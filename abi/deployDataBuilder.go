@@ -0,0 +1,112 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// UpgradeContractFunctionName is the name of the built-in function for upgrading a contract
+const UpgradeContractFunctionName = "upgradeContract"
+
+// WasmVMType is the type of the virtual machine running WebAssembly contracts
+var WasmVMType = []byte{0x05, 0x00}
+
+// ArgsNewDeployDataBuilder defines the arguments needed for a new deploy data builder
+type ArgsNewDeployDataBuilder struct {
+	// Abi is optional: if set, the arguments must match the inputs of the constructor (or of the upgrade constructor)
+	Abi *Definition
+}
+
+// deployDataBuilder builds the data field of contract deployment and upgrade transactions
+type deployDataBuilder struct {
+	serializer *serializer
+	abi        *Definition
+}
+
+// NewDeployDataBuilder creates a new deploy data builder
+func NewDeployDataBuilder(args ArgsNewDeployDataBuilder) (*deployDataBuilder, error) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: callDataPartsSeparator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployDataBuilder{
+		serializer: serializer,
+		abi:        args.Abi,
+	}, nil
+}
+
+// BuildDeploy builds "code@vmType@codeMetadata@arg1@arg2...", for deploying a WebAssembly contract
+func (builder *deployDataBuilder) BuildDeploy(code []byte, metadata CodeMetadata, args []any) (string, error) {
+	if len(code) == 0 {
+		return "", errors.New("code must not be empty")
+	}
+
+	if builder.abi != nil {
+		err := CheckValuesAgainstParameters(args, builder.abi.constructorInputs())
+		if err != nil {
+			return "", fmt.Errorf("bad arguments for constructor: %w", err)
+		}
+	}
+
+	values := []any{
+		&BytesValue{Value: WasmVMType},
+		&BytesValue{Value: metadata.Bytes()},
+	}
+	values = append(values, args...)
+
+	parts, err := builder.serializer.serializeToParts(values)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(code) + callDataPartsSeparator + builder.serializer.encodeParts(parts), nil
+}
+
+// BuildUpgrade builds "upgradeContract@code@codeMetadata@arg1@arg2..."
+func (builder *deployDataBuilder) BuildUpgrade(code []byte, metadata CodeMetadata, args []any) (string, error) {
+	if len(code) == 0 {
+		return "", errors.New("code must not be empty")
+	}
+
+	if builder.abi != nil {
+		err := CheckValuesAgainstParameters(args, builder.abi.upgradeConstructorInputs())
+		if err != nil {
+			return "", fmt.Errorf("bad arguments for upgrade constructor: %w", err)
+		}
+	}
+
+	values := []any{
+		&BytesValue{Value: code},
+		&BytesValue{Value: metadata.Bytes()},
+	}
+	values = append(values, args...)
+
+	parts, err := builder.serializer.serializeToParts(values)
+	if err != nil {
+		return "", err
+	}
+
+	return UpgradeContractFunctionName + callDataPartsSeparator + builder.serializer.encodeParts(parts), nil
+}
+
+// constructorInputs returns the inputs of the constructor (none, if the constructor isn't defined)
+func (definition *Definition) constructorInputs() []Parameter {
+	if definition.Constructor == nil {
+		return nil
+	}
+
+	return definition.Constructor.Inputs
+}
+
+// upgradeConstructorInputs returns the inputs of the upgrade constructor. If it isn't defined, the constructor is used on upgrade.
+func (definition *Definition) upgradeConstructorInputs() []Parameter {
+	if definition.UpgradeConstructor == nil {
+		return definition.constructorInputs()
+	}
+
+	return definition.UpgradeConstructor.Inputs
+}
@@ -0,0 +1,76 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployDataBuilder(t *testing.T) {
+	code := []byte{0x00, 0x61, 0x73, 0x6d}
+	metadata := CodeMetadata{Upgradeable: true, Readable: true}
+
+	t.Run("without ABI", func(t *testing.T) {
+		builder, err := NewDeployDataBuilder(ArgsNewDeployDataBuilder{})
+		require.NoError(t, err)
+
+		data, err := builder.BuildDeploy(code, metadata, []any{&BigUIntValue{Value: big.NewInt(42)}})
+		require.NoError(t, err)
+		require.Equal(t, "0061736d@0500@0500@2a", data)
+
+		data, err = builder.BuildDeploy(code, CodeMetadata{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "0061736d@0500@0000", data)
+
+		data, err = builder.BuildUpgrade(code, metadata, []any{&BigUIntValue{Value: big.NewInt(42)}})
+		require.NoError(t, err)
+		require.Equal(t, "upgradeContract@0061736d@0500@2a", data)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		builder, err := NewDeployDataBuilder(ArgsNewDeployDataBuilder{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		data, err := builder.BuildDeploy(code, metadata, []any{&BigUIntValue{Value: big.NewInt(42)}})
+		require.NoError(t, err)
+		require.Equal(t, "0061736d@0500@0500@2a", data)
+
+		_, err = builder.BuildDeploy(code, metadata, []any{&U32Value{Value: 42}})
+		require.ErrorContains(t, err, "bad arguments for constructor: bad value for parameter 'initial_value'")
+
+		_, err = builder.BuildDeploy(code, metadata, nil)
+		require.ErrorContains(t, err, "bad arguments for constructor: missing value for parameter 'initial_value'")
+
+		// The upgrade constructor isn't defined: the constructor is used instead.
+		data, err = builder.BuildUpgrade(code, metadata, []any{&BigUIntValue{Value: big.NewInt(42)}})
+		require.NoError(t, err)
+		require.Equal(t, "upgradeContract@0061736d@0500@2a", data)
+	})
+
+	t.Run("with ABI, with upgrade constructor", func(t *testing.T) {
+		abi := createTestAbi()
+		abi.UpgradeConstructor = &Endpoint{Name: "upgrade"}
+
+		builder, err := NewDeployDataBuilder(ArgsNewDeployDataBuilder{Abi: abi})
+		require.NoError(t, err)
+
+		data, err := builder.BuildUpgrade(code, metadata, nil)
+		require.NoError(t, err)
+		require.Equal(t, "upgradeContract@0061736d@0500", data)
+
+		_, err = builder.BuildUpgrade(code, metadata, []any{&BigUIntValue{Value: big.NewInt(42)}})
+		require.ErrorContains(t, err, "bad arguments for upgrade constructor: too many values: expected at most 0, got 1")
+	})
+
+	t.Run("with empty code", func(t *testing.T) {
+		builder, err := NewDeployDataBuilder(ArgsNewDeployDataBuilder{})
+		require.NoError(t, err)
+
+		_, err = builder.BuildDeploy(nil, metadata, nil)
+		require.ErrorContains(t, err, "code must not be empty")
+
+		_, err = builder.BuildUpgrade(nil, metadata, nil)
+		require.ErrorContains(t, err, "code must not be empty")
+	})
+}
@@ -0,0 +1,151 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ParsedDeployData is the outcome of parsing the data field of a contract deployment (or upgrade) transaction
+type ParsedDeployData struct {
+	Code []byte
+	// CodeHash is the Blake2b (256 bits) hash of the code
+	CodeHash []byte
+	// VMType is the type of the virtual machine (nil for upgrades, where it's not specified)
+	VMType       []byte
+	CodeMetadata CodeMetadata
+	// RawArguments holds the (hex-decoded) parts of the constructor arguments
+	RawArguments [][]byte
+	// Arguments holds the decoded constructor arguments, named after its inputs (only if the ABI is available)
+	Arguments []NamedValue
+}
+
+// ArgsNewDeployDataParser defines the arguments needed for a new deploy data parser
+type ArgsNewDeployDataParser struct {
+	// Abi is optional: if not set, the constructor arguments aren't decoded
+	Abi *Definition
+}
+
+// deployDataParser parses the data field of contract deployment and upgrade transactions (see deployDataBuilder)
+type deployDataParser struct {
+	callDataParser *callDataParser
+	abi            *Definition
+}
+
+// NewDeployDataParser creates a new deploy data parser
+func NewDeployDataParser(args ArgsNewDeployDataParser) (*deployDataParser, error) {
+	callDataParser, err := NewCallDataParser(ArgsNewCallDataParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployDataParser{
+		callDataParser: callDataParser,
+		abi:            args.Abi,
+	}, nil
+}
+
+// ParseDeploy parses "code@vmType@codeMetadata@arg1@arg2..."
+func (parser *deployDataParser) ParseDeploy(data string) (*ParsedDeployData, error) {
+	parts, err := parser.callDataParser.serializer.decodeIntoParts(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode deploy data: %w", err)
+	}
+
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("not enough parts: expected at least 3, got %d", len(parts))
+	}
+
+	parsed, err := parser.parseCode(parts[0], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.VMType = parts[1]
+
+	var inputs []Parameter
+	if parser.abi != nil {
+		inputs = parser.abi.constructorInputs()
+	}
+
+	err = parser.parseArguments(parsed, parts[3:], inputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode arguments of constructor: %w", err)
+	}
+
+	return parsed, nil
+}
+
+// ParseUpgrade parses "upgradeContract@code@codeMetadata@arg1@arg2..."
+func (parser *deployDataParser) ParseUpgrade(data string) (*ParsedDeployData, error) {
+	functionName, encodedParts, _ := strings.Cut(data, callDataPartsSeparator)
+	if functionName != UpgradeContractFunctionName {
+		return nil, fmt.Errorf("not a contract upgrade: %s", functionName)
+	}
+
+	parts, err := parser.callDataParser.serializer.decodeIntoParts(encodedParts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode upgrade data: %w", err)
+	}
+
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("not enough parts: expected at least 2, got %d", len(parts))
+	}
+
+	parsed, err := parser.parseCode(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []Parameter
+	if parser.abi != nil {
+		inputs = parser.abi.upgradeConstructorInputs()
+	}
+
+	err = parser.parseArguments(parsed, parts[2:], inputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode arguments of upgrade constructor: %w", err)
+	}
+
+	return parsed, nil
+}
+
+func (parser *deployDataParser) parseCode(code []byte, metadataBytes []byte) (*ParsedDeployData, error) {
+	if len(code) == 0 {
+		return nil, errors.New("code must not be empty")
+	}
+
+	metadata, err := NewCodeMetadataFromBytes(metadataBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHash := blake2b.Sum256(code)
+
+	return &ParsedDeployData{
+		Code:         code,
+		CodeHash:     codeHash[:],
+		CodeMetadata: metadata,
+	}, nil
+}
+
+// parseArguments decodes the raw arguments (if the ABI is available)
+func (parser *deployDataParser) parseArguments(parsed *ParsedDeployData, rawArguments [][]byte, inputs []Parameter) error {
+	parsed.RawArguments = rawArguments
+
+	if parser.abi == nil {
+		return nil
+	}
+
+	values, err := parser.callDataParser.decodeExactly(rawArguments, inputs)
+	if err != nil {
+		return err
+	}
+
+	parsed.Arguments = nameValues(values, inputs)
+	return nil
+}
@@ -0,0 +1,101 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployDataParser_ParseDeploy(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{})
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseDeploy("616263@0500@0102@2a")
+		require.NoError(t, err)
+		require.Equal(t, []byte("abc"), parsed.Code)
+		require.Equal(t, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319", hex.EncodeToString(parsed.CodeHash))
+		require.Equal(t, WasmVMType, parsed.VMType)
+		require.Equal(t, CodeMetadata{Upgradeable: true, Payable: true}, parsed.CodeMetadata)
+		require.Equal(t, [][]byte{{0x2a}}, parsed.RawArguments)
+		require.Nil(t, parsed.Arguments)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseDeploy("616263@0500@0102@2a")
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "initial_value", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Arguments)
+
+		_, err = parser.ParseDeploy("616263@0500@0102@2a@2b")
+		require.ErrorContains(t, err, "cannot decode arguments of constructor: too many parts: expected 1, got 2")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		builder, err := NewDeployDataBuilder(ArgsNewDeployDataBuilder{})
+		require.NoError(t, err)
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{})
+		require.NoError(t, err)
+
+		metadata := CodeMetadata{Readable: true, PayableBySmartContract: true}
+
+		data, err := builder.BuildDeploy([]byte("abc"), metadata, nil)
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseDeploy(data)
+		require.NoError(t, err)
+		require.Equal(t, []byte("abc"), parsed.Code)
+		require.Equal(t, metadata, parsed.CodeMetadata)
+		require.Empty(t, parsed.RawArguments)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{})
+		require.NoError(t, err)
+
+		_, err = parser.ParseDeploy("616263@0500")
+		require.ErrorContains(t, err, "not enough parts: expected at least 3, got 2")
+
+		_, err = parser.ParseDeploy("@0500@0102")
+		require.ErrorContains(t, err, "code must not be empty")
+
+		_, err = parser.ParseDeploy("616263@0500@01")
+		require.ErrorContains(t, err, "bad code metadata: expected 2 bytes, got 1")
+
+		_, err = parser.ParseDeploy("616263@0500@0102@xy")
+		require.ErrorContains(t, err, "cannot decode deploy data")
+	})
+}
+
+func TestDeployDataParser_ParseUpgrade(t *testing.T) {
+	t.Run("with ABI", func(t *testing.T) {
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseUpgrade("upgradeContract@616263@0100@2a")
+		require.NoError(t, err)
+		require.Equal(t, []byte("abc"), parsed.Code)
+		require.Equal(t, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319", hex.EncodeToString(parsed.CodeHash))
+		require.Nil(t, parsed.VMType)
+		require.Equal(t, CodeMetadata{Upgradeable: true}, parsed.CodeMetadata)
+		require.Equal(t, []NamedValue{{Name: "initial_value", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Arguments)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		parser, err := NewDeployDataParser(ArgsNewDeployDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = parser.ParseUpgrade("add@616263@0100@2a")
+		require.ErrorContains(t, err, "not a contract upgrade: add")
+
+		_, err = parser.ParseUpgrade("upgradeContract@616263")
+		require.ErrorContains(t, err, "not enough parts: expected at least 2, got 1")
+
+		_, err = parser.ParseUpgrade("upgradeContract@616263@0100")
+		require.ErrorContains(t, err, "cannot decode arguments of upgrade constructor: cannot wholly read part 0: unexpected end of data")
+	})
+}
@@ -1,7 +1,6 @@
 package abi
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +11,8 @@ type EnumValue struct {
 	Discriminant   uint8
 	Fields         []Field
 	FieldsProvider func(uint8) []Field
+	// VariantNameProvider (optional) provides the name of a variant, given its discriminant (e.g. for formatting)
+	VariantNameProvider func(uint8) string
 }
 
 // EncodeNested encodes the value in the nested form
@@ -42,6 +43,52 @@ func (value *EnumValue) EncodeTopLevel(writer io.Writer) error {
 	return value.EncodeNested(writer)
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *EnumValue) SizeNested() int {
+	size := 1
+
+	for _, field := range value.Fields {
+		size += sizeNestedOf(field.Value)
+	}
+
+	return size
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *EnumValue) SizeTopLevel() int {
+	if value.Discriminant == 0 && len(value.Fields) == 0 {
+		return 0
+	}
+
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *EnumValue) AppendNested(dst []byte) ([]byte, error) {
+	dst = append(dst, value.Discriminant)
+
+	for _, field := range value.Fields {
+		var err error
+
+		dst, err = appendNestedOf(dst, field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode field '%s' of enum, because of: %w", field.Name, err)
+		}
+	}
+
+	return dst, nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *EnumValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	if value.Discriminant == 0 && len(value.Fields) == 0 {
+		// Write nothing
+		return dst, nil
+	}
+
+	return value.AppendNested(dst)
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *EnumValue) DecodeNested(reader io.Reader) error {
 	if value.FieldsProvider == nil {
@@ -74,6 +121,42 @@ func (value *EnumValue) DecodeTopLevel(data []byte) error {
 		return nil
 	}
 
-	reader := bytes.NewReader(data)
-	return value.DecodeNested(reader)
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *EnumValue) decodeNested(c *cursor) error {
+	if value.FieldsProvider == nil {
+		return errors.New("cannot decode enum: fields provider is nil")
+	}
+
+	discriminant, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	value.Discriminant = discriminant
+	value.Fields = value.FieldsProvider(value.Discriminant)
+
+	for _, field := range value.Fields {
+		err := decodeNestedFromCursor(c, field.Value)
+		if err != nil {
+			return fmt.Errorf("cannot decode field '%s' of enum, because of: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (value *EnumValue) decodeTopLevel(c *cursor) error {
+	if c.isEmpty() {
+		value.Discriminant = 0
+		return nil
+	}
+
+	return value.decodeNested(c)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *EnumValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
 }
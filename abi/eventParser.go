@@ -0,0 +1,132 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParsedEvent is an event (emitted by a contract) decoded according to the ABI
+type ParsedEvent struct {
+	// Identifier is the identifier of the event, as found in the ABI (and in the first topic)
+	Identifier string
+	// Address is the address of the emitting contract
+	Address string
+	// Values holds the decoded inputs of the event, named after them (in the order of the ABI)
+	Values []NamedValue
+}
+
+// ArgsNewEventParser defines the arguments needed for a new event parser
+type ArgsNewEventParser struct {
+	Abi *Definition
+}
+
+// eventParser decodes events emitted by a contract. The first topic is the identifier of the event,
+// the next topics hold the indexed inputs, while the data (or the additional data, if any) holds the other inputs.
+type eventParser struct {
+	callDataParser *callDataParser
+	abi            *Definition
+}
+
+// NewEventParser creates a new event parser
+func NewEventParser(args ArgsNewEventParser) (*eventParser, error) {
+	if args.Abi == nil {
+		return nil, errors.New("cannot create event parser: ABI is required")
+	}
+
+	callDataParser, err := NewCallDataParser(ArgsNewCallDataParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventParser{
+		callDataParser: callDataParser,
+		abi:            args.Abi,
+	}, nil
+}
+
+// CanParse returns whether the event is defined by the ABI
+func (parser *eventParser) CanParse(event *TransactionEvent) bool {
+	if len(event.Topics) == 0 {
+		return false
+	}
+
+	_, err := parser.abi.GetEvent(string(event.Topics[0]))
+	return err == nil
+}
+
+// Parse decodes the given event
+func (parser *eventParser) Parse(event *TransactionEvent) (*ParsedEvent, error) {
+	if len(event.Topics) == 0 {
+		return nil, errors.New("cannot parse event without topics")
+	}
+
+	identifier := string(event.Topics[0])
+
+	definition, err := parser.abi.GetEvent(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	indexedInputs, otherInputs := splitEventInputs(definition.Inputs)
+
+	indexedValues, err := parser.callDataParser.decodeExactly(event.Topics[1:], indexedInputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode topics of event '%s': %w", identifier, err)
+	}
+
+	otherValues, err := parser.callDataParser.decodeExactly(getEventDataParts(event, len(otherInputs)), otherInputs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode data of event '%s': %w", identifier, err)
+	}
+
+	values := make([]NamedValue, 0, len(definition.Inputs))
+
+	for _, input := range definition.Inputs {
+		if input.Indexed {
+			values = append(values, NamedValue{Name: input.Name, Value: indexedValues[0]})
+			indexedValues = indexedValues[1:]
+		} else {
+			values = append(values, NamedValue{Name: input.Name, Value: otherValues[0]})
+			otherValues = otherValues[1:]
+		}
+	}
+
+	return &ParsedEvent{
+		Identifier: identifier,
+		Address:    event.Address,
+		Values:     values,
+	}, nil
+}
+
+func splitEventInputs(inputs []EventInput) ([]Parameter, []Parameter) {
+	indexedInputs := make([]Parameter, 0, len(inputs))
+	otherInputs := make([]Parameter, 0, len(inputs))
+
+	for _, input := range inputs {
+		parameter := Parameter{Name: input.Name, ValueCreator: input.ValueCreator}
+
+		if input.Indexed {
+			indexedInputs = append(indexedInputs, parameter)
+		} else {
+			otherInputs = append(otherInputs, parameter)
+		}
+	}
+
+	return indexedInputs, otherInputs
+}
+
+// getEventDataParts returns the additional data (if any), else the data.
+// Empty data is a (single) empty part, unless no inputs are expected.
+func getEventDataParts(event *TransactionEvent, numInputs int) [][]byte {
+	if len(event.AdditionalData) > 0 {
+		return event.AdditionalData
+	}
+
+	if len(event.Data) == 0 && numInputs == 0 {
+		return [][]byte{}
+	}
+
+	return [][]byte{event.Data}
+}
@@ -0,0 +1,78 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventParser(t *testing.T) {
+	parser, err := NewEventParser(ArgsNewEventParser{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("should parse", func(t *testing.T) {
+		event := &TransactionEvent{
+			Address:    "erd1contract",
+			Identifier: "distribute",
+			Topics:     [][]byte{[]byte("distributed"), []byte("WEGLD-d7c6bb")},
+			Data:       []byte{0x2a},
+		}
+
+		require.True(t, parser.CanParse(event))
+
+		parsed, err := parser.Parse(event)
+		require.NoError(t, err)
+		require.Equal(t, &ParsedEvent{
+			Identifier: "distributed",
+			Address:    "erd1contract",
+			Values: []NamedValue{
+				{Name: "token", Value: &StringValue{Value: "WEGLD-d7c6bb"}},
+				{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(42)}},
+			},
+		}, parsed)
+	})
+
+	t.Run("should parse empty data (as a single, empty part)", func(t *testing.T) {
+		parsed, err := parser.Parse(&TransactionEvent{Topics: [][]byte{[]byte("distributed"), []byte("WEGLD-d7c6bb")}})
+		require.NoError(t, err)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(0)}, parsed.Values[1].Value)
+	})
+
+	t.Run("should prefer additional data", func(t *testing.T) {
+		parsed, err := parser.Parse(&TransactionEvent{
+			Topics:         [][]byte{[]byte("distributed"), []byte("WEGLD-d7c6bb")},
+			Data:           []byte{0x01},
+			AdditionalData: [][]byte{{0x2b}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(43)}, parsed.Values[1].Value)
+	})
+
+	t.Run("should not parse unknown events", func(t *testing.T) {
+		require.False(t, parser.CanParse(&TransactionEvent{Identifier: "writeLog", Topics: [][]byte{[]byte("foo")}}))
+		require.False(t, parser.CanParse(&TransactionEvent{Identifier: "distributed"}))
+
+		_, err := parser.Parse(&TransactionEvent{})
+		require.ErrorContains(t, err, "cannot parse event without topics")
+
+		_, err = parser.Parse(&TransactionEvent{Topics: [][]byte{[]byte("foo")}})
+		require.ErrorContains(t, err, "event not found: foo")
+	})
+
+	t.Run("should err on bad topics or data", func(t *testing.T) {
+		_, err := parser.Parse(&TransactionEvent{Topics: [][]byte{[]byte("distributed")}})
+		require.ErrorContains(t, err, "cannot decode topics of event 'distributed'")
+
+		_, err = parser.Parse(&TransactionEvent{Topics: [][]byte{[]byte("distributed"), []byte("A"), []byte("B")}})
+		require.ErrorContains(t, err, "cannot decode topics of event 'distributed': too many parts: expected 1, got 2")
+
+		_, err = parser.Parse(&TransactionEvent{Topics: [][]byte{[]byte("distributed"), []byte("A")}, AdditionalData: [][]byte{{0x01}, {0x02}}})
+		require.ErrorContains(t, err, "cannot decode data of event 'distributed': too many parts: expected 1, got 2")
+	})
+
+	t.Run("should err without ABI", func(t *testing.T) {
+		_, err := NewEventParser(ArgsNewEventParser{})
+		require.ErrorContains(t, err, "ABI is required")
+	})
+}
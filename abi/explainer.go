@@ -0,0 +1,503 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// ExplanationRuleNested is the rule of values encoded in the nested form (e.g. within lists, structs)
+	ExplanationRuleNested = "nested"
+	// ExplanationRuleTopLevel is the rule of values encoded in the top-level form (a whole part)
+	ExplanationRuleTopLevel = "top-level"
+)
+
+const (
+	// ExplanationKindValue marks the bytes of a (leaf) value
+	ExplanationKindValue = "value"
+	// ExplanationKindLength marks the length prefix of a list, or of a length-prefixed value (e.g. string, big integer)
+	ExplanationKindLength = "length"
+	// ExplanationKindMarker marks the first byte of an option (absent or present)
+	ExplanationKindMarker = "marker"
+	// ExplanationKindDiscriminant marks the discriminant of an enum
+	ExplanationKindDiscriminant = "discriminant"
+	// ExplanationKindUndecoded marks the bytes which could not be decoded (on failure), or which were left unused
+	ExplanationKindUndecoded = "undecoded"
+)
+
+// ExplanationEntry describes a range of bytes, as interpreted while decoding
+type ExplanationEntry struct {
+	// Part is the index of the part holding the bytes (always 0 when explaining a single value)
+	Part int
+	// Offset is the position of the bytes, within the part
+	Offset int
+	// Raw shares memory with the input data
+	Raw []byte
+	// Path follows the same conventions as the ones of ValueDifference (e.g. "$[0].amount")
+	Path  string
+	Rule  string
+	Kind  string
+	Value string
+}
+
+// Explanation holds the entries recorded while decoding, in the order of the bytes
+type Explanation struct {
+	Entries []ExplanationEntry
+}
+
+// String renders the explanation as an annotated hex dump, one line per entry
+func (explanation *Explanation) String() string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "PART\tOFFSET\tHEX\tPATH\tRULE\tKIND\tVALUE")
+
+	for _, entry := range explanation.Entries {
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Part,
+			entry.Offset,
+			hex.EncodeToString(entry.Raw),
+			entry.Path,
+			entry.Rule,
+			entry.Kind,
+			entry.Value,
+		)
+	}
+
+	_ = writer.Flush()
+	return builder.String()
+}
+
+// Explain deserializes the given data into the output values (as Deserialize does), while recording which bytes map to which values.
+// On failure, the explanation recorded so far (including the undecoded bytes) is returned, along with the error.
+func (s *serializer) Explain(data string, outputValues []any) (*Explanation, error) {
+	parts, err := s.decodeIntoParts(data)
+	if err != nil {
+		return nil, err
+	}
+
+	explainer := newExplainer(true)
+
+	err = explainer.explainParts(newPartsHolder(parts), "$", outputValues, positionOfLastValues)
+	return explainer.explanation, err
+}
+
+// ExplainNested decodes the given data into the value (nested form), while recording which bytes map to which (inner) values
+func ExplainNested(data []byte, value SingleValue) (*Explanation, error) {
+	explainer := newExplainer(true)
+	c := newCursor(data, false)
+
+	err := explainer.explainNested(c, "$", value)
+	if err != nil {
+		explainer.addUndecoded(c, err)
+		return explainer.explanation, fmt.Errorf("cannot decode (nested) %T, because of: %w", value, err)
+	}
+
+	explainer.addUnused(c)
+	return explainer.explanation, nil
+}
+
+// ExplainTopLevel decodes the given data into the value (top-level form), while recording which bytes map to which (inner) values
+func ExplainTopLevel(data []byte, value SingleValue) (*Explanation, error) {
+	explainer := newExplainer(true)
+	c := newCursor(data, false)
+
+	err := explainer.explainTopLevel(c, "$", value)
+	if err != nil {
+		explainer.addUndecoded(c, err)
+		return explainer.explanation, fmt.Errorf("cannot decode (top-level) %T, because of: %w", value, err)
+	}
+
+	explainer.addUnused(c)
+	return explainer.explanation, nil
+}
+
+// explainer decodes values (into placeholders), keeping track of positions and paths.
+// It is also used for lenient decoding, in which case no entries are recorded.
+type explainer struct {
+	explanation   *Explanation
+	recordEntries bool
+	part          int
+	// failure is set when decoding fails within a part (or within the data of a single value)
+	failure *DecodingFailure
+	// failedPath is the path of the (innermost) value which failed to decode
+	failedPath string
+}
+
+func newExplainer(recordEntries bool) *explainer {
+	return &explainer{
+		explanation:   &Explanation{},
+		recordEntries: recordEntries,
+	}
+}
+
+// explainParts mirrors serializer.doDeserialize
+func (e *explainer) explainParts(partsHolder *partsHolder, path string, outputValues []any, position valuesPosition) error {
+	for i, value := range outputValues {
+		err := e.explainValue(partsHolder, fmt.Sprintf("%s[%d]", path, i), value, position.ofItem(outputValues, i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *explainer) explainValue(partsHolder *partsHolder, path string, value any, position valuesPosition) error {
+	switch value := value.(type) {
+	case nil:
+		return errors.New("cannot deserialize into nil value")
+	case *OptionalValue:
+		err := position.checkOptionalValue(outputValuesKind)
+		if err != nil {
+			return err
+		}
+
+		if partsHolder.isFocusedBeyondLastPart() {
+			value.Value = nil
+			return nil
+		}
+
+		return e.explainValue(partsHolder, path+".value", value.Value, position)
+	case *MultiValue:
+		return e.explainParts(partsHolder, path, value.Items, position)
+	case *VariadicValues:
+		err := position.checkVariadicValues(outputValuesKind)
+		if err != nil {
+			return err
+		}
+
+		return e.explainVariadicValues(partsHolder, path, value)
+	case SingleValue:
+		return e.explainPart(partsHolder, path, value)
+	case genericValue:
+		untyped := value.toUntyped()
+
+		err := e.explainValue(partsHolder, path, untyped, position)
+		if err != nil {
+			return err
+		}
+
+		return value.fromUntyped(untyped)
+	default:
+		return fmt.Errorf("unsupported type for deserialization: %T", value)
+	}
+}
+
+func (e *explainer) explainVariadicValues(partsHolder *partsHolder, path string, value *VariadicValues) error {
+	if value.ItemCreator == nil {
+		return errors.New("cannot deserialize variadic values: item creator is nil")
+	}
+
+	for !partsHolder.isFocusedBeyondLastPart() {
+		newItem := value.ItemCreator()
+
+		// Each item of the variadic values is deserialized as if it was the last among output values.
+		err := e.explainValue(partsHolder, fmt.Sprintf("%s[%d]", path, len(value.Items)), newItem, positionOfLastValues)
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
+func (e *explainer) explainPart(partsHolder *partsHolder, path string, value SingleValue) error {
+	e.part = int(partsHolder.focusedPartIndex)
+
+	part, err := partsHolder.readWholeFocusedPart()
+	if err != nil {
+		e.markFailedPath(path, err)
+		return err
+	}
+
+	c := newCursor(part, false)
+
+	err = e.explainTopLevel(c, path, value)
+	if err != nil {
+		e.addUndecoded(c, err)
+		return fmt.Errorf("cannot decode (top-level) %T, because of: %w", value, err)
+	}
+
+	e.addUnused(c)
+	return partsHolder.focusOnNextPart()
+}
+
+func (e *explainer) explainNested(c *cursor, path string, value SingleValue) error {
+	err := e.doExplainNested(c, path, value)
+	e.markFailedPath(path, err)
+	return err
+}
+
+func (e *explainer) doExplainNested(c *cursor, path string, value SingleValue) error {
+	switch value := value.(type) {
+	case *OptionValue:
+		if value.Value == nil {
+			return errors.New("placeholder value of option should be set before decoding")
+		}
+
+		marker, err := e.readByte(c, path, ExplanationRuleNested, ExplanationKindMarker)
+		if err != nil {
+			return err
+		}
+
+		if marker == optionMarkerForAbsentValue {
+			value.Value = nil
+			return nil
+		}
+
+		if marker != optionMarkerForPresentValue {
+			return fmt.Errorf("invalid first byte for nested encoded option: %d", marker)
+		}
+
+		return e.explainNested(c, path+".value", value.Value)
+	case *ListValue:
+		start := c.offset
+
+		length, err := c.readLength()
+		if err != nil {
+			return err
+		}
+
+		e.addEntry(c, start, path, ExplanationRuleNested, ExplanationKindLength, fmt.Sprint(length))
+
+		value.Items = make([]SingleValue, 0, c.capacityForItems(length))
+
+		for i := uint32(0); i < length; i++ {
+			err := e.explainListItem(c, path, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *StructValue:
+		return e.explainFields(c, path, value.Fields, "struct")
+	case *EnumValue:
+		if value.FieldsProvider == nil {
+			return errors.New("cannot decode enum: fields provider is nil")
+		}
+
+		discriminant, err := e.readByte(c, path, ExplanationRuleNested, ExplanationKindDiscriminant)
+		if err != nil {
+			return err
+		}
+
+		value.Discriminant = discriminant
+		value.Fields = value.FieldsProvider(discriminant)
+
+		return e.explainFields(c, path, value.Fields, "enum")
+	default:
+		return e.explainLeaf(c, path, value, ExplanationRuleNested)
+	}
+}
+
+func (e *explainer) explainTopLevel(c *cursor, path string, value SingleValue) error {
+	err := e.doExplainTopLevel(c, path, value)
+	e.markFailedPath(path, err)
+	return err
+}
+
+func (e *explainer) doExplainTopLevel(c *cursor, path string, value SingleValue) error {
+	switch value := value.(type) {
+	case *OptionValue:
+		if value.Value == nil {
+			return errors.New("placeholder value of option should be set before decoding")
+		}
+
+		if c.isEmpty() {
+			value.Value = nil
+			return nil
+		}
+
+		marker, err := e.readByte(c, path, ExplanationRuleTopLevel, ExplanationKindMarker)
+		if err != nil {
+			return err
+		}
+
+		if marker != optionMarkerForPresentValue {
+			return fmt.Errorf("invalid first byte for top-level encoded option: %d", marker)
+		}
+
+		return e.explainNested(c, path+".value", value.Value)
+	case *ListValue:
+		value.Items = make([]SingleValue, 0)
+
+		for !c.isEmpty() {
+			err := e.explainListItem(c, path, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *StructValue:
+		return e.explainFields(c, path, value.Fields, "struct")
+	case *EnumValue:
+		if c.isEmpty() {
+			value.Discriminant = 0
+			return nil
+		}
+
+		return e.explainNested(c, path, value)
+	default:
+		return e.explainLeaf(c, path, value, ExplanationRuleTopLevel)
+	}
+}
+
+func (e *explainer) explainListItem(c *cursor, path string, value *ListValue) error {
+	if value.ItemCreator == nil {
+		return errors.New("cannot decode list: item creator is nil")
+	}
+
+	newItem := value.ItemCreator()
+
+	err := e.explainNested(c, fmt.Sprintf("%s[%d]", path, len(value.Items)), newItem)
+	if err != nil {
+		return err
+	}
+
+	value.Items = append(value.Items, newItem)
+	return nil
+}
+
+func (e *explainer) explainFields(c *cursor, path string, fields []Field, kind string) error {
+	for i, field := range fields {
+		err := e.explainNested(c, pathOfField(path, i, field.Name), field.Value)
+		if err != nil {
+			return fmt.Errorf("cannot decode field '%s' of %s, because of: %w", field.Name, kind, err)
+		}
+	}
+
+	return nil
+}
+
+// explainLeaf decodes a value which does not hold other values (and values not known to the explainer, as a whole).
+// The length prefix of nested length-prefixed values (e.g. strings, big integers) is recorded separately.
+func (e *explainer) explainLeaf(c *cursor, path string, value SingleValue, rule string) error {
+	start := c.offset
+
+	var err error
+	if rule == ExplanationRuleNested {
+		err = decodeNestedFromCursor(c, value)
+	} else {
+		err = decodeTopLevelFromCursor(c, value)
+	}
+	if err != nil {
+		// The failure is reported from the start of the value.
+		c.offset = start
+		return err
+	}
+
+	if !e.recordEntries {
+		return nil
+	}
+
+	if rule == ExplanationRuleNested && isLengthPrefixedValue(value) && c.offset-start >= lengthPrefixSize {
+		lengthCursor := newCursor(c.data[start:start+lengthPrefixSize], false)
+		length, _ := lengthCursor.readLength()
+
+		e.explanation.Entries = append(e.explanation.Entries, ExplanationEntry{
+			Part:   e.part,
+			Offset: start,
+			Raw:    c.data[start : start+lengthPrefixSize],
+			Path:   path,
+			Rule:   rule,
+			Kind:   ExplanationKindLength,
+			Value:  fmt.Sprint(length),
+		})
+
+		start += lengthPrefixSize
+	}
+
+	e.addEntry(c, start, path, rule, ExplanationKindValue, defaultFormatter.Format(value))
+	return nil
+}
+
+func isLengthPrefixedValue(value SingleValue) bool {
+	switch value.(type) {
+	case *StringValue, *BytesValue, *BigIntValue, *BigUIntValue:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *explainer) readByte(c *cursor, path string, rule string, kind string) (byte, error) {
+	start := c.offset
+
+	b, err := c.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	e.addEntry(c, start, path, rule, kind, fmt.Sprint(b))
+	return b, nil
+}
+
+// addEntry records the bytes between "start" and the current position of the cursor
+func (e *explainer) addEntry(c *cursor, start int, path string, rule string, kind string, value string) {
+	if !e.recordEntries {
+		return
+	}
+
+	e.explanation.Entries = append(e.explanation.Entries, ExplanationEntry{
+		Part:   e.part,
+		Offset: start,
+		Raw:    c.data[start:c.offset],
+		Path:   path,
+		Rule:   rule,
+		Kind:   kind,
+		Value:  value,
+	})
+}
+
+// markFailedPath remembers the path of the innermost value which failed to decode
+func (e *explainer) markFailedPath(path string, err error) {
+	if err != nil && e.failedPath == "" {
+		e.failedPath = path
+	}
+}
+
+// addUndecoded records the bytes which could not be decoded (from the position of the failure onwards)
+func (e *explainer) addUndecoded(c *cursor, err error) {
+	e.failure = &DecodingFailure{
+		Path:           e.failedPath,
+		Part:           e.part,
+		Offset:         c.offset,
+		RemainingBytes: c.data[c.offset:],
+	}
+
+	if !e.recordEntries {
+		return
+	}
+
+	e.explanation.Entries = append(e.explanation.Entries, ExplanationEntry{
+		Part:   e.part,
+		Offset: c.offset,
+		Raw:    c.data[c.offset:],
+		Path:   e.failedPath,
+		Kind:   ExplanationKindUndecoded,
+		Value:  err.Error(),
+	})
+}
+
+// addUnused records the bytes left unused after decoding, if any
+func (e *explainer) addUnused(c *cursor) {
+	if c.isEmpty() || !e.recordEntries {
+		return
+	}
+
+	e.explanation.Entries = append(e.explanation.Entries, ExplanationEntry{
+		Part:   e.part,
+		Offset: c.offset,
+		Raw:    c.data[c.offset:],
+		Kind:   ExplanationKindUndecoded,
+		Value:  "unused bytes",
+	})
+}
@@ -0,0 +1,150 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainNested(t *testing.T) {
+	t.Run("should explain struct", func(t *testing.T) {
+		data, _ := hex.DecodeString("00000003414243" + "00000002" + "0001" + "0002" + "01" + "00000001" + "64")
+		value := &StructValue{
+			Fields: []Field{
+				{Name: "name", Value: &StringValue{}},
+				{Name: "items", Value: &ListValue{ItemCreator: func() SingleValue { return &U16Value{} }}},
+				{Name: "amount", Value: &OptionValue{Value: &BigUIntValue{}}},
+			},
+		}
+
+		explanation, err := ExplainNested(data, value)
+		require.NoError(t, err)
+		require.Equal(t, []ExplanationEntry{
+			{Offset: 0, Raw: []byte{0, 0, 0, 3}, Path: "$.name", Rule: "nested", Kind: "length", Value: "3"},
+			{Offset: 4, Raw: []byte("ABC"), Path: "$.name", Rule: "nested", Kind: "value", Value: `"ABC"`},
+			{Offset: 7, Raw: []byte{0, 0, 0, 2}, Path: "$.items", Rule: "nested", Kind: "length", Value: "2"},
+			{Offset: 11, Raw: []byte{0, 1}, Path: "$.items[0]", Rule: "nested", Kind: "value", Value: "1"},
+			{Offset: 13, Raw: []byte{0, 2}, Path: "$.items[1]", Rule: "nested", Kind: "value", Value: "2"},
+			{Offset: 15, Raw: []byte{1}, Path: "$.amount", Rule: "nested", Kind: "marker", Value: "1"},
+			{Offset: 16, Raw: []byte{0, 0, 0, 1}, Path: "$.amount.value", Rule: "nested", Kind: "length", Value: "1"},
+			{Offset: 20, Raw: []byte{0x64}, Path: "$.amount.value", Rule: "nested", Kind: "value", Value: "100"},
+		}, explanation.Entries)
+	})
+
+	t.Run("should explain list of enums", func(t *testing.T) {
+		data, _ := hex.DecodeString("00000002" + "00" + "01" + "0000002a")
+		value := &ListValue{ItemCreator: func() SingleValue {
+			return &EnumValue{FieldsProvider: func(discriminant uint8) []Field {
+				if discriminant == 1 {
+					return []Field{{Name: "x", Value: &U32Value{}}}
+				}
+
+				return nil
+			}}
+		}}
+
+		explanation, err := ExplainNested(data, value)
+		require.NoError(t, err)
+		require.Equal(t, []ExplanationEntry{
+			{Offset: 0, Raw: []byte{0, 0, 0, 2}, Path: "$", Rule: "nested", Kind: "length", Value: "2"},
+			{Offset: 4, Raw: []byte{0}, Path: "$[0]", Rule: "nested", Kind: "discriminant", Value: "0"},
+			{Offset: 5, Raw: []byte{1}, Path: "$[1]", Rule: "nested", Kind: "discriminant", Value: "1"},
+			{Offset: 6, Raw: []byte{0, 0, 0, 0x2a}, Path: "$[1].x", Rule: "nested", Kind: "value", Value: "42"},
+		}, explanation.Entries)
+
+		// The value is decoded, as well
+		require.Len(t, value.Items, 2)
+		require.Equal(t, uint8(1), value.Items[1].(*EnumValue).Discriminant)
+	})
+
+	t.Run("should explain failure", func(t *testing.T) {
+		data, _ := hex.DecodeString("0000002a" + "00000005414243")
+		value := &StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U32Value{}},
+				{Name: "b", Value: &BytesValue{}},
+			},
+		}
+
+		explanation, err := ExplainNested(data, value)
+		require.ErrorContains(t, err, "cannot decode (nested) *abi.StructValue, because of: cannot decode field 'b' of struct")
+		require.Equal(t, []ExplanationEntry{
+			{Offset: 0, Raw: []byte{0, 0, 0, 0x2a}, Path: "$.a", Rule: "nested", Kind: "value", Value: "42"},
+			{Offset: 4, Raw: []byte{0, 0, 0, 5, 0x41, 0x42, 0x43}, Path: "$.b", Kind: "undecoded", Value: "cannot decode field 'b' of struct, because of: cannot read exactly 5 bytes"},
+		}, explanation.Entries)
+	})
+
+	t.Run("should explain unused bytes", func(t *testing.T) {
+		explanation, err := ExplainNested([]byte{0x01, 0x02}, &U8Value{})
+		require.NoError(t, err)
+		require.Equal(t, []ExplanationEntry{
+			{Offset: 0, Raw: []byte{0x01}, Path: "$", Rule: "nested", Kind: "value", Value: "1"},
+			{Offset: 1, Raw: []byte{0x02}, Kind: "undecoded", Value: "unused bytes"},
+		}, explanation.Entries)
+	})
+}
+
+func TestExplainTopLevel(t *testing.T) {
+	value := &OptionValue{Value: &BigIntValue{}}
+
+	explanation, err := ExplainTopLevel([]byte{0x01, 0, 0, 0, 1, 0xff}, value)
+	require.NoError(t, err)
+	require.Equal(t, []ExplanationEntry{
+		{Offset: 0, Raw: []byte{0x01}, Path: "$", Rule: "top-level", Kind: "marker", Value: "1"},
+		{Offset: 1, Raw: []byte{0, 0, 0, 1}, Path: "$.value", Rule: "nested", Kind: "length", Value: "1"},
+		{Offset: 5, Raw: []byte{0xff}, Path: "$.value", Rule: "nested", Kind: "value", Value: "-1"},
+	}, explanation.Entries)
+	require.Equal(t, big.NewInt(-1), value.Value.(*BigIntValue).Value)
+}
+
+func TestSerializer_Explain(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should explain multi-part data", func(t *testing.T) {
+		variadic := &VariadicValues{ItemCreator: func() any { return &U16Value{} }}
+		values := []any{
+			&StringValue{},
+			&MultiValue{Items: []any{&U8Value{}, &BoolValue{}}},
+			variadic,
+		}
+
+		explanation, err := serializer.Explain("616263@2a@01@0102@0304", values)
+		require.NoError(t, err)
+		require.Equal(t, []ExplanationEntry{
+			{Part: 0, Offset: 0, Raw: []byte("abc"), Path: "$[0]", Rule: "top-level", Kind: "value", Value: `"abc"`},
+			{Part: 1, Offset: 0, Raw: []byte{0x2a}, Path: "$[1][0]", Rule: "top-level", Kind: "value", Value: "42"},
+			{Part: 2, Offset: 0, Raw: []byte{0x01}, Path: "$[1][1]", Rule: "top-level", Kind: "value", Value: "true"},
+			{Part: 3, Offset: 0, Raw: []byte{0x01, 0x02}, Path: "$[2][0]", Rule: "top-level", Kind: "value", Value: "258"},
+			{Part: 4, Offset: 0, Raw: []byte{0x03, 0x04}, Path: "$[2][1]", Rule: "top-level", Kind: "value", Value: "772"},
+		}, explanation.Entries)
+		require.Len(t, variadic.Items, 2)
+
+		require.Equal(t, ""+
+			"PART  OFFSET  HEX     PATH     RULE       KIND   VALUE\n"+
+			"0     0       616263  $[0]     top-level  value  \"abc\"\n"+
+			"1     0       2a      $[1][0]  top-level  value  42\n"+
+			"2     0       01      $[1][1]  top-level  value  true\n"+
+			"3     0       0102    $[2][0]  top-level  value  258\n"+
+			"4     0       0304    $[2][1]  top-level  value  772\n",
+			explanation.String())
+	})
+
+	t.Run("should explain failure within part", func(t *testing.T) {
+		explanation, err := serializer.Explain("2a@0000000141", []any{&U8Value{}, &ListValue{ItemCreator: func() SingleValue { return &U16Value{} }}})
+		require.ErrorContains(t, err, "cannot decode (top-level) *abi.ListValue")
+		require.Equal(t, []ExplanationEntry{
+			{Part: 0, Offset: 0, Raw: []byte{0x2a}, Path: "$[0]", Rule: "top-level", Kind: "value", Value: "42"},
+			{Part: 1, Offset: 0, Raw: []byte{0, 0}, Path: "$[1][0]", Rule: "nested", Kind: "value", Value: "0"},
+			{Part: 1, Offset: 2, Raw: []byte{0, 1}, Path: "$[1][1]", Rule: "nested", Kind: "value", Value: "1"},
+			{Part: 1, Offset: 4, Raw: []byte{0x41}, Path: "$[1][2]", Kind: "undecoded", Value: "cannot read exactly 2 bytes"},
+		}, explanation.Entries)
+	})
+
+	t.Run("should err on bad placeholders", func(t *testing.T) {
+		_, err := serializer.Explain("2a", []any{nil})
+		require.ErrorContains(t, err, "cannot deserialize into nil value")
+	})
+}
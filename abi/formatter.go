@@ -0,0 +1,279 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+const defaultAddressHrp = "erd"
+const defaultIndentation = "  "
+
+var defaultFormatter = &formatter{
+	addressHrp:  defaultAddressHrp,
+	indentation: defaultIndentation,
+}
+
+// ArgsNewFormatter defines the arguments needed for a new formatter
+type ArgsNewFormatter struct {
+	// AddressHrp is the human-readable part of bech32 addresses (e.g. "erd")
+	AddressHrp string
+	// Indentation is used (once per nesting level) when formatting values on multiple lines
+	Indentation string
+}
+
+// formatter renders value trees (single values or multi-values) in a human-readable, Rust-debug-like form.
+type formatter struct {
+	addressHrp  string
+	indentation string
+}
+
+// NewFormatter creates a new formatter.
+func NewFormatter(args ArgsNewFormatter) (*formatter, error) {
+	if args.AddressHrp == "" {
+		return nil, errors.New("cannot create formatter: address HRP must not be empty")
+	}
+
+	return &formatter{
+		addressHrp:  args.AddressHrp,
+		indentation: args.Indentation,
+	}, nil
+}
+
+// Format renders the given value on a single line, e.g. "{ to: erd1..., amount: 1000 }"
+func (f *formatter) Format(value any) string {
+	builder := &strings.Builder{}
+	f.formatValue(builder, value, -1)
+	return builder.String()
+}
+
+// FormatIndented renders the given value on multiple lines, one line per (nested) item or field
+func (f *formatter) FormatIndented(value any) string {
+	builder := &strings.Builder{}
+	f.formatValue(builder, value, 0)
+	return builder.String()
+}
+
+// formatValue writes the value into the builder. A negative depth means "single line".
+func (f *formatter) formatValue(builder *strings.Builder, value any, depth int) {
+	switch value := value.(type) {
+	case nil:
+		builder.WriteString("nil")
+	case *U8Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *U16Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *U32Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *U64Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *I8Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *I16Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *I32Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *I64Value:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *BigIntValue:
+		builder.WriteString(f.formatBigInt(value.Value))
+	case *BigUIntValue:
+		builder.WriteString(f.formatBigInt(value.Value))
+	case *BoolValue:
+		builder.WriteString(fmt.Sprint(value.Value))
+	case *StringValue:
+		builder.WriteString(fmt.Sprintf("%q", value.Value))
+	case *BytesValue:
+		builder.WriteString("0x" + hex.EncodeToString(value.Value))
+	case *AddressValue:
+		builder.WriteString(f.formatAddress(value.Value))
+	case *OptionValue:
+		if value.Value == nil {
+			builder.WriteString("None")
+			return
+		}
+
+		builder.WriteString("Some(")
+		f.formatValue(builder, value.Value, depth)
+		builder.WriteString(")")
+	case *ListValue:
+		f.formatItems(builder, "[", "]", singleValuesAsAny(value.Items), depth)
+	case *StructValue:
+		f.formatFields(builder, value.Fields, depth)
+	case *EnumValue:
+		f.formatEnum(builder, value, depth)
+	case *OptionalValue:
+		if value.Value == nil {
+			builder.WriteString("OptionalValue::None")
+			return
+		}
+
+		builder.WriteString("OptionalValue::Some(")
+		f.formatValue(builder, value.Value, depth)
+		builder.WriteString(")")
+	case *MultiValue:
+		f.formatItems(builder, "multi(", ")", value.Items, depth)
+	case *VariadicValues:
+		f.formatItems(builder, "variadic[", "]", value.Items, depth)
+	case genericValue:
+		f.formatValue(builder, value.toUntyped(), depth)
+	default:
+		builder.WriteString(fmt.Sprintf("%T", value))
+	}
+}
+
+func (f *formatter) formatEnum(builder *strings.Builder, value *EnumValue, depth int) {
+	if value.VariantNameProvider != nil {
+		builder.WriteString(value.VariantNameProvider(value.Discriminant))
+	}
+
+	builder.WriteString(fmt.Sprintf("#%d", value.Discriminant))
+
+	if len(value.Fields) == 0 {
+		return
+	}
+
+	builder.WriteString(" ")
+	f.formatFields(builder, value.Fields, depth)
+}
+
+func (f *formatter) formatFields(builder *strings.Builder, fields []Field, depth int) {
+	if len(fields) == 0 {
+		builder.WriteString("{}")
+		return
+	}
+
+	builder.WriteString("{")
+
+	for i, field := range fields {
+		f.writeItemPrefix(builder, i, depth+1, " ")
+
+		if field.Name != "" {
+			builder.WriteString(field.Name)
+		} else {
+			builder.WriteString(fmt.Sprint(i))
+		}
+
+		builder.WriteString(": ")
+		f.formatValue(builder, singleValueAsAny(field.Value), f.nextDepth(depth))
+	}
+
+	f.writeClosingPrefix(builder, depth, " ")
+	builder.WriteString("}")
+}
+
+func (f *formatter) formatItems(builder *strings.Builder, opening string, closing string, items []any, depth int) {
+	builder.WriteString(opening)
+
+	if len(items) == 0 {
+		builder.WriteString(closing)
+		return
+	}
+
+	for i, item := range items {
+		f.writeItemPrefix(builder, i, depth+1, "")
+		f.formatValue(builder, item, f.nextDepth(depth))
+	}
+
+	f.writeClosingPrefix(builder, depth, "")
+	builder.WriteString(closing)
+}
+
+// writeItemPrefix writes the separator before an item (or field): a new line (multi-line form), or a comma (single-line form)
+func (f *formatter) writeItemPrefix(builder *strings.Builder, index int, depth int, singleLinePadding string) {
+	if depth > 0 {
+		if index > 0 {
+			builder.WriteString(",")
+		}
+
+		builder.WriteString("\n")
+		builder.WriteString(strings.Repeat(f.indentation, depth))
+		return
+	}
+
+	if index > 0 {
+		builder.WriteString(", ")
+	} else {
+		builder.WriteString(singleLinePadding)
+	}
+}
+
+// writeClosingPrefix writes the separator before a closing bracket
+func (f *formatter) writeClosingPrefix(builder *strings.Builder, depth int, singleLinePadding string) {
+	if depth >= 0 {
+		builder.WriteString(",\n")
+		builder.WriteString(strings.Repeat(f.indentation, depth))
+		return
+	}
+
+	builder.WriteString(singleLinePadding)
+}
+
+func (f *formatter) nextDepth(depth int) int {
+	if depth < 0 {
+		return depth
+	}
+
+	return depth + 1
+}
+
+func (f *formatter) formatBigInt(value *big.Int) string {
+	if value == nil {
+		return "nil"
+	}
+
+	return value.String()
+}
+
+func (f *formatter) formatAddress(pubkey []byte) string {
+	if len(pubkey) != pubKeyLength {
+		return "0x" + hex.EncodeToString(pubkey)
+	}
+
+	address, err := encodeBech32(f.addressHrp, pubkey)
+	if err != nil {
+		return "0x" + hex.EncodeToString(pubkey)
+	}
+
+	return address
+}
+
+// formatWithVerb implements the logic of fmt.Formatter, for all value types:
+// "%v" and "%s" render the value on a single line, while "%+v" renders the value on multiple lines.
+// Other verbs (e.g. "%d", "%x") fall back to the default formatting of Go values.
+func formatWithVerb(state fmt.State, verb rune, value any) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			_, _ = state.Write([]byte(defaultFormatter.FormatIndented(value)))
+			return
+		}
+
+		_, _ = state.Write([]byte(defaultFormatter.Format(value)))
+	case 's':
+		_, _ = state.Write([]byte(defaultFormatter.Format(value)))
+	default:
+		formatWithDefaultVerb(state, verb, value)
+	}
+}
+
+// formatWithDefaultVerb formats the value (a pointer to a struct) as fmt would do if the value didn't implement fmt.Formatter:
+// the pointed struct is formatted instead (its type has no "Format" method, since all values implement it on the pointer receiver).
+func formatWithDefaultVerb(state fmt.State, verb rune, value any) {
+	pointer := reflect.ValueOf(value)
+	if pointer.Kind() != reflect.Pointer {
+		_, _ = fmt.Fprintf(state, "%%!%c(%T)", verb, value)
+		return
+	}
+
+	if pointer.IsNil() {
+		_, _ = state.Write([]byte("<nil>"))
+		return
+	}
+
+	_, _ = state.Write([]byte("&"))
+	_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), pointer.Elem().Interface())
+}
@@ -0,0 +1,109 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatter(t *testing.T) {
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	value := &StructValue{
+		Fields: []Field{
+			{Name: "to", Value: &AddressValue{Value: alicePubKey}},
+			{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(0).SetUint64(1_000_000_000_000_000_000)}},
+			{Name: "tokens", Value: &ListValue{Items: []SingleValue{&StringValue{Value: "FOO"}, &StringValue{Value: "BAR"}}}},
+			{Name: "gas_limit", Value: &OptionValue{Value: &U64Value{Value: 15000000}}},
+			{Name: "data", Value: &BytesValue{Value: []byte{0x03, 0x42}}},
+			{
+				Name: "action",
+				Value: &EnumValue{
+					Discriminant:        1,
+					Fields:              []Field{{Value: &I8Value{Value: -1}}, {Value: &BoolValue{Value: true}}},
+					VariantNameProvider: func(uint8) string { return "Transfer" },
+				},
+			},
+			{Name: "status", Value: &EnumValue{Discriminant: 0}},
+			{Name: "nothing", Value: &OptionValue{}},
+			{Name: "empty", Value: &ListValue{}},
+		},
+	}
+
+	t.Run("should format on a single line", func(t *testing.T) {
+		formatted := defaultFormatter.Format(value)
+
+		require.Equal(t,
+			`{ to: erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th, amount: 1000000000000000000, `+
+				`tokens: ["FOO", "BAR"], gas_limit: Some(15000000), data: 0x0342, action: Transfer#1 { 0: -1, 1: true }, `+
+				`status: #0, nothing: None, empty: [] }`,
+			formatted,
+		)
+	})
+
+	t.Run("should format on multiple lines", func(t *testing.T) {
+		formatted := defaultFormatter.FormatIndented(value)
+
+		require.Equal(t, `{
+  to: erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th,
+  amount: 1000000000000000000,
+  tokens: [
+    "FOO",
+    "BAR",
+  ],
+  gas_limit: Some(15000000),
+  data: 0x0342,
+  action: Transfer#1 {
+    0: -1,
+    1: true,
+  },
+  status: #0,
+  nothing: None,
+  empty: [],
+}`, formatted)
+	})
+
+	t.Run("should format multi-values", func(t *testing.T) {
+		formatted := defaultFormatter.Format(&MultiValue{
+			Items: []any{
+				&U8Value{Value: 42},
+				&OptionalValue{Value: &BigIntValue{Value: big.NewInt(-7)}},
+				&OptionalValue{},
+				&VariadicValues{Items: []any{&U16Value{Value: 1}, &U16Value{Value: 2}}},
+			},
+		})
+
+		require.Equal(t, "multi(42, OptionalValue::Some(-7), OptionalValue::None, variadic[1, 2])", formatted)
+	})
+
+	t.Run("should use the configured address HRP", func(t *testing.T) {
+		formatter, err := NewFormatter(ArgsNewFormatter{AddressHrp: "test", Indentation: "\t"})
+		require.NoError(t, err)
+
+		formatted := formatter.FormatIndented(&ListValue{Items: []SingleValue{&AddressValue{Value: alicePubKey}, &AddressValue{Value: []byte{0x01}}}})
+		require.Equal(t, "[\n\ttest1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ss5hqhtr,\n\t0x01,\n]", formatted)
+	})
+
+	t.Run("should err on missing address HRP", func(t *testing.T) {
+		_, err := NewFormatter(ArgsNewFormatter{})
+		require.ErrorContains(t, err, "cannot create formatter: address HRP must not be empty")
+	})
+
+	t.Run("should implement fmt.Formatter", func(t *testing.T) {
+		require.Equal(t, "Some(42)", fmt.Sprintf("%v", &OptionValue{Value: &U8Value{Value: 42}}))
+		require.Equal(t, `"hello"`, fmt.Sprintf("%s", &StringValue{Value: "hello"}))
+		require.Equal(t, "[\n  1,\n  2,\n]", fmt.Sprintf("%+v", &ListValue{Items: []SingleValue{&U32Value{Value: 1}, &U32Value{Value: 2}}}))
+	})
+
+	t.Run("should fall back to default formatting for other verbs", func(t *testing.T) {
+		require.Equal(t, "&{42}", fmt.Sprintf("%d", &U8Value{Value: 42}))
+		require.Equal(t, "&{2a}", fmt.Sprintf("%x", &U8Value{Value: 42}))
+		require.Equal(t, "&{ff}", fmt.Sprintf("%x", &BytesValue{Value: []byte{0xff}}))
+		require.Equal(t, `&{"hello"}`, fmt.Sprintf("%q", &StringValue{Value: "hello"}))
+		require.Equal(t, "<nil>", fmt.Sprintf("%d", (*U8Value)(nil)))
+		require.Equal(t, "*abi.U8Value", fmt.Sprintf("%T", &U8Value{Value: 42}))
+	})
+}
@@ -0,0 +1,222 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+)
+
+// Array is a typed, fixed-length array of values (e.g. "array32<u8>" in ABI terms).
+// Unlike lists, arrays are encoded without a length prefix (both nested and top-level), since their length is known.
+// ItemCreator is optional if T is a pointer to a struct type whose zero value is usable as a placeholder for decoding (e.g. *U8Value).
+type Array[T SingleValue] struct {
+	Items       []T
+	Length      int
+	ItemCreator func() T
+}
+
+// NewArray creates an array of the given length, with no items (a placeholder for decoding)
+func NewArray[T SingleValue](length int) *Array[T] {
+	return &Array[T]{Length: length}
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *Array[T]) EncodeNested(writer io.Writer) error {
+	err := value.checkLength()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range value.Items {
+		err := item.EncodeNested(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *Array[T]) EncodeTopLevel(writer io.Writer) error {
+	return value.EncodeNested(writer)
+}
+
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *Array[T]) SizeNested() int {
+	size := 0
+
+	for _, item := range value.Items {
+		size += sizeNestedOf(item)
+	}
+
+	return size
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *Array[T]) SizeTopLevel() int {
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *Array[T]) AppendNested(dst []byte) ([]byte, error) {
+	err := value.checkLength()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range value.Items {
+		dst, err = appendNestedOf(dst, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *Array[T]) AppendTopLevel(dst []byte) ([]byte, error) {
+	return value.AppendNested(dst)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *Array[T]) DecodeNested(reader io.Reader) error {
+	err := value.checkDecodableLength()
+	if err != nil {
+		return err
+	}
+
+	value.Items = make([]T, 0, capacityForItemsOfReader(reader, value.Length))
+
+	for i := 0; i < value.Length; i++ {
+		newItem, err := newGenericItem(value.ItemCreator)
+		if err != nil {
+			return err
+		}
+
+		err = newItem.DecodeNested(reader)
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *Array[T]) DecodeTopLevel(data []byte) error {
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *Array[T]) decodeNested(c *cursor) error {
+	err := value.checkDecodableLength()
+	if err != nil {
+		return err
+	}
+
+	value.Items = make([]T, 0, c.capacityForItems(uint32(value.Length)))
+
+	for i := 0; i < value.Length; i++ {
+		newItem, err := newGenericItem(value.ItemCreator)
+		if err != nil {
+			return err
+		}
+
+		err = decodeNestedFromCursor(c, newItem)
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
+func (value *Array[T]) skipNested(c *cursor) error {
+	err := value.checkDecodableLength()
+	if err != nil {
+		return err
+	}
+
+	newItem, err := newGenericItem(value.ItemCreator)
+	if err != nil {
+		return err
+	}
+
+	return skipItems(c, newItem, value.Length)
+}
+
+func (value *Array[T]) decodeTopLevel(c *cursor) error {
+	err := value.decodeNested(c)
+	if err != nil {
+		return err
+	}
+
+	if !c.isEmpty() {
+		return fmt.Errorf("unexpected data after array of length %d: %d bytes", value.Length, c.remaining())
+	}
+
+	return nil
+}
+
+func (value *Array[T]) checkDecodableLength() error {
+	if value.Length < 0 {
+		return fmt.Errorf("array has invalid length: %d", value.Length)
+	}
+
+	return nil
+}
+
+func (value *Array[T]) checkLength() error {
+	if len(value.Items) != value.Length {
+		return fmt.Errorf("array has invalid number of items: %d (expected: %d)", len(value.Items), value.Length)
+	}
+
+	return nil
+}
+
+// ToListValue converts the array to an (untyped) ListValue, which shares the items with the array.
+// Note that the ListValue follows the encoding rules of lists (not arrays).
+func (value *Array[T]) ToListValue() *ListValue {
+	return &ListValue{
+		Items:       genericItemsToUntyped(value.Items),
+		ItemCreator: untypedItemCreator(value.ItemCreator),
+	}
+}
+
+func (value *Array[T]) toUntyped() any {
+	return value.ToListValue()
+}
+
+func (value *Array[T]) fromUntyped(untyped any) error {
+	listValue, ok := untyped.(*ListValue)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to a typed array", untyped)
+	}
+
+	items, err := genericItemsFromUntyped[T](listValue.Items)
+	if err != nil {
+		return err
+	}
+
+	value.Items = items
+	value.Length = len(items)
+	return nil
+}
+
+func (value *Array[T]) clone() (any, error) {
+	items, err := cloneGenericItems(value.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Array[T]{Items: items, Length: value.Length, ItemCreator: value.ItemCreator}, nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *Array[T]) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
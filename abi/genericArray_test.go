@@ -0,0 +1,74 @@
+package abi
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArray(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec,
+			&Array[*U16Value]{Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}}, Length: 2},
+			"41424344",
+		)
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec,
+			&Array[*U8Value]{Items: []*U8Value{{Value: 0x41}, {Value: 0x42}, {Value: 0x43}}, Length: 3},
+			"414243",
+		)
+	})
+
+	t.Run("should err on encode, if the number of items differs from the length", func(t *testing.T) {
+		_, err := codec.EncodeNested(&Array[*U8Value]{Items: []*U8Value{{Value: 0x41}}, Length: 2})
+		require.ErrorContains(t, err, "array has invalid number of items: 1 (expected: 2)")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec,
+			"4142434445",
+			NewArray[*U16Value](2),
+			&Array[*U16Value]{Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}}, Length: 2},
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec,
+			"4142",
+			NewArray[*U8Value](2),
+			&Array[*U8Value]{Items: []*U8Value{{Value: 0x41}, {Value: 0x42}}, Length: 2},
+		)
+	})
+
+	t.Run("should err on decode", func(t *testing.T) {
+		testDecodeNestedWithError(t, codec, "4142", NewArray[*U16Value](2), "cannot read exactly 2 bytes")
+		testDecodeTopLevelWithError(t, codec, "414243", NewArray[*U8Value](2), "unexpected data after array of length 2: 1 bytes")
+		testDecodeNestedWithError(t, codec, "41", NewArray[*U8Value](-1), "array has invalid length: -1")
+		testDecodeNestedWithError(t, codec, "41", NewArray[*StructValue](1), "cannot create item of type *abi.StructValue: a creator is required")
+
+		err := NewArray[*U8Value](math.MaxInt32).DecodeNested(bytes.NewReader([]byte{0x41}))
+		require.ErrorContains(t, err, "EOF")
+	})
+
+	t.Run("should decode nested (with item creator)", func(t *testing.T) {
+		array := &Array[*OptionValue]{
+			Length:      2,
+			ItemCreator: func() *OptionValue { return &OptionValue{Value: &U8Value{}} },
+		}
+
+		err := codec.DecodeNested([]byte{0x01, 0x2a, 0x00}, array)
+		require.NoError(t, err)
+		require.Equal(t, []*OptionValue{{Value: &U8Value{Value: 42}}, {}}, array.Items)
+	})
+
+	t.Run("should convert to ListValue", func(t *testing.T) {
+		array := &Array[*U8Value]{Items: []*U8Value{{Value: 1}}, Length: 1}
+		require.Equal(t, []SingleValue{&U8Value{Value: 1}}, array.ToListValue().Items)
+	})
+}
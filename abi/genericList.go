@@ -0,0 +1,215 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+)
+
+// List is a typed list of values (the generic counterpart of ListValue).
+// ItemCreator is optional if T is a pointer to a struct type whose zero value is usable as a placeholder for decoding (e.g. *U8Value).
+type List[T SingleValue] struct {
+	Items       []T
+	ItemCreator func() T
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *List[T]) EncodeNested(writer io.Writer) error {
+	err := encodeLength(writer, uint32(len(value.Items)))
+	if err != nil {
+		return err
+	}
+
+	return value.EncodeTopLevel(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *List[T]) EncodeTopLevel(writer io.Writer) error {
+	for _, item := range value.Items {
+		err := item.EncodeNested(writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *List[T]) SizeNested() int {
+	return lengthPrefixSize + value.SizeTopLevel()
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *List[T]) SizeTopLevel() int {
+	size := 0
+
+	for _, item := range value.Items {
+		size += sizeNestedOf(item)
+	}
+
+	return size
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *List[T]) AppendNested(dst []byte) ([]byte, error) {
+	dst = appendLength(dst, uint32(len(value.Items)))
+	return value.AppendTopLevel(dst)
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *List[T]) AppendTopLevel(dst []byte) ([]byte, error) {
+	for _, item := range value.Items {
+		var err error
+
+		dst, err = appendNestedOf(dst, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *List[T]) DecodeNested(reader io.Reader) error {
+	length, err := decodeLength(reader)
+	if err != nil {
+		return err
+	}
+
+	value.Items = make([]T, 0, capacityForItemsOfReader(reader, int(length)))
+
+	for i := uint32(0); i < length; i++ {
+		newItem, err := newGenericItem(value.ItemCreator)
+		if err != nil {
+			return err
+		}
+
+		err = newItem.DecodeNested(reader)
+		if err != nil {
+			return err
+		}
+
+		value.Items = append(value.Items, newItem)
+	}
+
+	return nil
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *List[T]) DecodeTopLevel(data []byte) error {
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *List[T]) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	value.Items = make([]T, 0, c.capacityForItems(length))
+
+	for i := uint32(0); i < length; i++ {
+		err := value.decodeItem(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (value *List[T]) skipNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	newItem, err := newGenericItem(value.ItemCreator)
+	if err != nil {
+		return err
+	}
+
+	return skipItems(c, newItem, int(length))
+}
+
+func (value *List[T]) decodeTopLevel(c *cursor) error {
+	value.Items = make([]T, 0)
+
+	for !c.isEmpty() {
+		err := value.decodeItem(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (value *List[T]) decodeItem(c *cursor) error {
+	newItem, err := newGenericItem(value.ItemCreator)
+	if err != nil {
+		return err
+	}
+
+	err = decodeNestedFromCursor(c, newItem)
+	if err != nil {
+		return err
+	}
+
+	value.Items = append(value.Items, newItem)
+	return nil
+}
+
+// ToListValue converts the typed list to an (untyped) ListValue, which shares the items with the typed list
+func (value *List[T]) ToListValue() *ListValue {
+	return &ListValue{
+		Items:       genericItemsToUntyped(value.Items),
+		ItemCreator: untypedItemCreator(value.ItemCreator),
+	}
+}
+
+// NewListFromListValue creates a typed list from an (untyped) ListValue, whose items must be of type T
+func NewListFromListValue[T SingleValue](value *ListValue) (*List[T], error) {
+	list := &List[T]{}
+
+	err := list.fromUntyped(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (value *List[T]) toUntyped() any {
+	return value.ToListValue()
+}
+
+func (value *List[T]) fromUntyped(untyped any) error {
+	listValue, ok := untyped.(*ListValue)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to a typed list", untyped)
+	}
+
+	items, err := genericItemsFromUntyped[T](listValue.Items)
+	if err != nil {
+		return err
+	}
+
+	value.Items = items
+	return nil
+}
+
+func (value *List[T]) clone() (any, error) {
+	items, err := cloneGenericItems(value.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &List[T]{Items: items, ItemCreator: value.ItemCreator}, nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *List[T]) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
@@ -0,0 +1,147 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec,
+			&List[*U16Value]{
+				Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}},
+			},
+			"0000000241424344",
+		)
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec,
+			&List[*U16Value]{
+				Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}},
+			},
+			"41424344",
+		)
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec,
+			"0000000241424344",
+			&List[*U16Value]{},
+			&List[*U16Value]{
+				Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}},
+			},
+		)
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec,
+			"41424344",
+			&List[*U16Value]{},
+			&List[*U16Value]{
+				Items: []*U16Value{{Value: 0x4142}, {Value: 0x4344}},
+			},
+		)
+	})
+
+	t.Run("should decode nested (list of lists)", func(t *testing.T) {
+		testDecodeNested(t, codec,
+			"00000002000000014100000000",
+			&List[*List[*U8Value]]{},
+			&List[*List[*U8Value]]{
+				Items: []*List[*U8Value]{
+					{Items: []*U8Value{{Value: 0x41}}},
+					{Items: []*U8Value{}},
+				},
+			},
+		)
+	})
+
+	t.Run("should decode nested (with io.Reader)", func(t *testing.T) {
+		value := &List[*StringValue]{}
+		err := value.DecodeNested(newCursor([]byte{0, 0, 0, 1, 0, 0, 0, 2, 'h', 'i'}, false))
+
+		require.NoError(t, err)
+		require.Equal(t, []*StringValue{{Value: "hi"}}, value.Items)
+	})
+
+	t.Run("should decode nested (with item creator)", func(t *testing.T) {
+		value := &List[*StructValue]{
+			ItemCreator: func() *StructValue {
+				return &StructValue{Fields: []Field{{Name: "a", Value: &U8Value{}}, {Name: "b", Value: &U16Value{}}}}
+			},
+		}
+
+		err := codec.DecodeNested([]byte{0, 0, 0, 2, 0x01, 0x00, 0x02, 0x03, 0x00, 0x04}, value)
+		require.NoError(t, err)
+		require.Len(t, value.Items, 2)
+		require.Equal(t, &U16Value{Value: 4}, value.Items[1].Fields[1].Value)
+
+		items := &List[SingleValue]{ItemCreator: func() SingleValue { return &U8Value{} }}
+		err = codec.DecodeNested([]byte{0, 0, 0, 1, 0x2a}, items)
+		require.NoError(t, err)
+		require.Equal(t, []SingleValue{&U8Value{Value: 42}}, items.Items)
+	})
+
+	t.Run("should err on decode, if items cannot be created", func(t *testing.T) {
+		err := codec.DecodeNested([]byte{0, 0, 0, 1, 0x2a}, &List[SingleValue]{})
+		require.ErrorContains(t, err, "cannot create item of type abi.SingleValue: a creator is required")
+
+		err = codec.DecodeNested([]byte{0, 0, 0, 1, 0x2a}, &List[*StructValue]{})
+		require.ErrorContains(t, err, "cannot create item of type *abi.StructValue: a creator is required")
+
+		err = codec.DecodeNested([]byte{0, 0, 0, 1, 0x2a}, &List[*U8Value]{ItemCreator: func() *U8Value { return nil }})
+		require.ErrorContains(t, err, "cannot create item of type *abi.U8Value: the creator returned nil")
+
+		err = (&List[SingleValue]{}).DecodeNested(bytes.NewReader([]byte{0, 0, 0, 1, 0x2a}))
+		require.ErrorContains(t, err, "a creator is required")
+
+		require.Nil(t, (&List[SingleValue]{}).ToListValue().ItemCreator)
+	})
+
+	t.Run("should err on decode nested (with io.Reader), if the length is bogus", func(t *testing.T) {
+		value := &List[*U8Value]{}
+		err := value.DecodeNested(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x01}))
+		require.ErrorIs(t, err, io.EOF)
+		require.Equal(t, 1, cap(value.Items))
+
+		// The remaining length of the reader is unknown
+		value = &List[*U8Value]{}
+		err = value.DecodeNested(io.LimitReader(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x01}), 5))
+		require.Error(t, err)
+		require.Len(t, value.Items, 1)
+	})
+
+	t.Run("should convert to and from ListValue", func(t *testing.T) {
+		list := &List[*U8Value]{Items: []*U8Value{{Value: 1}, {Value: 2}}}
+
+		listValue := list.ToListValue()
+		require.Equal(t, []SingleValue{&U8Value{Value: 1}, &U8Value{Value: 2}}, listValue.Items)
+		require.Equal(t, &U8Value{}, listValue.ItemCreator())
+
+		converted, err := NewListFromListValue[*U8Value](listValue)
+		require.NoError(t, err)
+		require.Equal(t, list, converted)
+
+		_, err = NewListFromListValue[*U16Value](listValue)
+		require.ErrorContains(t, err, "item 0 has unexpected type: *abi.U8Value (expected: *abi.U16Value)")
+	})
+
+	t.Run("should interoperate with untyped values", func(t *testing.T) {
+		list := &List[*U8Value]{Items: []*U8Value{{Value: 1}, {Value: 2}}}
+
+		require.True(t, EqualValues(list, &ListValue{Items: []SingleValue{&U8Value{Value: 1}, &U8Value{Value: 2}}}))
+		require.Equal(t, "[1, 2]", fmt.Sprintf("%v", list))
+
+		cloned, err := CloneValue(list)
+		require.NoError(t, err)
+		require.Equal(t, list, cloned)
+		require.NotSame(t, list.Items[0], cloned.(*List[*U8Value]).Items[0])
+	})
+}
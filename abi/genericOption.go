@@ -0,0 +1,244 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+)
+
+// Option is a typed option (the generic counterpart of OptionValue). A nil Value means "absent".
+// ValueCreator is optional if T is a pointer to a struct type whose zero value is usable as a placeholder for decoding (e.g. *U8Value).
+type Option[T SingleValue] struct {
+	Value        T
+	ValueCreator func() T
+}
+
+// IsSet returns true if the option holds a value
+func (value *Option[T]) IsSet() bool {
+	return !isNilGenericItem(value.Value)
+}
+
+// EncodeNested encodes the value in the nested form
+func (value *Option[T]) EncodeNested(writer io.Writer) error {
+	if !value.IsSet() {
+		_, err := writer.Write([]byte{optionMarkerForAbsentValue})
+		return err
+	}
+
+	_, err := writer.Write([]byte{optionMarkerForPresentValue})
+	if err != nil {
+		return err
+	}
+
+	return value.Value.EncodeNested(writer)
+}
+
+// EncodeTopLevel encodes the value in the top-level form
+func (value *Option[T]) EncodeTopLevel(writer io.Writer) error {
+	if !value.IsSet() {
+		return nil
+	}
+
+	return value.EncodeNested(writer)
+}
+
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *Option[T]) SizeNested() int {
+	if !value.IsSet() {
+		return 1
+	}
+
+	return 1 + sizeNestedOf(value.Value)
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *Option[T]) SizeTopLevel() int {
+	if !value.IsSet() {
+		return 0
+	}
+
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *Option[T]) AppendNested(dst []byte) ([]byte, error) {
+	if !value.IsSet() {
+		return append(dst, optionMarkerForAbsentValue), nil
+	}
+
+	dst = append(dst, optionMarkerForPresentValue)
+	return appendNestedOf(dst, value.Value)
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *Option[T]) AppendTopLevel(dst []byte) ([]byte, error) {
+	if !value.IsSet() {
+		return dst, nil
+	}
+
+	return value.AppendNested(dst)
+}
+
+// DecodeNested decodes the value from the nested form
+func (value *Option[T]) DecodeNested(reader io.Reader) error {
+	data, err := readBytesExactly(reader, 1)
+	if err != nil {
+		return err
+	}
+
+	firstByte := data[0]
+
+	if firstByte == optionMarkerForAbsentValue {
+		value.clear()
+		return nil
+	}
+
+	if firstByte == optionMarkerForPresentValue {
+		newValue, err := newGenericItem(value.ValueCreator)
+		if err != nil {
+			return err
+		}
+
+		err = newValue.DecodeNested(reader)
+		if err != nil {
+			return err
+		}
+
+		value.Value = newValue
+		return nil
+	}
+
+	return fmt.Errorf("invalid first byte for nested encoded option: %d", firstByte)
+}
+
+// DecodeTopLevel decodes the value from the top-level form
+func (value *Option[T]) DecodeTopLevel(data []byte) error {
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *Option[T]) decodeNested(c *cursor) error {
+	firstByte, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	if firstByte == optionMarkerForAbsentValue {
+		value.clear()
+		return nil
+	}
+
+	if firstByte == optionMarkerForPresentValue {
+		return value.decodeInnerValue(c)
+	}
+
+	return fmt.Errorf("invalid first byte for nested encoded option: %d", firstByte)
+}
+
+func (value *Option[T]) skipNested(c *cursor) error {
+	newValue, err := newGenericItem(value.ValueCreator)
+	if err != nil {
+		return err
+	}
+
+	return skipOption(c, newValue)
+}
+
+func (value *Option[T]) decodeTopLevel(c *cursor) error {
+	if c.isEmpty() {
+		value.clear()
+		return nil
+	}
+
+	firstByte, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	if firstByte != optionMarkerForPresentValue {
+		return fmt.Errorf("invalid first byte for top-level encoded option: %d", firstByte)
+	}
+
+	return value.decodeInnerValue(c)
+}
+
+func (value *Option[T]) decodeInnerValue(c *cursor) error {
+	newValue, err := newGenericItem(value.ValueCreator)
+	if err != nil {
+		return err
+	}
+
+	err = decodeNestedFromCursor(c, newValue)
+	if err != nil {
+		return err
+	}
+
+	value.Value = newValue
+	return nil
+}
+
+func (value *Option[T]) clear() {
+	var zero T
+	value.Value = zero
+}
+
+// ToOptionValue converts the typed option to an (untyped) OptionValue, which shares the inner value with the typed option
+func (value *Option[T]) ToOptionValue() *OptionValue {
+	if !value.IsSet() {
+		return &OptionValue{}
+	}
+
+	return &OptionValue{Value: value.Value}
+}
+
+// NewOptionFromOptionValue creates a typed option from an (untyped) OptionValue, whose inner value (if any) must be of type T
+func NewOptionFromOptionValue[T SingleValue](value *OptionValue) (*Option[T], error) {
+	option := &Option[T]{}
+
+	err := option.fromUntyped(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (value *Option[T]) toUntyped() any {
+	return value.ToOptionValue()
+}
+
+func (value *Option[T]) fromUntyped(untyped any) error {
+	optionValue, ok := untyped.(*OptionValue)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to a typed option", untyped)
+	}
+
+	if optionValue.Value == nil {
+		value.clear()
+		return nil
+	}
+
+	inner, ok := optionValue.Value.(T)
+	if !ok {
+		return fmt.Errorf("inner value of option has unexpected type: %T (expected: %T)", optionValue.Value, value.Value)
+	}
+
+	value.Value = inner
+	return nil
+}
+
+func (value *Option[T]) clone() (any, error) {
+	if !value.IsSet() {
+		return &Option[T]{ValueCreator: value.ValueCreator}, nil
+	}
+
+	inner, err := CloneSingleValue(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Option[T]{Value: inner.(T), ValueCreator: value.ValueCreator}, nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *Option[T]) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
@@ -0,0 +1,76 @@
+package abi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOption(t *testing.T) {
+	codec := &codec{}
+
+	t.Run("should encode nested", func(t *testing.T) {
+		testEncodeNested(t, codec, &Option[*U8Value]{}, "00")
+		testEncodeNested(t, codec, &Option[*U8Value]{Value: &U8Value{Value: 0x08}}, "0108")
+	})
+
+	t.Run("should encode top-level", func(t *testing.T) {
+		testEncodeTopLevel(t, codec, &Option[*U8Value]{}, "")
+		testEncodeTopLevel(t, codec, &Option[*U8Value]{Value: &U8Value{Value: 0x08}}, "0108")
+	})
+
+	t.Run("should decode nested", func(t *testing.T) {
+		testDecodeNested(t, codec, "00", &Option[*U8Value]{}, &Option[*U8Value]{})
+		testDecodeNested(t, codec, "0108", &Option[*U8Value]{}, &Option[*U8Value]{Value: &U8Value{Value: 0x08}})
+	})
+
+	t.Run("should err on decode nested (bad marker for value presence)", func(t *testing.T) {
+		testDecodeNestedWithError(t, codec, "072a", &Option[*BytesValue]{}, "invalid first byte for nested encoded option: 7")
+	})
+
+	t.Run("should decode nested (with value creator)", func(t *testing.T) {
+		option := &Option[*ListValue]{
+			ValueCreator: func() *ListValue { return &ListValue{ItemCreator: func() SingleValue { return &U8Value{} }} },
+		}
+
+		err := codec.DecodeNested([]byte{0x01, 0x00, 0x00, 0x00, 0x01, 0x2a}, option)
+		require.NoError(t, err)
+		require.Equal(t, []SingleValue{&U8Value{Value: 42}}, option.Value.Items)
+
+		testDecodeNestedWithError(t, codec, "0100000000", &Option[*ListValue]{}, "cannot create item of type *abi.ListValue: a creator is required")
+	})
+
+	t.Run("should decode top-level", func(t *testing.T) {
+		testDecodeTopLevel(t, codec, "", &Option[*U8Value]{Value: &U8Value{Value: 0x08}}, &Option[*U8Value]{})
+		testDecodeTopLevel(t, codec, "0108", &Option[*U8Value]{}, &Option[*U8Value]{Value: &U8Value{Value: 0x08}})
+	})
+
+	t.Run("should err on decode top-level (bad marker for value presence)", func(t *testing.T) {
+		testDecodeTopLevelWithError(t, codec, "002a", &Option[*BytesValue]{}, "invalid first byte for top-level encoded option: 0")
+	})
+
+	t.Run("should convert to and from OptionValue", func(t *testing.T) {
+		option := &Option[*U8Value]{Value: &U8Value{Value: 42}}
+		require.True(t, option.IsSet())
+		require.Equal(t, &OptionValue{Value: &U8Value{Value: 42}}, option.ToOptionValue())
+		require.Equal(t, &OptionValue{}, (&Option[*U8Value]{}).ToOptionValue())
+
+		converted, err := NewOptionFromOptionValue[*U8Value](&OptionValue{Value: &U8Value{Value: 42}})
+		require.NoError(t, err)
+		require.Equal(t, option, converted)
+
+		converted, err = NewOptionFromOptionValue[*U8Value](&OptionValue{})
+		require.NoError(t, err)
+		require.False(t, converted.IsSet())
+
+		_, err = NewOptionFromOptionValue[*U8Value](&OptionValue{Value: &U16Value{}})
+		require.ErrorContains(t, err, "inner value of option has unexpected type: *abi.U16Value (expected: *abi.U8Value)")
+	})
+
+	t.Run("should interoperate with untyped values", func(t *testing.T) {
+		require.Equal(t, "Some(42)", fmt.Sprintf("%v", &Option[*U8Value]{Value: &U8Value{Value: 42}}))
+		require.Equal(t, "None", fmt.Sprintf("%v", &Option[*U8Value]{}))
+		require.True(t, EqualValues(&Option[*U8Value]{}, &OptionValue{}))
+	})
+}
@@ -0,0 +1,91 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// Variadic holds typed variadic values (the generic counterpart of VariadicValues).
+// ItemCreator is optional if T is a pointer to a struct type whose zero value is usable as a placeholder for decoding (e.g. *U8Value).
+type Variadic[T SingleValue] struct {
+	Items       []T
+	ItemCreator func() T
+}
+
+// ToVariadicValues converts the typed variadic values to (untyped) VariadicValues, which share the items with the typed ones
+func (value *Variadic[T]) ToVariadicValues() *VariadicValues {
+	var items []any
+
+	if value.Items != nil {
+		items = make([]any, len(value.Items))
+		for i, item := range value.Items {
+			items[i] = item
+		}
+	}
+
+	var itemCreator func() any
+
+	if untypedCreator := untypedItemCreator(value.ItemCreator); untypedCreator != nil {
+		itemCreator = func() any { return untypedCreator() }
+	}
+
+	return &VariadicValues{
+		Items:       items,
+		ItemCreator: itemCreator,
+	}
+}
+
+// NewVariadicFromVariadicValues creates typed variadic values from (untyped) VariadicValues, whose items must be of type T
+func NewVariadicFromVariadicValues[T SingleValue](value *VariadicValues) (*Variadic[T], error) {
+	variadic := &Variadic[T]{}
+
+	err := variadic.fromUntyped(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return variadic, nil
+}
+
+func (value *Variadic[T]) toUntyped() any {
+	return value.ToVariadicValues()
+}
+
+func (value *Variadic[T]) fromUntyped(untyped any) error {
+	variadicValues, ok := untyped.(*VariadicValues)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to typed variadic values", untyped)
+	}
+
+	if variadicValues.Items == nil {
+		value.Items = nil
+		return nil
+	}
+
+	items := make([]T, len(variadicValues.Items))
+
+	for i, untypedItem := range variadicValues.Items {
+		item, ok := untypedItem.(T)
+		if !ok {
+			return fmt.Errorf("item %d has unexpected type: %T (expected: %T)", i, untypedItem, item)
+		}
+
+		items[i] = item
+	}
+
+	value.Items = items
+	return nil
+}
+
+func (value *Variadic[T]) clone() (any, error) {
+	items, err := cloneGenericItems(value.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Variadic[T]{Items: items, ItemCreator: value.ItemCreator}, nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *Variadic[T]) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
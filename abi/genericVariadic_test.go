@@ -0,0 +1,60 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariadic(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	require.NoError(t, err)
+
+	t.Run("should serialize", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&U8Value{Value: 0x41},
+			&Variadic[*U16Value]{Items: []*U16Value{{Value: 0x4243}, {Value: 0x4445}}},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "41@4243@4445", data)
+	})
+
+	t.Run("should err on serialize, if not last", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{
+			&Variadic[*U16Value]{Items: []*U16Value{{Value: 0x4243}}},
+			&U8Value{Value: 0x41},
+		})
+
+		require.ErrorContains(t, err, "variadic values must be last among input values")
+	})
+
+	t.Run("should deserialize", func(t *testing.T) {
+		destination := &Variadic[*List[*U8Value]]{}
+
+		err := serializer.Deserialize("41@4243@", []any{destination})
+		require.NoError(t, err)
+		require.Equal(t, []*List[*U8Value]{
+			{Items: []*U8Value{{Value: 0x41}}},
+			{Items: []*U8Value{{Value: 0x42}, {Value: 0x43}}},
+			{Items: []*U8Value{}},
+		}, destination.Items)
+	})
+
+	t.Run("should convert to and from VariadicValues", func(t *testing.T) {
+		variadic := &Variadic[*U8Value]{Items: []*U8Value{{Value: 1}}}
+		variadicValues := variadic.ToVariadicValues()
+
+		require.Equal(t, []any{&U8Value{Value: 1}}, variadicValues.Items)
+		require.Equal(t, &U8Value{}, variadicValues.ItemCreator())
+
+		converted, err := NewVariadicFromVariadicValues[*U8Value](variadicValues)
+		require.NoError(t, err)
+		require.Equal(t, variadic, converted)
+
+		_, err = NewVariadicFromVariadicValues[*U8Value](&VariadicValues{Items: []any{&BoolValue{}}})
+		require.ErrorContains(t, err, "item 0 has unexpected type: *abi.BoolValue (expected: *abi.U8Value)")
+	})
+}
@@ -0,0 +1,123 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// genericValue is implemented by the generic (typed) containers (e.g. List[T], Option[T]).
+// It allows them to interoperate with the untyped values (e.g. ListValue, OptionValue),
+// which are used by the serializer, the formatter, the walker etc.
+type genericValue interface {
+	// toUntyped returns an untyped counterpart (or view) of the value, sharing the items with the generic value
+	toUntyped() any
+	// fromUntyped sets the items of the generic value, given an untyped counterpart
+	fromUntyped(value any) error
+	// clone returns a deep copy of the generic value
+	clone() (any, error)
+}
+
+// untypedView returns the untyped counterpart of generic values, or the value itself otherwise.
+func untypedView(value any) any {
+	if value, ok := value.(genericValue); ok {
+		return value.toUntyped()
+	}
+
+	return value
+}
+
+// newGenericItem creates a new item of type T (a placeholder for decoding), by means of the given creator (if any).
+// Without a creator, T must be a pointer to a struct type whose zero value is usable as a placeholder (e.g. *U8Value).
+// Interface types (e.g. SingleValue) and composite types (e.g. *StructValue, *ListValue) require a creator.
+func newGenericItem[T SingleValue](creator func() T) (T, error) {
+	var zero T
+
+	if creator != nil {
+		item := creator()
+		if isNilGenericItem(item) {
+			return zero, fmt.Errorf("cannot create item of type %s: the creator returned nil", genericTypeName[T]())
+		}
+
+		return item, nil
+	}
+
+	itemType := reflect.TypeOf(zero)
+	if itemType == nil || itemType.Kind() != reflect.Pointer || itemType.Elem().Kind() != reflect.Struct {
+		return zero, fmt.Errorf("cannot create item of type %s: a creator is required", genericTypeName[T]())
+	}
+
+	item := reflect.New(itemType.Elem()).Interface().(T)
+
+	switch any(item).(type) {
+	case *StructValue, *EnumValue, *ListValue, *OptionValue:
+		return zero, fmt.Errorf("cannot create item of type %s: a creator is required", genericTypeName[T]())
+	}
+
+	return item, nil
+}
+
+// untypedItemCreator adapts the creator of generic items, for the untyped counterparts of generic values.
+// It returns nil if items of type T cannot be created (thus, decoding the untyped counterparts fails with an error, as well).
+func untypedItemCreator[T SingleValue](creator func() T) func() SingleValue {
+	_, err := newGenericItem(creator)
+	if err != nil {
+		return nil
+	}
+
+	return func() SingleValue {
+		item, _ := newGenericItem(creator)
+		return item
+	}
+}
+
+func genericTypeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// isNilGenericItem returns true if the given item (a pointer) is nil.
+func isNilGenericItem[T SingleValue](item T) bool {
+	var zero T
+	return any(item) == any(zero)
+}
+
+func genericItemsToUntyped[T SingleValue](items []T) []SingleValue {
+	if items == nil {
+		return nil
+	}
+
+	untypedItems := make([]SingleValue, len(items))
+	for i, item := range items {
+		untypedItems[i] = item
+	}
+
+	return untypedItems
+}
+
+func genericItemsFromUntyped[T SingleValue](untypedItems []SingleValue) ([]T, error) {
+	if untypedItems == nil {
+		return nil, nil
+	}
+
+	items := make([]T, len(untypedItems))
+
+	for i, untypedItem := range untypedItems {
+		item, ok := untypedItem.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("item %d has unexpected type: %T (expected: %T)", i, untypedItem, zero)
+		}
+
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+func cloneGenericItems[T SingleValue](items []T) ([]T, error) {
+	untypedItems, err := cloneSingleValues(genericItemsToUntyped(items))
+	if err != nil {
+		return nil, err
+	}
+
+	return genericItemsFromUntyped[T](untypedItems)
+}
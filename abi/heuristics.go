@@ -0,0 +1,240 @@
+package abi
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// InterpretationKindEmpty is the kind of empty parts (e.g. zero, empty string, false)
+	InterpretationKindEmpty = "empty"
+	// InterpretationKindString is the kind of printable UTF-8 strings
+	InterpretationKindString = "string"
+	// InterpretationKindTokenIdentifier is the kind of token identifiers (e.g. "USDC-c76f1f")
+	InterpretationKindTokenIdentifier = "token identifier"
+	// InterpretationKindAddress is the kind of addresses (32 bytes)
+	InterpretationKindAddress = "address"
+	// InterpretationKindSmallInteger is the kind of unsigned integers which fit in 64 bits
+	InterpretationKindSmallInteger = "small integer"
+	// InterpretationKindBigInteger is the kind of unsigned integers which do not fit in 64 bits
+	InterpretationKindBigInteger = "big integer"
+	// InterpretationKindBoolean is the kind of booleans
+	InterpretationKindBoolean = "boolean"
+	// InterpretationKindListOfAddresses is the kind of length-prefixed lists of addresses
+	InterpretationKindListOfAddresses = "list of addresses"
+	// InterpretationKindNestedString is the kind of length-prefixed, printable UTF-8 strings
+	InterpretationKindNestedString = "length-prefixed string"
+	// InterpretationKindBytes is the kind of raw bytes (always a candidate, with low confidence)
+	InterpretationKindBytes = "bytes"
+)
+
+const (
+	confidenceOfTokenIdentifier      = 0.95
+	confidenceOfContractAddress      = 0.95
+	confidenceOfListOfAddresses      = 0.9
+	confidenceOfLongString           = 0.85
+	confidenceOfAddress              = 0.8
+	confidenceOfNestedString         = 0.75
+	confidenceOfTinyInteger          = 0.7
+	confidenceOfZero                 = 0.5
+	confidenceOfSmallInteger         = 0.5
+	confidenceOfBigInteger           = 0.5
+	confidenceOfShortString          = 0.4
+	confidenceOfEmptyString          = 0.3
+	confidenceOfBoolean              = 0.3
+	confidenceOfLongBigInteger       = 0.2
+	confidenceOfBytes                = 0.1
+	minLengthOfLongString            = 3
+	maxLengthOfTinyInteger           = 2
+	maxLengthOfLikelyBigInteger      = 32
+	numLeadingZerosOfContractAddress = 8
+)
+
+var tokenIdentifierRegex = regexp.MustCompile("^[A-Z0-9]{3,10}-[0-9a-f]{6}$")
+
+// Interpretation is a plausible interpretation of a part, as proposed by the heuristic analyzer
+type Interpretation struct {
+	Kind  string
+	Value SingleValue
+	// Text is the human-readable form of the value
+	Text string
+	// Confidence is a score between 0 and 1
+	Confidence float64
+}
+
+// ArgsNewHeuristicAnalyzer defines the arguments needed for a new heuristic analyzer
+type ArgsNewHeuristicAnalyzer struct {
+	PartsSeparator string
+	// AddressHrp is the human-readable part of bech32 addresses (e.g. "erd")
+	AddressHrp string
+}
+
+// heuristicAnalyzer proposes plausible interpretations of data whose types are unknown (e.g. when the ABI isn't available).
+type heuristicAnalyzer struct {
+	serializer *serializer
+	formatter  *formatter
+	codec      *codec
+}
+
+// NewHeuristicAnalyzer creates a new heuristic analyzer
+func NewHeuristicAnalyzer(args ArgsNewHeuristicAnalyzer) (*heuristicAnalyzer, error) {
+	if args.AddressHrp == "" {
+		return nil, errors.New("cannot create heuristic analyzer: address HRP must not be empty")
+	}
+
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: args.PartsSeparator})
+	if err != nil {
+		return nil, err
+	}
+
+	formatter, err := NewFormatter(ArgsNewFormatter{AddressHrp: args.AddressHrp})
+	if err != nil {
+		return nil, err
+	}
+
+	return &heuristicAnalyzer{
+		serializer: serializer,
+		formatter:  formatter,
+		codec:      &codec{},
+	}, nil
+}
+
+// Analyze proposes interpretations for each part of the given data (hex-encoded parts, separated by the parts separator)
+func (analyzer *heuristicAnalyzer) Analyze(data string) ([][]Interpretation, error) {
+	parts, err := analyzer.serializer.decodeIntoParts(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return analyzer.AnalyzeParts(parts), nil
+}
+
+// AnalyzeParts proposes interpretations for each of the given (raw) parts
+func (analyzer *heuristicAnalyzer) AnalyzeParts(parts [][]byte) [][]Interpretation {
+	interpretations := make([][]Interpretation, len(parts))
+
+	for i, part := range parts {
+		interpretations[i] = analyzer.AnalyzePart(part)
+	}
+
+	return interpretations
+}
+
+// AnalyzePart proposes interpretations of a (raw) part, sorted by confidence (highest first)
+func (analyzer *heuristicAnalyzer) AnalyzePart(part []byte) []Interpretation {
+	var interpretations []Interpretation
+
+	add := func(kind string, value SingleValue, confidence float64) {
+		interpretations = append(interpretations, Interpretation{
+			Kind:       kind,
+			Value:      value,
+			Text:       analyzer.formatter.Format(value),
+			Confidence: confidence,
+		})
+	}
+
+	if len(part) == 0 {
+		add(InterpretationKindEmpty, &U64Value{Value: 0}, confidenceOfZero)
+		add(InterpretationKindEmpty, &StringValue{Value: ""}, confidenceOfEmptyString)
+		add(InterpretationKindEmpty, &BoolValue{Value: false}, confidenceOfBoolean)
+		return interpretations
+	}
+
+	if tokenIdentifierRegex.Match(part) {
+		add(InterpretationKindTokenIdentifier, &StringValue{Value: string(part)}, confidenceOfTokenIdentifier)
+	} else if isPrintableText(part) {
+		confidence := confidenceOfShortString
+		if len(part) >= minLengthOfLongString {
+			confidence = confidenceOfLongString
+		}
+
+		add(InterpretationKindString, &StringValue{Value: string(part)}, confidence)
+	}
+
+	if len(part) == pubKeyLength {
+		confidence := confidenceOfAddress
+		if isContractAddress(part) {
+			confidence = confidenceOfContractAddress
+		}
+
+		add(InterpretationKindAddress, &AddressValue{Value: part}, confidence)
+	}
+
+	addresses := &ListValue{ItemCreator: func() SingleValue { return &AddressValue{} }}
+	if len(part) > lengthPrefixSize && analyzer.decodesExactly(part, addresses) {
+		add(InterpretationKindListOfAddresses, addresses, confidenceOfListOfAddresses)
+	}
+
+	nestedString := &StringValue{}
+	if len(part) > lengthPrefixSize && analyzer.decodesExactly(part, nestedString) && isPrintableText([]byte(nestedString.Value)) {
+		add(InterpretationKindNestedString, nestedString, confidenceOfNestedString)
+	}
+
+	if len(part) == 1 && part[0] <= 1 {
+		add(InterpretationKindBoolean, &BoolValue{Value: part[0] == 1}, confidenceOfBoolean)
+	}
+
+	integer := &BigUIntValue{}
+	_ = analyzer.codec.DecodeTopLevel(part, integer)
+
+	switch {
+	case len(part) <= maxLengthOfTinyInteger:
+		add(InterpretationKindSmallInteger, &U64Value{Value: integer.Value.Uint64()}, confidenceOfTinyInteger)
+	case integer.Value.IsUint64():
+		add(InterpretationKindSmallInteger, &U64Value{Value: integer.Value.Uint64()}, confidenceOfSmallInteger)
+	case len(part) <= maxLengthOfLikelyBigInteger:
+		add(InterpretationKindBigInteger, integer, confidenceOfBigInteger)
+	default:
+		add(InterpretationKindBigInteger, integer, confidenceOfLongBigInteger)
+	}
+
+	add(InterpretationKindBytes, &BytesValue{Value: part}, confidenceOfBytes)
+
+	sort.SliceStable(interpretations, func(i, j int) bool {
+		return interpretations[i].Confidence > interpretations[j].Confidence
+	})
+
+	return interpretations
+}
+
+// decodesExactly tells whether the data is the nested encoding of a value (of the type of the placeholder), with no bytes left
+func (analyzer *heuristicAnalyzer) decodesExactly(data []byte, placeholder SingleValue) bool {
+	c := newCursor(data, false)
+
+	err := decodeNestedFromCursor(c, placeholder)
+	return err == nil && c.isEmpty()
+}
+
+func isPrintableText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+
+	text := string(data)
+	if strings.TrimSpace(text) == "" {
+		return false
+	}
+
+	for _, character := range text {
+		if !unicode.IsPrint(character) && !unicode.IsSpace(character) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isContractAddress tells whether the public key looks like the one of a smart contract (which starts with zero bytes)
+func isContractAddress(pubkey []byte) bool {
+	for _, b := range pubkey[:numLeadingZerosOfContractAddress] {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHeuristicAnalyzer(t *testing.T) {
+	_, err := NewHeuristicAnalyzer(ArgsNewHeuristicAnalyzer{PartsSeparator: "@"})
+	require.ErrorContains(t, err, "address HRP must not be empty")
+
+	_, err = NewHeuristicAnalyzer(ArgsNewHeuristicAnalyzer{AddressHrp: "erd"})
+	require.ErrorContains(t, err, "parts separator must not be empty")
+}
+
+func TestHeuristicAnalyzer_AnalyzePart(t *testing.T) {
+	analyzer, err := NewHeuristicAnalyzer(ArgsNewHeuristicAnalyzer{PartsSeparator: "@", AddressHrp: "erd"})
+	require.NoError(t, err)
+
+	alicePubKey, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	contractPubKey, _ := hex.DecodeString("00000000000000000500" + "0139472eff6886771a982f3083da5d421f24c29181e6")
+
+	kindsOf := func(interpretations []Interpretation) []string {
+		kinds := make([]string, len(interpretations))
+		for i, interpretation := range interpretations {
+			kinds[i] = interpretation.Kind
+		}
+
+		return kinds
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart([]byte{})
+		require.Equal(t, []string{"empty", "empty", "empty"}, kindsOf(interpretations))
+		require.Equal(t, "0", interpretations[0].Text)
+		require.Equal(t, `""`, interpretations[1].Text)
+		require.Equal(t, "false", interpretations[2].Text)
+	})
+
+	t.Run("token identifier", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart([]byte("USDC-c76f1f"))
+		require.Equal(t, "token identifier", interpretations[0].Kind)
+		require.Equal(t, `"USDC-c76f1f"`, interpretations[0].Text)
+		require.Equal(t, 0.95, interpretations[0].Confidence)
+		require.Equal(t, "big integer", interpretations[1].Kind)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart([]byte("hello world"))
+		require.Equal(t, []string{"string", "big integer", "bytes"}, kindsOf(interpretations))
+		require.Equal(t, &StringValue{Value: "hello world"}, interpretations[0].Value)
+
+		// Short strings are less likely than small integers
+		interpretations = analyzer.AnalyzePart([]byte("ok"))
+		require.Equal(t, []string{"small integer", "string", "bytes"}, kindsOf(interpretations))
+	})
+
+	t.Run("address", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart(alicePubKey)
+		require.Equal(t, []string{"address", "big integer", "bytes"}, kindsOf(interpretations))
+		require.Equal(t, "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", interpretations[0].Text)
+		require.Equal(t, 0.8, interpretations[0].Confidence)
+
+		interpretations = analyzer.AnalyzePart(contractPubKey)
+		require.Equal(t, "address", interpretations[0].Kind)
+		require.Equal(t, 0.95, interpretations[0].Confidence)
+	})
+
+	t.Run("list of addresses", func(t *testing.T) {
+		part := append([]byte{0, 0, 0, 2}, alicePubKey...)
+		part = append(part, contractPubKey...)
+
+		interpretations := analyzer.AnalyzePart(part)
+		require.Equal(t, "list of addresses", interpretations[0].Kind)
+		require.Len(t, interpretations[0].Value.(*ListValue).Items, 2)
+	})
+
+	t.Run("length-prefixed string", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart([]byte{0, 0, 0, 5, 'h', 'e', 'l', 'l', 'o'})
+		require.Equal(t, "length-prefixed string", interpretations[0].Kind)
+		require.Equal(t, `"hello"`, interpretations[0].Text)
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		interpretations := analyzer.AnalyzePart([]byte{0x01})
+		require.Equal(t, []string{"small integer", "boolean", "bytes"}, kindsOf(interpretations))
+		require.Equal(t, "1", interpretations[0].Text)
+		require.Equal(t, "true", interpretations[1].Text)
+
+		interpretations = analyzer.AnalyzePart([]byte{0x0d, 0xe0, 0xb6, 0xb3, 0xa7, 0x64, 0x00, 0x00})
+		require.Equal(t, []string{"small integer", "bytes"}, kindsOf(interpretations))
+		require.Equal(t, "1000000000000000000", interpretations[0].Text)
+
+		interpretations = analyzer.AnalyzePart([]byte{0x01, 0x0d, 0xe0, 0xb6, 0xb3, 0xa7, 0x64, 0x00, 0x00})
+		require.Equal(t, []string{"big integer", "bytes"}, kindsOf(interpretations))
+		require.Equal(t, "19446744073709551616", interpretations[0].Text)
+	})
+}
+
+func TestHeuristicAnalyzer_Analyze(t *testing.T) {
+	analyzer, err := NewHeuristicAnalyzer(ArgsNewHeuristicAnalyzer{PartsSeparator: "@", AddressHrp: "erd"})
+	require.NoError(t, err)
+
+	interpretations, err := analyzer.Analyze("5553444b2d633736663166@0de0b6b3a7640000@")
+	require.NoError(t, err)
+	require.Len(t, interpretations, 3)
+	require.Equal(t, "token identifier", interpretations[0][0].Kind)
+	require.Equal(t, "small integer", interpretations[1][0].Kind)
+	require.Equal(t, "empty", interpretations[2][0].Kind)
+
+	_, err = analyzer.Analyze("zz")
+	require.ErrorContains(t, err, "invalid byte")
+}
@@ -10,3 +10,14 @@ type SingleValue interface {
 	DecodeNested(reader io.Reader) error
 	DecodeTopLevel(data []byte) error
 }
+
+// AppendableValue is implemented by single values which are able to report the length of their encoded forms
+// (without actually encoding), and to encode themselves by appending to a caller-provided buffer.
+// All single values defined in this package implement this interface.
+type AppendableValue interface {
+	SingleValue
+	SizeNested() int
+	SizeTopLevel() int
+	AppendNested(dst []byte) ([]byte, error)
+	AppendTopLevel(dst []byte) ([]byte, error)
+}
@@ -0,0 +1,110 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// DecodingFailure describes where (and why) a lenient decoding stopped
+type DecodingFailure struct {
+	Err error
+	// Path of the (innermost) value which failed to decode, following the same conventions as the ones of ValueDifference
+	Path string
+	// Part is the index of the part in which decoding failed (always 0 for single values)
+	Part int
+	// Offset is the position of the failure, within the part
+	Offset int
+	// RemainingBytes are the undecoded bytes of the failing part, from the position of the failure onwards
+	RemainingBytes []byte
+	// RemainingParts are the parts following the failing part (never reached)
+	RemainingParts [][]byte
+}
+
+// Error implements the error interface
+func (failure *DecodingFailure) Error() string {
+	return fmt.Sprintf("decoding failed at %s (part %d, offset %d): %v", failure.Path, failure.Part, failure.Offset, failure.Err)
+}
+
+// Unwrap returns the underlying error
+func (failure *DecodingFailure) Unwrap() error {
+	return failure.Err
+}
+
+// DeserializeLeniently deserializes the given data into the output values, on a best-effort basis.
+// On failure, the output values hold everything successfully decoded so far: lists hold the items decoded before the failure,
+// while the values following the failing one are left as they were (placeholders). The failure describes the failing position and the undecoded bytes.
+// An error is returned (instead of a failure) only if the data cannot be split into parts (e.g. bad hex).
+func (s *serializer) DeserializeLeniently(data string, outputValues []any) (*DecodingFailure, error) {
+	parts, err := s.decodeIntoParts(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.DeserializePartsLeniently(parts, outputValues), nil
+}
+
+// DeserializePartsLeniently deserializes the given (raw) parts into the output values, on a best-effort basis (see DeserializeLeniently).
+// It returns nil if all the parts were successfully decoded.
+func (s *serializer) DeserializePartsLeniently(parts [][]byte, outputValues []any) *DecodingFailure {
+	explainer := newExplainer(false)
+	partsHolder := newPartsHolder(parts)
+
+	err := explainer.explainParts(partsHolder, "$", outputValues, positionOfLastValues)
+	if err == nil {
+		return nil
+	}
+
+	failure := explainer.failure
+	firstRemainingPart := 0
+
+	if failure != nil {
+		firstRemainingPart = failure.Part + 1
+	} else {
+		// The failure isn't related to the data of a part (e.g. a missing part, or a bad placeholder).
+		failure = &DecodingFailure{
+			Path: explainer.failedPath,
+			Part: int(partsHolder.focusedPartIndex),
+		}
+
+		firstRemainingPart = failure.Part
+	}
+
+	failure.Err = err
+
+	if firstRemainingPart < len(parts) {
+		failure.RemainingParts = parts[firstRemainingPart:]
+	}
+
+	return failure
+}
+
+// DecodeNestedLeniently decodes the given data into the value (nested form), on a best-effort basis (see DeserializeLeniently).
+// It returns nil if the value was successfully decoded.
+func DecodeNestedLeniently(data []byte, value SingleValue) *DecodingFailure {
+	explainer := newExplainer(false)
+	c := newCursor(data, false)
+
+	err := explainer.explainNested(c, "$", value)
+	if err != nil {
+		explainer.addUndecoded(c, err)
+		explainer.failure.Err = fmt.Errorf("cannot decode (nested) %T, because of: %w", value, err)
+		return explainer.failure
+	}
+
+	return nil
+}
+
+// DecodeTopLevelLeniently decodes the given data into the value (top-level form), on a best-effort basis (see DeserializeLeniently).
+// It returns nil if the value was successfully decoded.
+func DecodeTopLevelLeniently(data []byte, value SingleValue) *DecodingFailure {
+	explainer := newExplainer(false)
+	c := newCursor(data, false)
+
+	err := explainer.explainTopLevel(c, "$", value)
+	if err != nil {
+		explainer.addUndecoded(c, err)
+		explainer.failure.Err = fmt.Errorf("cannot decode (top-level) %T, because of: %w", value, err)
+		return explainer.failure
+	}
+
+	return nil
+}
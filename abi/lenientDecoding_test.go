@@ -0,0 +1,111 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializer_DeserializeLeniently(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	createListOfStructs := func() *ListValue {
+		return &ListValue{ItemCreator: func() SingleValue {
+			return &StructValue{Fields: []Field{
+				{Name: "a", Value: &U8Value{}},
+				{Name: "b", Value: &StringValue{}},
+			}}
+		}}
+	}
+
+	t.Run("should deserialize everything", func(t *testing.T) {
+		u8 := &U8Value{}
+		list := createListOfStructs()
+
+		failure, err := serializer.DeserializeLeniently("2a@010000000141", []any{u8, list})
+		require.NoError(t, err)
+		require.Nil(t, failure)
+		require.Equal(t, uint8(42), u8.Value)
+		require.Len(t, list.Items, 1)
+	})
+
+	t.Run("should keep what was decoded before failing within a list", func(t *testing.T) {
+		u8 := &U8Value{}
+		list := createListOfStructs()
+		last := &U8Value{Value: 7}
+
+		// The second item of the list is malformed (string length exceeds the data)
+		failure, err := serializer.DeserializeLeniently("2a@01000000014102000000ff41@2b", []any{u8, list, last})
+		require.NoError(t, err)
+		require.NotNil(t, failure)
+		require.ErrorContains(t, failure, "cannot decode (top-level) *abi.ListValue")
+		require.ErrorContains(t, failure, "decoding failed at $[1][1].b (part 1, offset 7)")
+		require.Equal(t, "$[1][1].b", failure.Path)
+		require.Equal(t, 1, failure.Part)
+		require.Equal(t, 7, failure.Offset)
+		require.Equal(t, []byte{0x00, 0x00, 0x00, 0xff, 0x41}, failure.RemainingBytes)
+		require.Equal(t, [][]byte{{0x2b}}, failure.RemainingParts)
+
+		require.Equal(t, uint8(42), u8.Value)
+		require.Equal(t, []SingleValue{
+			&StructValue{Fields: []Field{{Name: "a", Value: &U8Value{Value: 1}}, {Name: "b", Value: &StringValue{Value: "A"}}}},
+		}, list.Items)
+
+		// Values following the failing one are left as they were
+		require.Equal(t, uint8(7), last.Value)
+	})
+
+	t.Run("should report missing parts", func(t *testing.T) {
+		failure, err := serializer.DeserializeLeniently("2a", []any{&U8Value{}, &U8Value{}})
+		require.NoError(t, err)
+		require.Equal(t, "$[1]", failure.Path)
+		require.Equal(t, 1, failure.Part)
+		require.Nil(t, failure.RemainingParts)
+	})
+
+	t.Run("should report bad placeholders", func(t *testing.T) {
+		failure, err := serializer.DeserializeLeniently("2a@2b", []any{&U8Value{}, nil})
+		require.NoError(t, err)
+		require.ErrorContains(t, failure, "cannot deserialize into nil value")
+		require.Equal(t, 1, failure.Part)
+		require.Equal(t, [][]byte{{0x2b}}, failure.RemainingParts)
+	})
+
+	t.Run("should err on bad hex", func(t *testing.T) {
+		_, err := serializer.DeserializeLeniently("zz", []any{&U8Value{}})
+		require.ErrorContains(t, err, "invalid byte")
+	})
+}
+
+func TestDecodeNestedLeniently(t *testing.T) {
+	value := &StructValue{Fields: []Field{
+		{Name: "a", Value: &U16Value{}},
+		{Name: "b", Value: &ListValue{ItemCreator: func() SingleValue { return &U32Value{} }}},
+		{Name: "c", Value: &BoolValue{}},
+	}}
+
+	failure := DecodeNestedLeniently([]byte{0x00, 0x01, 0, 0, 0, 3, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0}, value)
+	require.NotNil(t, failure)
+	require.ErrorContains(t, failure, "cannot decode (nested) *abi.StructValue, because of: cannot decode field 'b' of struct")
+	require.Equal(t, "$.b[2]", failure.Path)
+	require.Equal(t, 14, failure.Offset)
+	require.Equal(t, []byte{0, 0}, failure.RemainingBytes)
+
+	require.Equal(t, uint16(1), value.Fields[0].Value.(*U16Value).Value)
+	require.Equal(t, []SingleValue{&U32Value{Value: 1}, &U32Value{Value: 2}}, value.Fields[1].Value.(*ListValue).Items)
+
+	failure = DecodeNestedLeniently([]byte{0x00, 0x01}, &U16Value{})
+	require.Nil(t, failure)
+}
+
+func TestDecodeTopLevelLeniently(t *testing.T) {
+	value := &ListValue{ItemCreator: func() SingleValue { return &U16Value{} }}
+
+	failure := DecodeTopLevelLeniently([]byte{0, 1, 0, 2, 0}, value)
+	require.NotNil(t, failure)
+	require.ErrorContains(t, failure, "cannot decode (top-level) *abi.ListValue")
+	require.Equal(t, "$[2]", failure.Path)
+	require.Equal(t, 4, failure.Offset)
+	require.Equal(t, []SingleValue{&U16Value{Value: 1}, &U16Value{Value: 2}}, value.Items)
+}
@@ -1,8 +1,8 @@
 package abi
 
 import (
-	"bytes"
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -38,6 +38,42 @@ func (value *ListValue) encodeItems(writer io.Writer) error {
 	return nil
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *ListValue) SizeNested() int {
+	return lengthPrefixSize + value.SizeTopLevel()
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *ListValue) SizeTopLevel() int {
+	size := 0
+
+	for _, item := range value.Items {
+		size += sizeNestedOf(item)
+	}
+
+	return size
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *ListValue) AppendNested(dst []byte) ([]byte, error) {
+	dst = appendLength(dst, uint32(len(value.Items)))
+	return value.AppendTopLevel(dst)
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *ListValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	for _, item := range value.Items {
+		var err error
+
+		dst, err = appendNestedOf(dst, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *ListValue) DecodeNested(reader io.Reader) error {
 	length, err := decodeLength(reader)
@@ -45,7 +81,7 @@ func (value *ListValue) DecodeNested(reader io.Reader) error {
 		return err
 	}
 
-	value.Items = make([]SingleValue, 0, length)
+	value.Items = make([]SingleValue, 0, capacityForItemsOfReader(reader, int(length)))
 
 	for i := uint32(0); i < length; i++ {
 		err := value.decodeItem(reader)
@@ -59,11 +95,32 @@ func (value *ListValue) DecodeNested(reader io.Reader) error {
 
 // DecodeTopLevel decodes the value from the top-level form
 func (value *ListValue) DecodeTopLevel(data []byte) error {
-	reader := bytes.NewReader(data)
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *ListValue) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	value.Items = make([]SingleValue, 0, c.capacityForItems(length))
+
+	for i := uint32(0); i < length; i++ {
+		err := value.decodeItemFromCursor(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (value *ListValue) decodeTopLevel(c *cursor) error {
 	value.Items = make([]SingleValue, 0)
 
-	for reader.Len() > 0 {
-		err := value.decodeItem(reader)
+	for !c.isEmpty() {
+		err := value.decodeItemFromCursor(c)
 		if err != nil {
 			return err
 		}
@@ -72,6 +129,11 @@ func (value *ListValue) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *ListValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 func (value *ListValue) decodeItem(reader io.Reader) error {
 	if value.ItemCreator == nil {
 		return errors.New("cannot decode list: item creator is nil")
@@ -87,3 +149,19 @@ func (value *ListValue) decodeItem(reader io.Reader) error {
 	value.Items = append(value.Items, newItem)
 	return nil
 }
+
+func (value *ListValue) decodeItemFromCursor(c *cursor) error {
+	if value.ItemCreator == nil {
+		return errors.New("cannot decode list: item creator is nil")
+	}
+
+	newItem := value.ItemCreator()
+
+	err := decodeNestedFromCursor(c, newItem)
+	if err != nil {
+		return err
+	}
+
+	value.Items = append(value.Items, newItem)
+	return nil
+}
@@ -1,5 +1,7 @@
 package abi
 
+import "fmt"
+
 // MultiValue is a multi-value
 type MultiValue struct {
 	Items []any
@@ -15,3 +17,18 @@ type VariadicValues struct {
 type OptionalValue struct {
 	Value any
 }
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *MultiValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *VariadicValues) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *OptionalValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
@@ -0,0 +1,119 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of sequences of values (as mentioned by errors)
+const (
+	inputValuesKind  = "input values"
+	outputValuesKind = "output values"
+)
+
+var (
+	errOptionalValueNotLast                 = errors.New("an optional value must be last")
+	errVariadicValuesNotLast                = errors.New("variadic values must be last")
+	errMissingOptionalValueFollowedByValues = errors.New("a missing optional value cannot be followed by provided values")
+)
+
+// valuesPosition describes where a sequence of values is placed, with respect to the whole tree of input (or output) values.
+// Optional and variadic values are only allowed in some positions:
+// https://docs.multiversx.com/developers/data/multi-values
+type valuesPosition struct {
+	// isLast is true if no other value follows the sequence (within the whole tree, or within an item of variadic values).
+	isLast bool
+	// onlyOptionalsFollow is true if the sequence is only followed by optional (or variadic) values.
+	onlyOptionalsFollow bool
+	// providedValuesFollow is true if the sequence is followed by values that produce at least one part (only relevant for serialization).
+	providedValuesFollow bool
+}
+
+// positionOfLastValues is the position of the top-level values (and of the items of variadic values).
+var positionOfLastValues = valuesPosition{
+	isLast:               true,
+	onlyOptionalsFollow:  true,
+	providedValuesFollow: false,
+}
+
+// ofItem returns the position of the item (at the given index) within a sequence of values placed at the current position.
+func (position valuesPosition) ofItem(values []any, index int) valuesPosition {
+	followingValues := values[index+1:]
+
+	return valuesPosition{
+		isLast:               position.isLast && len(followingValues) == 0,
+		onlyOptionalsFollow:  position.onlyOptionalsFollow && areOptionalValues(followingValues),
+		providedValuesFollow: position.providedValuesFollow || hasProvidedValues(followingValues),
+	}
+}
+
+// checkOptionalValue returns an error if an optional value isn't allowed at this position.
+// Optional values can only be followed by other optional values (or by variadic values).
+func (position valuesPosition) checkOptionalValue(kind string) error {
+	if !position.onlyOptionalsFollow {
+		return fmt.Errorf("%w among %s", errOptionalValueNotLast, kind)
+	}
+
+	return nil
+}
+
+// checkMissingOptionalValue returns an error if a missing optional value isn't allowed at this position (only relevant for serialization).
+func (position valuesPosition) checkMissingOptionalValue() error {
+	if position.providedValuesFollow {
+		return errMissingOptionalValueFollowedByValues
+	}
+
+	return nil
+}
+
+// checkVariadicValues returns an error if variadic values aren't allowed at this position (they must be last).
+func (position valuesPosition) checkVariadicValues(kind string) error {
+	if !position.isLast {
+		return fmt.Errorf("%w among %s", errVariadicValuesNotLast, kind)
+	}
+
+	return nil
+}
+
+// areOptionalValues returns true if all the given values are optional (or variadic) values,
+// or multi-values holding such values.
+func areOptionalValues(values []any) bool {
+	for _, value := range values {
+		switch value := untypedView(value).(type) {
+		case *OptionalValue, *VariadicValues:
+			continue
+		case *MultiValue:
+			if !areOptionalValues(value.Items) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasProvidedValues returns true if any of the given values would produce at least one part, when serialized.
+func hasProvidedValues(values []any) bool {
+	for _, value := range values {
+		switch value := untypedView(value).(type) {
+		case *OptionalValue:
+			if value.Value != nil && hasProvidedValues([]any{value.Value}) {
+				return true
+			}
+		case *MultiValue:
+			if hasProvidedValues(value.Items) {
+				return true
+			}
+		case *VariadicValues:
+			if hasProvidedValues(value.Items) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValuesPosition(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{
+		PartsSeparator: "@",
+	})
+	require.NoError(t, err)
+
+	createOptional := func() any { return &OptionalValue{Value: &U8Value{}} }
+	createVariadic := func() any { return &VariadicValues{ItemCreator: func() any { return &U8Value{} }} }
+
+	// Each decoder (serializer, decoding plans, explainer, lenient decoding) must apply the same rules
+	decoders := map[string]func(outputValues []any) error{
+		"serializer": func(outputValues []any) error {
+			return serializer.Deserialize("01@02", outputValues)
+		},
+		"decoding plan": func(outputValues []any) error {
+			_, err := NewDecodingPlan(ArgsNewDecodingPlan{OutputValues: outputValues, PartsSeparator: "@"})
+			return err
+		},
+		"explainer": func(outputValues []any) error {
+			_, err := serializer.Explain("01@02", outputValues)
+			return err
+		},
+		"lenient decoding": func(outputValues []any) error {
+			failure, err := serializer.DeserializeLeniently("01@02", outputValues)
+			require.NoError(t, err)
+			if failure == nil {
+				return nil
+			}
+
+			return failure.Err
+		},
+	}
+
+	for name, decode := range decoders {
+		t.Run(name, func(t *testing.T) {
+			err := decode([]any{createOptional(), &U8Value{}})
+			require.ErrorIs(t, err, errOptionalValueNotLast)
+			require.ErrorContains(t, err, "among output values")
+
+			err = decode([]any{createVariadic(), &U8Value{}})
+			require.ErrorIs(t, err, errVariadicValuesNotLast)
+
+			err = decode([]any{createVariadic(), createOptional()})
+			require.ErrorIs(t, err, errVariadicValuesNotLast)
+
+			// Optional values may be followed by optional (or variadic) values
+			err = decode([]any{createOptional(), &MultiValue{Items: []any{createOptional()}}, createVariadic()})
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("serialization", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{&OptionalValue{Value: &U8Value{}}, &U8Value{}})
+		require.ErrorIs(t, err, errOptionalValueNotLast)
+		require.ErrorContains(t, err, "among input values")
+
+		_, err = serializer.Serialize([]any{&OptionalValue{}, &OptionalValue{Value: &U8Value{}}})
+		require.ErrorIs(t, err, errMissingOptionalValueFollowedByValues)
+
+		_, err = serializer.Serialize([]any{&VariadicValues{}, &U8Value{}})
+		require.ErrorIs(t, err, errVariadicValuesNotLast)
+	})
+}
@@ -1,7 +1,6 @@
 package abi
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 )
@@ -40,6 +39,43 @@ func (value *OptionValue) EncodeTopLevel(writer io.Writer) error {
 	return value.Value.EncodeNested(writer)
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *OptionValue) SizeNested() int {
+	if value.Value == nil {
+		return 1
+	}
+
+	return 1 + sizeNestedOf(value.Value)
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *OptionValue) SizeTopLevel() int {
+	if value.Value == nil {
+		return 0
+	}
+
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *OptionValue) AppendNested(dst []byte) ([]byte, error) {
+	if value.Value == nil {
+		return append(dst, optionMarkerForAbsentValue), nil
+	}
+
+	dst = append(dst, optionMarkerForPresentValue)
+	return appendNestedOf(dst, value.Value)
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *OptionValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	if value.Value == nil {
+		return dst, nil
+	}
+
+	return value.AppendNested(dst)
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *OptionValue) DecodeNested(reader io.Reader) error {
 	if value.Value == nil {
@@ -83,6 +119,54 @@ func (value *OptionValue) DecodeTopLevel(data []byte) error {
 		return fmt.Errorf("invalid first byte for top-level encoded option: %d", firstByte)
 	}
 
-	reader := bytes.NewReader(dataAfterFirstByte)
-	return value.Value.DecodeNested(reader)
+	return decodeNestedFromCursor(newCursor(dataAfterFirstByte, false), value.Value)
+}
+
+func (value *OptionValue) decodeNested(c *cursor) error {
+	if value.Value == nil {
+		return fmt.Errorf("placeholder value of option should be set before decoding")
+	}
+
+	firstByte, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	if firstByte == optionMarkerForAbsentValue {
+		value.Value = nil
+		return nil
+	}
+
+	if firstByte == optionMarkerForPresentValue {
+		return decodeNestedFromCursor(c, value.Value)
+	}
+
+	return fmt.Errorf("invalid first byte for nested encoded option: %d", firstByte)
+}
+
+func (value *OptionValue) decodeTopLevel(c *cursor) error {
+	if value.Value == nil {
+		return fmt.Errorf("placeholder value of option should be set before decoding")
+	}
+
+	if c.isEmpty() {
+		value.Value = nil
+		return nil
+	}
+
+	firstByte, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	if firstByte != optionMarkerForPresentValue {
+		return fmt.Errorf("invalid first byte for top-level encoded option: %d", firstByte)
+	}
+
+	return decodeNestedFromCursor(c, value.Value)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *OptionValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
 }
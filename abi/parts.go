@@ -66,6 +66,12 @@ func (holder *partsHolder) appendEmptyPart() {
 	holder.parts = append(holder.parts, make([]byte, 0))
 }
 
+// writePart appends a new part, holding the given data
+func (holder *partsHolder) writePart(part []byte) error {
+	holder.appendEmptyPart()
+	return holder.appendToLastPart(part)
+}
+
 // readWholeFocusedPart reads the whole focused part, if any. Otherwise, it returns an error.
 func (holder *partsHolder) readWholeFocusedPart() ([]byte, error) {
 	if holder.isFocusedBeyondLastPart() {
@@ -0,0 +1,82 @@
+package abi
+
+// ReturnCode is the outcome of executing a contract (as reported by the virtual machine)
+type ReturnCode int
+
+const (
+	// ReturnCodeUnknown is used for return codes which aren't recognized
+	ReturnCodeUnknown ReturnCode = -1
+	// ReturnCodeOk is returned when the execution was successful
+	ReturnCodeOk ReturnCode = 0
+	// ReturnCodeFunctionNotFound is returned when the called function doesn't exist
+	ReturnCodeFunctionNotFound ReturnCode = 1
+	// ReturnCodeFunctionWrongSignature is returned when the called function has a bad signature
+	ReturnCodeFunctionWrongSignature ReturnCode = 2
+	// ReturnCodeContractNotFound is returned when the called contract doesn't exist
+	ReturnCodeContractNotFound ReturnCode = 3
+	// ReturnCodeUserError is returned when the contract signals an error (e.g. a failed "require")
+	ReturnCodeUserError ReturnCode = 4
+	// ReturnCodeOutOfGas is returned when the execution ran out of gas
+	ReturnCodeOutOfGas ReturnCode = 5
+	// ReturnCodeAccountCollision is returned when deploying at an address already in use
+	ReturnCodeAccountCollision ReturnCode = 6
+	// ReturnCodeOutOfFunds is returned when the balance is insufficient
+	ReturnCodeOutOfFunds ReturnCode = 7
+	// ReturnCodeCallStackOverFlow is returned when the call stack is too deep
+	ReturnCodeCallStackOverFlow ReturnCode = 8
+	// ReturnCodeContractInvalid is returned when the contract code is invalid
+	ReturnCodeContractInvalid ReturnCode = 9
+	// ReturnCodeExecutionFailed is returned when the execution failed for other reasons
+	ReturnCodeExecutionFailed ReturnCode = 10
+	// ReturnCodeUpgradeFailed is returned when upgrading the contract failed
+	ReturnCodeUpgradeFailed ReturnCode = 11
+	// ReturnCodeSimulateFailed is returned when simulating the execution failed
+	ReturnCodeSimulateFailed ReturnCode = 12
+)
+
+// The texts of the return codes, as found in the data of smart contract results, in logs and in query responses
+var returnCodesTexts = map[ReturnCode]string{
+	ReturnCodeOk:                     "ok",
+	ReturnCodeFunctionNotFound:       "function not found",
+	ReturnCodeFunctionWrongSignature: "wrong signature for function",
+	ReturnCodeContractNotFound:       "contract not found",
+	ReturnCodeUserError:              "user error",
+	ReturnCodeOutOfGas:               "out of gas",
+	ReturnCodeAccountCollision:       "account collision",
+	ReturnCodeOutOfFunds:             "out of funds",
+	ReturnCodeCallStackOverFlow:      "call stack overflow",
+	ReturnCodeContractInvalid:        "contract invalid",
+	ReturnCodeExecutionFailed:        "execution failed",
+	ReturnCodeUpgradeFailed:          "upgrade failed",
+	ReturnCodeSimulateFailed:         "simulate failed",
+}
+
+var returnCodesByText = func() map[string]ReturnCode {
+	codes := make(map[string]ReturnCode, len(returnCodesTexts))
+
+	for code, text := range returnCodesTexts {
+		codes[text] = code
+	}
+
+	return codes
+}()
+
+// String returns the text of the return code (e.g. "ok", "user error")
+func (code ReturnCode) String() string {
+	text, ok := returnCodesTexts[code]
+	if !ok {
+		return "unknown"
+	}
+
+	return text
+}
+
+// ParseReturnCode parses the text of a return code (e.g. "ok", "user error"). Unrecognized texts yield ReturnCodeUnknown.
+func ParseReturnCode(text string) ReturnCode {
+	code, ok := returnCodesByText[text]
+	if !ok {
+		return ReturnCodeUnknown
+	}
+
+	return code
+}
@@ -0,0 +1,26 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnCode(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "ok", ReturnCodeOk.String())
+		require.Equal(t, "user error", ReturnCodeUserError.String())
+		require.Equal(t, "out of funds", ReturnCodeOutOfFunds.String())
+		require.Equal(t, "function not found", ReturnCodeFunctionNotFound.String())
+		require.Equal(t, "unknown", ReturnCodeUnknown.String())
+		require.Equal(t, "unknown", ReturnCode(42).String())
+	})
+
+	t.Run("ParseReturnCode", func(t *testing.T) {
+		require.Equal(t, ReturnCodeOk, ParseReturnCode("ok"))
+		require.Equal(t, ReturnCodeUserError, ParseReturnCode("user error"))
+		require.Equal(t, ReturnCodeSimulateFailed, ParseReturnCode("simulate failed"))
+		require.Equal(t, ReturnCodeUnknown, ParseReturnCode("OK"))
+		require.Equal(t, ReturnCodeUnknown, ParseReturnCode(""))
+	})
+}
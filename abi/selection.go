@@ -0,0 +1,322 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var pathSegmentRegex = regexp.MustCompile(`^(\.[^.\[\]]+|\[[0-9]+\])`)
+
+// selectionNode is a node of the tree of selected paths
+type selectionNode struct {
+	isSelected bool
+	children   map[string]*selectionNode
+}
+
+type selector struct {
+	root *selectionNode
+	// results holds the decoded values, by path
+	results map[string]SingleValue
+	// numPending is the number of selected paths not yet decoded (when zero, the traversal stops)
+	numPending int
+}
+
+// SelectNested decodes only the values found at the given paths, within the nested encoding of a value (of the type of the given placeholder).
+// Everything else is skipped (see SkipNested). Paths follow the same conventions as the ones of ValueDifference,
+// e.g. "$.amount", "$.items[3]", "$.maybe_address.value" (for the inner value of an option).
+// The returned values are new values (the placeholder is never modified). Paths which do not exist in the data
+// (e.g. list index out of range, absent option) are missing from the result.
+// Generic containers (e.g. List[T]) can be selected or skipped, but values within them cannot be selected.
+func SelectNested(data []byte, placeholder SingleValue, paths ...string) (map[string]SingleValue, error) {
+	selector, err := newSelector(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	err = selector.selectNested(newCursor(data, false), "$", placeholder, selector.root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot select (nested) from %T, because of: %w", placeholder, err)
+	}
+
+	return selector.results, nil
+}
+
+// SelectTopLevel decodes only the values found at the given paths, within the top-level encoding of a value (see SelectNested).
+func SelectTopLevel(data []byte, placeholder SingleValue, paths ...string) (map[string]SingleValue, error) {
+	selector, err := newSelector(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	err = selector.selectTopLevel(newCursor(data, false), "$", placeholder, selector.root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot select (top-level) from %T, because of: %w", placeholder, err)
+	}
+
+	return selector.results, nil
+}
+
+func newSelector(paths []string) (*selector, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no path selected")
+	}
+
+	root := &selectionNode{children: make(map[string]*selectionNode)}
+	numPending := 0
+
+	for _, path := range paths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		node := root
+		for _, segment := range segments {
+			child, ok := node.children[segment]
+			if !ok {
+				child = &selectionNode{children: make(map[string]*selectionNode)}
+				node.children[segment] = child
+			}
+
+			node = child
+		}
+
+		if !node.isSelected {
+			node.isSelected = true
+			numPending++
+		}
+	}
+
+	return &selector{
+		root:       root,
+		results:    make(map[string]SingleValue),
+		numPending: numPending,
+	}, nil
+}
+
+// parsePath splits a path (e.g. "$.items[3].amount") into segments (e.g. ".items", "[3]", ".amount")
+func parsePath(path string) ([]string, error) {
+	if len(path) == 0 || path[0] != '$' {
+		return nil, fmt.Errorf("invalid path %q: must start with '$'", path)
+	}
+
+	var segments []string
+	rest := path[1:]
+
+	for len(rest) > 0 {
+		segment := pathSegmentRegex.FindString(rest)
+		if segment == "" {
+			return nil, fmt.Errorf("invalid path %q, near %q", path, rest)
+		}
+
+		segments = append(segments, segment)
+		rest = rest[len(segment):]
+	}
+
+	return segments, nil
+}
+
+func (s *selector) selectNested(c *cursor, path string, placeholder SingleValue, node *selectionNode) error {
+	if node.isSelected {
+		return s.decodeSelected(c, path, placeholder, false)
+	}
+
+	switch placeholder := placeholder.(type) {
+	case *OptionValue:
+		if placeholder.Value == nil {
+			return errors.New("placeholder value of option should be set before decoding")
+		}
+
+		marker, err := c.readByte()
+		if err != nil {
+			return err
+		}
+
+		if marker == optionMarkerForAbsentValue {
+			return nil
+		}
+
+		if marker != optionMarkerForPresentValue {
+			return fmt.Errorf("invalid first byte for nested encoded option: %d", marker)
+		}
+
+		return s.selectInner(c, path, placeholder.Value, node)
+	case *ListValue:
+		length, err := c.readLength()
+		if err != nil {
+			return err
+		}
+
+		return s.selectItems(c, path, placeholder, node, int(length))
+	case *StructValue:
+		return s.selectFields(c, path, placeholder.Fields, node, "struct")
+	case *EnumValue:
+		if placeholder.FieldsProvider == nil {
+			return errors.New("cannot decode enum: fields provider is nil")
+		}
+
+		discriminant, err := c.readByte()
+		if err != nil {
+			return err
+		}
+
+		return s.selectFields(c, path, placeholder.FieldsProvider(discriminant), node, "enum")
+	default:
+		return fmt.Errorf("cannot select within %T", placeholder)
+	}
+}
+
+func (s *selector) selectTopLevel(c *cursor, path string, placeholder SingleValue, node *selectionNode) error {
+	if node.isSelected {
+		return s.decodeSelected(c, path, placeholder, true)
+	}
+
+	switch placeholder := placeholder.(type) {
+	case *OptionValue:
+		if placeholder.Value == nil {
+			return errors.New("placeholder value of option should be set before decoding")
+		}
+
+		if c.isEmpty() {
+			return nil
+		}
+
+		marker, err := c.readByte()
+		if err != nil {
+			return err
+		}
+
+		if marker != optionMarkerForPresentValue {
+			return fmt.Errorf("invalid first byte for top-level encoded option: %d", marker)
+		}
+
+		return s.selectInner(c, path, placeholder.Value, node)
+	case *ListValue:
+		if placeholder.ItemCreator == nil {
+			return errors.New("cannot decode list: item creator is nil")
+		}
+
+		item := placeholder.ItemCreator()
+
+		for i := 0; !c.isEmpty() && s.numPending > 0; i++ {
+			err := s.selectOrSkip(c, fmt.Sprintf("%s[%d]", path, i), item, node.children[fmt.Sprintf("[%d]", i)])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *EnumValue:
+		if c.isEmpty() {
+			// Variant 0, without fields
+			return nil
+		}
+
+		return s.selectNested(c, path, placeholder, node)
+	default:
+		return s.selectNested(c, path, placeholder, node)
+	}
+}
+
+func (s *selector) selectInner(c *cursor, path string, inner SingleValue, node *selectionNode) error {
+	err := s.checkChildren(node, map[string]bool{".value": true})
+	if err != nil {
+		return err
+	}
+
+	return s.selectNested(c, path+".value", inner, node.children[".value"])
+}
+
+func (s *selector) selectItems(c *cursor, path string, placeholder *ListValue, node *selectionNode, numItems int) error {
+	if placeholder.ItemCreator == nil {
+		return errors.New("cannot decode list: item creator is nil")
+	}
+
+	for segment := range node.children {
+		if segment[0] != '[' {
+			return fmt.Errorf("cannot select %q within list", segment)
+		}
+	}
+
+	item := placeholder.ItemCreator()
+
+	for i := 0; i < numItems && s.numPending > 0; i++ {
+		err := s.selectOrSkip(c, fmt.Sprintf("%s[%d]", path, i), item, node.children[fmt.Sprintf("[%d]", i)])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *selector) selectFields(c *cursor, path string, fields []Field, node *selectionNode, kind string) error {
+	knownSegments := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		knownSegments[pathOfField("", i, field.Name)] = true
+	}
+
+	// Fields differ among the variants of an enum: selected fields of other variants are simply missing from the result.
+	if kind == "struct" {
+		err := s.checkChildren(node, knownSegments)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, field := range fields {
+		if s.numPending == 0 {
+			return nil
+		}
+
+		segment := pathOfField("", i, field.Name)
+
+		err := s.selectOrSkip(c, path+segment, field.Value, node.children[segment])
+		if err != nil {
+			return fmt.Errorf("cannot decode field '%s' of %s, because of: %w", field.Name, kind, err)
+		}
+	}
+
+	return nil
+}
+
+// selectOrSkip selects within the value, if any path goes through it, or skips it otherwise
+func (s *selector) selectOrSkip(c *cursor, path string, placeholder SingleValue, node *selectionNode) error {
+	if node == nil {
+		return skipNested(c, placeholder)
+	}
+
+	return s.selectNested(c, path, placeholder, node)
+}
+
+func (s *selector) decodeSelected(c *cursor, path string, placeholder SingleValue, isTopLevel bool) error {
+	value, err := CloneSingleValue(placeholder)
+	if err != nil {
+		return err
+	}
+
+	if isTopLevel {
+		err = decodeTopLevelFromCursor(c, value)
+	} else {
+		err = decodeNestedFromCursor(c, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.results[path] = value
+	s.numPending--
+	return nil
+}
+
+// checkChildren makes sure that the selected paths only go through known segments (e.g. existing fields)
+func (s *selector) checkChildren(node *selectionNode, knownSegments map[string]bool) error {
+	for segment := range node.children {
+		if !knownSegments[segment] {
+			return fmt.Errorf("cannot select unknown %q", segment)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectNested(t *testing.T) {
+	codec := &codec{}
+
+	createPlaceholder := func() SingleValue {
+		return &StructValue{
+			Fields: []Field{
+				{Name: "code", Value: &BytesValue{}},
+				{Name: "items", Value: &ListValue{ItemCreator: func() SingleValue {
+					return &StructValue{Fields: []Field{
+						{Name: "token", Value: &StringValue{}},
+						{Name: "amount", Value: &BigUIntValue{}},
+					}}
+				}}},
+				{Name: "owner", Value: &OptionValue{Value: &AddressValue{}}},
+				{Name: "status", Value: &EnumValue{FieldsProvider: func(discriminant uint8) []Field {
+					if discriminant == 1 {
+						return []Field{{Name: "reason", Value: &StringValue{}}}
+					}
+
+					return nil
+				}}},
+				{Name: "", Value: &U8Value{}},
+			},
+		}
+	}
+
+	value := &StructValue{
+		Fields: []Field{
+			{Name: "code", Value: &BytesValue{Value: make([]byte, 1000)}},
+			{Name: "items", Value: &ListValue{Items: []SingleValue{
+				&StructValue{Fields: []Field{{Name: "token", Value: &StringValue{Value: "A"}}, {Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1)}}}},
+				&StructValue{Fields: []Field{{Name: "token", Value: &StringValue{Value: "B"}}, {Name: "amount", Value: &BigUIntValue{Value: big.NewInt(2)}}}},
+			}}},
+			{Name: "owner", Value: &OptionValue{}},
+			{Name: "status", Value: &EnumValue{Discriminant: 1, Fields: []Field{{Name: "reason", Value: &StringValue{Value: "paused"}}}}},
+			{Name: "", Value: &U8Value{Value: 42}},
+		},
+	}
+
+	data, err := codec.EncodeNested(value)
+	require.NoError(t, err)
+
+	t.Run("should select fields and list items", func(t *testing.T) {
+		results, err := SelectNested(data, createPlaceholder(), "$.items[1].amount", "$.status.reason", "$.4", "$.items[0]")
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(2)}, results["$.items[1].amount"])
+		require.Equal(t, &StringValue{Value: "paused"}, results["$.status.reason"])
+		require.Equal(t, &U8Value{Value: 42}, results["$.4"])
+		require.Empty(t, DiffValues(value.Fields[1].Value.(*ListValue).Items[0], results["$.items[0]"]))
+	})
+
+	t.Run("should select whole value", func(t *testing.T) {
+		results, err := SelectNested(data, createPlaceholder(), "$")
+		require.NoError(t, err)
+		require.Empty(t, DiffValues(value, results["$"]))
+	})
+
+	t.Run("should stop once everything is selected", func(t *testing.T) {
+		// The data is truncated right after the selected value: still, the selection succeeds
+		truncated := data[:4+1000+4+4+1]
+
+		results, err := SelectNested(truncated, createPlaceholder(), "$.items[0].token")
+		require.NoError(t, err)
+		require.Equal(t, &StringValue{Value: "A"}, results["$.items[0].token"])
+	})
+
+	t.Run("should omit paths missing from data", func(t *testing.T) {
+		results, err := SelectNested(data, createPlaceholder(), "$.items[5]", "$.owner.value", "$.status.other")
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("should err on bad paths", func(t *testing.T) {
+		_, err := SelectNested(data, createPlaceholder())
+		require.ErrorContains(t, err, "no path selected")
+
+		_, err = SelectNested(data, createPlaceholder(), "items")
+		require.ErrorContains(t, err, "invalid path \"items\": must start with '$'")
+
+		_, err = SelectNested(data, createPlaceholder(), "$.items[x]")
+		require.ErrorContains(t, err, "invalid path \"$.items[x]\", near \"[x]\"")
+
+		_, err = SelectNested(data, createPlaceholder(), "$.unknown")
+		require.ErrorContains(t, err, "cannot select unknown \".unknown\"")
+
+		_, err = SelectNested(data, createPlaceholder(), "$.items.token")
+		require.ErrorContains(t, err, "cannot select \".token\" within list")
+
+		_, err = SelectNested(data, createPlaceholder(), "$.code.length")
+		require.ErrorContains(t, err, "cannot select within *abi.BytesValue")
+	})
+}
+
+func TestSelectTopLevel(t *testing.T) {
+	placeholder := &ListValue{ItemCreator: func() SingleValue { return &U32Value{} }}
+
+	results, err := SelectTopLevel([]byte{0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}, placeholder, "$[2]", "$[7]")
+	require.NoError(t, err)
+	require.Equal(t, map[string]SingleValue{"$[2]": &U32Value{Value: 3}}, results)
+
+	results, err = SelectTopLevel([]byte{0x01, 0x2a}, &OptionValue{Value: &U8Value{}}, "$.value")
+	require.NoError(t, err)
+	require.Equal(t, map[string]SingleValue{"$.value": &U8Value{Value: 42}}, results)
+
+	results, err = SelectTopLevel([]byte{0x2a}, &U8Value{}, "$")
+	require.NoError(t, err)
+	require.Equal(t, map[string]SingleValue{"$": &U8Value{Value: 42}}, results)
+}
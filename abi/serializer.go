@@ -4,17 +4,25 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 )
 
 type serializer struct {
-	codec          *codec
-	partsSeparator string
+	codec                   *codec
+	partsSeparator          string
+	validateBeforeSerialize bool
 }
 
 // ArgsNewSerializer defines the arguments needed for a new serializer
 type ArgsNewSerializer struct {
 	PartsSeparator string
+	// AliasInputData, if set, causes deserialized byte slices and strings to share memory with the (decoded) input parts,
+	// instead of being copied. This saves allocations, but keeps the parts alive as long as the deserialized values are.
+	// Parts provided to DeserializeParts must not be modified afterwards, if this option is set.
+	AliasInputData bool
+	// ValidateBeforeSerialize, if set, causes the input values to be validated (see ValidateValues) before serialization.
+	ValidateBeforeSerialize bool
 }
 
 // NewSerializer creates a new serializer.
@@ -25,11 +33,14 @@ func NewSerializer(args ArgsNewSerializer) (*serializer, error) {
 		return nil, errors.New("cannot create serializer: parts separator must not be empty")
 	}
 
-	codec := &codec{}
+	codec := &codec{
+		aliasInputData: args.AliasInputData,
+	}
 
 	return &serializer{
-		codec:          codec,
-		partsSeparator: args.PartsSeparator,
+		codec:                   codec,
+		partsSeparator:          args.PartsSeparator,
+		validateBeforeSerialize: args.ValidateBeforeSerialize,
 	}, nil
 }
 
@@ -43,10 +54,34 @@ func (s *serializer) Serialize(inputValues []any) (string, error) {
 	return s.encodeParts(parts), nil
 }
 
+// SerializeTo serializes the given input values, writing the (hex-encoded) parts and separators to the given writer.
+// Unlike Serialize, each part is written as soon as it's produced: neither the parts, nor the hex-encoded output are fully
+// materialized in memory (useful for large payloads, such as contract code). On error, the writer might have received some parts.
+func (s *serializer) SerializeTo(writer io.Writer, inputValues []any) error {
+	err := s.validateInputValues(inputValues)
+	if err != nil {
+		return err
+	}
+
+	partsWriter := newStreamingPartsWriter(writer, s.partsSeparator)
+
+	err = s.doSerialize(partsWriter, inputValues, positionOfLastValues)
+	if err != nil {
+		return err
+	}
+
+	return partsWriter.flush()
+}
+
 func (s *serializer) serializeToParts(inputValues []any) ([][]byte, error) {
+	err := s.validateInputValues(inputValues)
+	if err != nil {
+		return nil, err
+	}
+
 	partsHolder := newEmptyPartsHolder()
 
-	err := s.doSerialize(partsHolder, inputValues)
+	err = s.doSerialize(partsHolder, inputValues, positionOfLastValues)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +89,25 @@ func (s *serializer) serializeToParts(inputValues []any) ([][]byte, error) {
 	return partsHolder.getParts(), nil
 }
 
-func (s *serializer) doSerialize(partsHolder *partsHolder, inputValues []any) error {
+func (s *serializer) validateInputValues(inputValues []any) error {
+	if !s.validateBeforeSerialize {
+		return nil
+	}
+
+	err := problemsToError(ValidateValues(inputValues))
+	if err != nil {
+		return fmt.Errorf("cannot serialize: %w", err)
+	}
+
+	return nil
+}
+
+// partsWriter receives the parts produced by serialization (one part for each single value)
+type partsWriter interface {
+	writePart(part []byte) error
+}
+
+func (s *serializer) doSerialize(partsWriter partsWriter, inputValues []any, position valuesPosition) error {
 	var err error
 
 	for i, value := range inputValues {
@@ -62,29 +115,34 @@ func (s *serializer) doSerialize(partsHolder *partsHolder, inputValues []any) er
 			return errors.New("cannot serialize nil value")
 		}
 
+		itemPosition := position.ofItem(inputValues, i)
+
 		switch value := value.(type) {
 		case *OptionalValue:
-			if i != len(inputValues)-1 {
-				// Usage of multiple optional values is not recommended:
-				// https://docs.multiversx.com/developers/data/multi-values
-				// Thus, here, we disallow them.
-				return errors.New("an optional value must be last among input values")
+			err = itemPosition.checkOptionalValue(inputValuesKind)
+			if err != nil {
+				return err
 			}
 
-			if value.Value != nil {
-				err = s.doSerialize(partsHolder, []any{value.Value})
+			if value.Value == nil {
+				err = itemPosition.checkMissingOptionalValue()
+			} else {
+				err = s.doSerialize(partsWriter, []any{value.Value}, itemPosition)
 			}
 		case *MultiValue:
-			err = s.doSerialize(partsHolder, value.Items)
+			err = s.doSerialize(partsWriter, value.Items, itemPosition)
 		case *VariadicValues:
-			if i != len(inputValues)-1 {
-				return errors.New("variadic values must be last among input values")
+			err = itemPosition.checkVariadicValues(inputValuesKind)
+			if err != nil {
+				return err
 			}
 
-			err = s.doSerialize(partsHolder, value.Items)
+			err = s.serializeVariadicValues(partsWriter, value)
 		case SingleValue:
-			partsHolder.appendEmptyPart()
-			err = s.serializeSingleValue(partsHolder, value)
+			err = s.serializeSingleValue(partsWriter, value)
+		case genericValue:
+			// E.g. Variadic[T], serialized by means of its untyped counterpart
+			err = s.doSerialize(partsWriter, []any{value.toUntyped()}, itemPosition)
 		default:
 			return fmt.Errorf("unsupported type for serialization: %T", value)
 		}
@@ -97,6 +155,23 @@ func (s *serializer) doSerialize(partsHolder *partsHolder, inputValues []any) er
 	return nil
 }
 
+func (s *serializer) serializeVariadicValues(partsWriter partsWriter, value *VariadicValues) error {
+	for i, item := range value.Items {
+		// Each item of the variadic values is serialized as if it was the last among input values,
+		// but it's still followed by the remaining items.
+		err := s.doSerialize(partsWriter, []any{item}, valuesPosition{
+			isLast:               true,
+			onlyOptionalsFollow:  true,
+			providedValuesFollow: hasProvidedValues(value.Items[i+1:]),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Deserialize deserializes the given data into the output values
 func (s *serializer) Deserialize(data string, outputValues []any) error {
 	parts, err := s.decodeIntoParts(data)
@@ -107,10 +182,27 @@ func (s *serializer) Deserialize(data string, outputValues []any) error {
 	return s.deserializeParts(parts, outputValues)
 }
 
+// DeserializeFrom deserializes the data read from the given reader (hex-encoded parts, separated by the parts separator) into the output values.
+// Unlike Deserialize, the hex-encoded input is never fully materialized in memory (useful for large payloads, such as contract code).
+func (s *serializer) DeserializeFrom(reader io.Reader, outputValues []any) error {
+	parts, err := readParts(reader, s.partsSeparator)
+	if err != nil {
+		return err
+	}
+
+	return s.deserializeParts(parts, outputValues)
+}
+
+// DeserializeParts deserializes the given (raw) parts into the output values.
+// This is useful when the data is already split into parts (e.g. the topics of an event).
+func (s *serializer) DeserializeParts(parts [][]byte, outputValues []any) error {
+	return s.deserializeParts(parts, outputValues)
+}
+
 func (s *serializer) deserializeParts(parts [][]byte, outputValues []any) error {
 	partsHolder := newPartsHolder(parts)
 
-	err := s.doDeserialize(partsHolder, outputValues)
+	err := s.doDeserialize(partsHolder, outputValues, positionOfLastValues)
 	if err != nil {
 		return err
 	}
@@ -118,7 +210,7 @@ func (s *serializer) deserializeParts(parts [][]byte, outputValues []any) error
 	return nil
 }
 
-func (s *serializer) doDeserialize(partsHolder *partsHolder, outputValues []any) error {
+func (s *serializer) doDeserialize(partsHolder *partsHolder, outputValues []any, position valuesPosition) error {
 	var err error
 
 	for i, value := range outputValues {
@@ -126,30 +218,34 @@ func (s *serializer) doDeserialize(partsHolder *partsHolder, outputValues []any)
 			return errors.New("cannot deserialize into nil value")
 		}
 
+		itemPosition := position.ofItem(outputValues, i)
+
 		switch value := value.(type) {
 		case *OptionalValue:
-			if i != len(outputValues)-1 {
-				// Usage of multiple optional values is not recommended:
-				// https://docs.multiversx.com/developers/data/multi-values
-				// Thus, here, we disallow them.
-				return errors.New("an optional value must be last among output values")
+			err = itemPosition.checkOptionalValue(outputValuesKind)
+			if err != nil {
+				return err
 			}
 
 			if partsHolder.isFocusedBeyondLastPart() {
 				value.Value = nil
 			} else {
-				err = s.doDeserialize(partsHolder, []any{value.Value})
+				err = s.doDeserialize(partsHolder, []any{value.Value}, itemPosition)
 			}
 		case *MultiValue:
-			err = s.doDeserialize(partsHolder, value.Items)
+			err = s.doDeserialize(partsHolder, value.Items, itemPosition)
 		case *VariadicValues:
-			if i != len(outputValues)-1 {
-				return errors.New("variadic values must be last among output values")
+			err = itemPosition.checkVariadicValues(outputValuesKind)
+			if err != nil {
+				return err
 			}
 
 			err = s.deserializeVariadicValues(partsHolder, value)
 		case SingleValue:
 			err = s.deserializeSingleValue(partsHolder, value)
+		case genericValue:
+			// E.g. Variadic[T], deserialized by means of its untyped counterpart
+			err = s.deserializeGenericValue(partsHolder, value, itemPosition)
 		default:
 			return fmt.Errorf("unsupported type for deserialization: %T", value)
 		}
@@ -162,13 +258,13 @@ func (s *serializer) doDeserialize(partsHolder *partsHolder, outputValues []any)
 	return nil
 }
 
-func (s *serializer) serializeSingleValue(partsHolder *partsHolder, value SingleValue) error {
+func (s *serializer) serializeSingleValue(partsWriter partsWriter, value SingleValue) error {
 	data, err := s.codec.EncodeTopLevel(value)
 	if err != nil {
 		return err
 	}
 
-	return partsHolder.appendToLastPart(data)
+	return partsWriter.writePart(data)
 }
 
 func (s *serializer) deserializeVariadicValues(partsHolder *partsHolder, value *VariadicValues) error {
@@ -179,7 +275,8 @@ func (s *serializer) deserializeVariadicValues(partsHolder *partsHolder, value *
 	for !partsHolder.isFocusedBeyondLastPart() {
 		newItem := value.ItemCreator()
 
-		err := s.doDeserialize(partsHolder, []any{newItem})
+		// Each item of the variadic values is deserialized as if it was the last among output values.
+		err := s.doDeserialize(partsHolder, []any{newItem}, positionOfLastValues)
 		if err != nil {
 			return err
 		}
@@ -190,6 +287,17 @@ func (s *serializer) deserializeVariadicValues(partsHolder *partsHolder, value *
 	return nil
 }
 
+func (s *serializer) deserializeGenericValue(partsHolder *partsHolder, value genericValue, position valuesPosition) error {
+	untyped := value.toUntyped()
+
+	err := s.doDeserialize(partsHolder, []any{untyped}, position)
+	if err != nil {
+		return err
+	}
+
+	return value.fromUntyped(untyped)
+}
+
 func (s *serializer) deserializeSingleValue(partsHolder *partsHolder, value SingleValue) error {
 	part, err := partsHolder.readWholeFocusedPart()
 	if err != nil {
@@ -172,6 +172,138 @@ func TestSerializer_Serialize(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, "41@42@43", data)
 	})
+
+	t.Run("u8, multi<u8, optional<u32>>", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&U8Value{Value: 0x41},
+			&MultiValue{
+				Items: []any{
+					&U8Value{Value: 0x42},
+					&OptionalValue{Value: &U32Value{Value: 0x43444546}},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "41@42@43444546", data)
+	})
+
+	t.Run("multi<u8, optional<u32>>, u8: should err because optional must be last", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{
+			&MultiValue{
+				Items: []any{
+					&U8Value{Value: 0x42},
+					&OptionalValue{Value: &U32Value{Value: 0x43444546}},
+				},
+			},
+			&U8Value{Value: 0x41},
+		})
+
+		require.ErrorContains(t, err, "an optional value must be last among input values")
+	})
+
+	t.Run("multi<variadic<u8>>, u8: should err because variadic must be last", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{
+			&MultiValue{
+				Items: []any{
+					&VariadicValues{Items: []any{&U8Value{Value: 0x42}}},
+				},
+			},
+			&U8Value{Value: 0x41},
+		})
+
+		require.ErrorContains(t, err, "variadic values must be last among input values")
+	})
+
+	t.Run("optional<u8>, optional<u8>", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&OptionalValue{Value: &U8Value{Value: 0x42}},
+			&OptionalValue{Value: &U8Value{Value: 0x43}},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "42@43", data)
+
+		data, err = serializer.Serialize([]any{
+			&OptionalValue{Value: &U8Value{Value: 0x42}},
+			&OptionalValue{},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "42", data)
+	})
+
+	t.Run("optional<u8>, optional<u8>: should err because missing optional is followed by provided optional", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{
+			&OptionalValue{},
+			&OptionalValue{Value: &U8Value{Value: 0x43}},
+		})
+
+		require.ErrorContains(t, err, "a missing optional value cannot be followed by provided values")
+	})
+
+	t.Run("optional<multi<u8, u16>>", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&OptionalValue{
+				Value: &MultiValue{
+					Items: []any{
+						&U8Value{Value: 0x42},
+						&U16Value{Value: 0x4243},
+					},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "42@4243", data)
+	})
+
+	t.Run("variadic<multi<u8, optional<u16>>>", func(t *testing.T) {
+		data, err := serializer.Serialize([]any{
+			&VariadicValues{
+				Items: []any{
+					&MultiValue{
+						Items: []any{
+							&U8Value{Value: 0x42},
+							&OptionalValue{Value: &U16Value{Value: 0x4243}},
+						},
+					},
+					&MultiValue{
+						Items: []any{
+							&U8Value{Value: 0x44},
+							&OptionalValue{},
+						},
+					},
+				},
+			},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "42@4243@44", data)
+	})
+
+	t.Run("variadic<multi<u8, optional<u16>>>: should err because missing optional is followed by other items", func(t *testing.T) {
+		_, err := serializer.Serialize([]any{
+			&VariadicValues{
+				Items: []any{
+					&MultiValue{
+						Items: []any{
+							&U8Value{Value: 0x42},
+							&OptionalValue{},
+						},
+					},
+					&MultiValue{
+						Items: []any{
+							&U8Value{Value: 0x44},
+							&OptionalValue{Value: &U16Value{Value: 0x4445}},
+						},
+					},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "a missing optional value cannot be followed by provided values")
+	})
 }
 
 func TestSerializer_Deserialize(t *testing.T) {
@@ -395,6 +527,142 @@ func TestSerializer_Deserialize(t *testing.T) {
 		err := serializer.Deserialize("0100", []any{destination})
 		require.ErrorContains(t, err, "cannot decode (top-level) *abi.U8Value, because of: decoded value is too large: 256 > 255")
 	})
+
+	t.Run("u8, multi<u8, optional<u32>>", func(t *testing.T) {
+		outputValues := []any{
+			&U8Value{},
+			&MultiValue{
+				Items: []any{
+					&U8Value{},
+					&OptionalValue{Value: &U32Value{}},
+				},
+			},
+		}
+
+		err := serializer.Deserialize("41@42@43444546", outputValues)
+
+		require.Nil(t, err)
+		require.Equal(t, []any{
+			&U8Value{Value: 0x41},
+			&MultiValue{
+				Items: []any{
+					&U8Value{Value: 0x42},
+					&OptionalValue{Value: &U32Value{Value: 0x43444546}},
+				},
+			},
+		}, outputValues)
+	})
+
+	t.Run("multi<u8, optional<u32>>, u8: should err because optional must be last", func(t *testing.T) {
+		outputValues := []any{
+			&MultiValue{
+				Items: []any{
+					&U8Value{},
+					&OptionalValue{Value: &U32Value{}},
+				},
+			},
+			&U8Value{},
+		}
+
+		err := serializer.Deserialize("42@43444546@41", outputValues)
+		require.ErrorContains(t, err, "an optional value must be last among output values")
+	})
+
+	t.Run("optional<u8>, optional<u8>", func(t *testing.T) {
+		outputValues := []any{
+			&OptionalValue{Value: &U8Value{}},
+			&OptionalValue{Value: &U8Value{}},
+		}
+
+		err := serializer.Deserialize("42", outputValues)
+
+		require.Nil(t, err)
+		require.Equal(t, []any{
+			&OptionalValue{Value: &U8Value{Value: 0x42}},
+			&OptionalValue{},
+		}, outputValues)
+	})
+
+	t.Run("variadic<multi<u8, optional<u16>>>", func(t *testing.T) {
+		destination := &VariadicValues{
+			Items: []any{},
+			ItemCreator: func() any {
+				return &MultiValue{
+					Items: []any{
+						&U8Value{},
+						&OptionalValue{Value: &U16Value{}},
+					},
+				}
+			},
+		}
+
+		err := serializer.Deserialize("42@4243@44", []any{destination})
+		require.NoError(t, err)
+
+		require.Equal(t, []any{
+			&MultiValue{
+				Items: []any{
+					&U8Value{Value: 0x42},
+					&OptionalValue{Value: &U16Value{Value: 0x4243}},
+				},
+			},
+			&MultiValue{
+				Items: []any{
+					&U8Value{Value: 0x44},
+					&OptionalValue{},
+				},
+			},
+		}, destination.Items)
+	})
+
+	t.Run("variadic<u8>, u8: should err because variadic must be last", func(t *testing.T) {
+		outputValues := []any{
+			&VariadicValues{ItemCreator: func() any { return &U8Value{} }},
+			&U8Value{},
+		}
+
+		err := serializer.Deserialize("42@43", outputValues)
+		require.ErrorContains(t, err, "variadic values must be last among output values")
+	})
+}
+
+func TestSerializer_DeserializeParts(t *testing.T) {
+	t.Run("should deserialize parts", func(t *testing.T) {
+		serializer, err := NewSerializer(ArgsNewSerializer{
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		parts := [][]byte{{0x42}, []byte("hello"), {0x41, 0x42}}
+		outputValues := []any{&U8Value{}, &StringValue{}, &ListValue{ItemCreator: func() SingleValue { return &U8Value{} }}}
+
+		err = serializer.DeserializeParts(parts, outputValues)
+		require.NoError(t, err)
+		require.Empty(t, DiffValues(&MultiValue{
+			Items: []any{
+				&U8Value{Value: 0x42},
+				&StringValue{Value: "hello"},
+				&ListValue{Items: []SingleValue{&U8Value{Value: 0x41}, &U8Value{Value: 0x42}}},
+			},
+		}, &MultiValue{Items: outputValues}))
+	})
+
+	t.Run("should alias input parts, if enabled", func(t *testing.T) {
+		serializer, err := NewSerializer(ArgsNewSerializer{
+			PartsSeparator: "@",
+			AliasInputData: true,
+		})
+		require.NoError(t, err)
+
+		parts := [][]byte{[]byte("abc")}
+		destination := &BytesValue{}
+
+		err = serializer.DeserializeParts(parts, []any{destination})
+		require.NoError(t, err)
+
+		parts[0][0] = 'x'
+		require.Equal(t, []byte("xbc"), destination.Value)
+	})
 }
 
 func TestSerializer_InRealWorldScenarios(t *testing.T) {
@@ -1,11 +1,16 @@
 package abi
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"reflect"
 )
 
+// lengthPrefixSize is the size of the length prefix of nested-encoded lists, strings, byte slices, big integers etc.
+const lengthPrefixSize = 4
+
 func encodeLength(writer io.Writer, length uint32) error {
 	bytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(bytes, length)
@@ -18,6 +23,10 @@ func encodeLength(writer io.Writer, length uint32) error {
 	return nil
 }
 
+func appendLength(dst []byte, length uint32) []byte {
+	return binary.BigEndian.AppendUint32(dst, length)
+}
+
 func decodeLength(reader io.Reader) (uint32, error) {
 	bytes, err := readBytesExactly(reader, 4)
 	if err != nil {
@@ -27,6 +36,30 @@ func decodeLength(reader io.Reader) (uint32, error) {
 	return binary.BigEndian.Uint32(bytes), nil
 }
 
+// isNilPointer returns true if the given value is a typed nil pointer (e.g. (*U8Value)(nil)), which isn't caught by "value == nil"
+func isNilPointer(value any) bool {
+	reflected := reflect.ValueOf(value)
+	return reflected.Kind() == reflect.Pointer && reflected.IsNil()
+}
+
+// maxPreallocatedItems bounds the capacity preallocated for lists decoded from an io.Reader whose remaining length is unknown
+const maxPreallocatedItems = 1024
+
+// capacityForItemsOfReader returns the capacity to preallocate for a list of "length" items (as read from the data).
+// Since the length might be bogus, it is bounded by the number of remaining bytes (if known), or by maxPreallocatedItems.
+func capacityForItemsOfReader(reader io.Reader, length int) int {
+	bound := maxPreallocatedItems
+	if reader, ok := reader.(interface{ Len() int }); ok {
+		bound = reader.Len()
+	}
+
+	if length > bound {
+		return bound
+	}
+
+	return length
+}
+
 func readBytesExactly(reader io.Reader, numBytes int) ([]byte, error) {
 	if numBytes == 0 {
 		return make([]byte, 0), nil
@@ -43,3 +76,31 @@ func readBytesExactly(reader io.Reader, numBytes int) ([]byte, error) {
 
 	return data, err
 }
+
+// sizeNestedOf returns the length of the nested-encoded form of a single value.
+// For values which do not implement AppendableValue, it actually encodes the value (and returns 0 on error).
+func sizeNestedOf(value SingleValue) int {
+	if value, ok := value.(AppendableValue); ok {
+		return value.SizeNested()
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	_ = value.EncodeNested(buffer)
+	return buffer.Len()
+}
+
+// appendNestedOf appends the nested-encoded form of a single value to the given buffer.
+// For values which do not implement AppendableValue, it falls back to EncodeNested.
+func appendNestedOf(dst []byte, value SingleValue) ([]byte, error) {
+	if value, ok := value.(AppendableValue); ok {
+		return value.AppendNested(dst)
+	}
+
+	buffer := bytes.NewBuffer(dst)
+	err := value.EncodeNested(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
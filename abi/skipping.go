@@ -0,0 +1,170 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// skippableValue is implemented by single values which know how to skip their nested encoding (e.g. generic containers)
+type skippableValue interface {
+	skipNested(c *cursor) error
+}
+
+// SkipNested returns the number of bytes taken by the nested encoding of a value (of the type of the given placeholder),
+// found at the start of the data. The value isn't decoded (nor materialized): the data is traversed using length prefixes and fixed sizes.
+// The placeholder is never modified.
+func SkipNested(data []byte, placeholder SingleValue) (int, error) {
+	c := newCursor(data, false)
+
+	err := skipNested(c, placeholder)
+	if err != nil {
+		return 0, fmt.Errorf("cannot skip (nested) %T, because of: %w", placeholder, err)
+	}
+
+	return c.offset, nil
+}
+
+// skipNested advances the cursor over the nested encoding of a value (of the type of the given placeholder)
+func skipNested(c *cursor, placeholder SingleValue) error {
+	size, isFixed := fixedSizeOf(placeholder)
+	if isFixed {
+		return skipBytes(c, size)
+	}
+
+	switch placeholder := placeholder.(type) {
+	case *StringValue, *BytesValue, *BigIntValue, *BigUIntValue:
+		length, err := c.readLength()
+		if err != nil {
+			return err
+		}
+
+		return skipBytes(c, int(length))
+	case *OptionValue:
+		if placeholder.Value == nil {
+			return errors.New("placeholder value of option should be set before decoding")
+		}
+
+		return skipOption(c, placeholder.Value)
+	case *ListValue:
+		if placeholder.ItemCreator == nil {
+			return errors.New("cannot decode list: item creator is nil")
+		}
+
+		length, err := c.readLength()
+		if err != nil {
+			return err
+		}
+
+		return skipItems(c, placeholder.ItemCreator(), int(length))
+	case *StructValue:
+		return skipFields(c, placeholder.Fields, "struct")
+	case *EnumValue:
+		if placeholder.FieldsProvider == nil {
+			return errors.New("cannot decode enum: fields provider is nil")
+		}
+
+		discriminant, err := c.readByte()
+		if err != nil {
+			return err
+		}
+
+		return skipFields(c, placeholder.FieldsProvider(discriminant), "enum")
+	case skippableValue:
+		return placeholder.skipNested(c)
+	case nil:
+		return errors.New("cannot skip nil value")
+	default:
+		// Values not known to the skipper are decoded into a copy of the placeholder (thus, materialized).
+		value, err := CloneSingleValue(placeholder)
+		if err != nil {
+			return err
+		}
+
+		return decodeNestedFromCursor(c, value)
+	}
+}
+
+func skipOption(c *cursor, inner SingleValue) error {
+	marker, err := c.readByte()
+	if err != nil {
+		return err
+	}
+
+	if marker == optionMarkerForAbsentValue {
+		return nil
+	}
+
+	if marker == optionMarkerForPresentValue {
+		return skipNested(c, inner)
+	}
+
+	return fmt.Errorf("invalid first byte for nested encoded option: %d", marker)
+}
+
+// skipItems skips "numItems" items of a list (or of an array). Items of fixed size are skipped at once.
+func skipItems(c *cursor, item SingleValue, numItems int) error {
+	size, isFixed := fixedSizeOf(item)
+	if isFixed {
+		if size != 0 && numItems > c.remaining()/size {
+			return fmt.Errorf("cannot skip %d items of %d bytes: not enough data", numItems, size)
+		}
+
+		return skipBytes(c, numItems*size)
+	}
+
+	for i := 0; i < numItems; i++ {
+		err := skipNested(c, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func skipFields(c *cursor, fields []Field, kind string) error {
+	for _, field := range fields {
+		err := skipNested(c, field.Value)
+		if err != nil {
+			return fmt.Errorf("cannot skip field '%s' of %s, because of: %w", field.Name, kind, err)
+		}
+	}
+
+	return nil
+}
+
+func skipBytes(c *cursor, numBytes int) error {
+	_, err := c.readSlice(numBytes)
+	return err
+}
+
+// fixedSizeOf returns the size of the nested encoding of values (of the type of the given placeholder), if it's fixed
+func fixedSizeOf(placeholder SingleValue) (int, bool) {
+	switch placeholder := placeholder.(type) {
+	case *U8Value, *I8Value, *BoolValue:
+		return 1, true
+	case *U16Value, *I16Value:
+		return 2, true
+	case *U32Value, *I32Value:
+		return 4, true
+	case *U64Value, *I64Value:
+		return 8, true
+	case *AddressValue:
+		return pubKeyLength, true
+	case *StructValue:
+		total := 0
+
+		for _, field := range placeholder.Fields {
+			size, isFixed := fixedSizeOf(field.Value)
+			if !isFixed {
+				return 0, false
+			}
+
+			total += size
+		}
+
+		return total, true
+	default:
+		return 0, false
+	}
+}
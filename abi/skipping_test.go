@@ -0,0 +1,102 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipNested(t *testing.T) {
+	codec := &codec{}
+
+	createPlaceholder := func() SingleValue {
+		return &StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U16Value{}},
+				{Name: "b", Value: &BigIntValue{}},
+				{Name: "c", Value: &StringValue{}},
+				{Name: "d", Value: &OptionValue{Value: &AddressValue{}}},
+				{Name: "e", Value: &ListValue{ItemCreator: func() SingleValue { return &U64Value{} }}},
+				{Name: "f", Value: &ListValue{ItemCreator: func() SingleValue { return &BytesValue{} }}},
+				{Name: "g", Value: &EnumValue{FieldsProvider: func(discriminant uint8) []Field {
+					return []Field{{Name: "x", Value: &U32Value{}}}
+				}}},
+				{Name: "h", Value: &List[*U8Value]{}},
+				{Name: "i", Value: &Option[*BoolValue]{}},
+				{Name: "j", Value: NewArray[*U16Value](2)},
+			},
+		}
+	}
+
+	value := &StructValue{
+		Fields: []Field{
+			{Name: "a", Value: &U16Value{Value: 1}},
+			{Name: "b", Value: &BigIntValue{Value: big.NewInt(-1000)}},
+			{Name: "c", Value: &StringValue{Value: "hello"}},
+			{Name: "d", Value: &OptionValue{Value: &AddressValue{Value: make([]byte, 32)}}},
+			{Name: "e", Value: &ListValue{Items: []SingleValue{&U64Value{Value: 1}, &U64Value{Value: 2}}}},
+			{Name: "f", Value: &ListValue{Items: []SingleValue{&BytesValue{Value: []byte{1}}, &BytesValue{Value: []byte{}}}}},
+			{Name: "g", Value: &EnumValue{Discriminant: 3, Fields: []Field{{Name: "x", Value: &U32Value{Value: 7}}}}},
+			{Name: "h", Value: &List[*U8Value]{Items: []*U8Value{{Value: 1}}}},
+			{Name: "i", Value: &Option[*BoolValue]{Value: &BoolValue{Value: true}}},
+			{Name: "j", Value: &Array[*U16Value]{Length: 2, Items: []*U16Value{{Value: 1}, {Value: 2}}}},
+		},
+	}
+
+	encoded, err := codec.EncodeNested(value)
+	require.NoError(t, err)
+
+	t.Run("should skip", func(t *testing.T) {
+		// Followed by some other data
+		data := append(append([]byte{}, encoded...), 0xaa, 0xbb)
+
+		size, err := SkipNested(data, createPlaceholder())
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), size)
+	})
+
+	t.Run("should not modify the placeholder", func(t *testing.T) {
+		placeholder := createPlaceholder()
+
+		_, err := SkipNested(encoded, placeholder)
+		require.NoError(t, err)
+		require.Empty(t, DiffValues(createPlaceholder(), placeholder))
+	})
+
+	t.Run("should err on truncated data", func(t *testing.T) {
+		_, err := SkipNested(encoded[:len(encoded)-1], createPlaceholder())
+		require.ErrorContains(t, err, "cannot skip (nested) *abi.StructValue, because of: cannot skip field 'j' of struct")
+
+		_, err = SkipNested([]byte{0xff, 0xff, 0xff, 0xff}, &ListValue{ItemCreator: func() SingleValue { return &U64Value{} }})
+		require.ErrorContains(t, err, "cannot skip 4294967295 items of 8 bytes: not enough data")
+
+		_, err = SkipNested([]byte{0x02}, &OptionValue{Value: &U8Value{}})
+		require.ErrorContains(t, err, "invalid first byte for nested encoded option: 2")
+	})
+
+	t.Run("should not allocate for lists of fixed-size or length-prefixed items", func(t *testing.T) {
+		items := make([]SingleValue, 1000)
+		for i := range items {
+			items[i] = &StructValue{Fields: []Field{
+				{Name: "nonce", Value: &U64Value{Value: uint64(i)}},
+				{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(int64(i))}},
+			}}
+		}
+
+		data, err := codec.EncodeNested(&ListValue{Items: items})
+		require.NoError(t, err)
+
+		placeholder := &ListValue{ItemCreator: func() SingleValue {
+			return &StructValue{Fields: []Field{{Name: "nonce", Value: &U64Value{}}, {Name: "amount", Value: &BigUIntValue{}}}}
+		}}
+
+		allocs := testing.AllocsPerRun(10, func() {
+			c := newCursor(data, false)
+			_ = skipNested(c, placeholder)
+		})
+
+		// The cursor, and the item placeholder (created once, regardless of the number of items)
+		require.LessOrEqual(t, allocs, float64(8))
+	})
+}
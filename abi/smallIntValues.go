@@ -7,6 +7,7 @@ import (
 	"io"
 	"math"
 	"math/big"
+	"math/bits"
 
 	twos "github.com/multiversx/mx-components-big-int/twos-complement"
 )
@@ -26,6 +27,26 @@ func (value *U8Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelUnsignedSmallInt(writer, uint64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *U8Value) SizeNested() int {
+	return 1
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *U8Value) SizeTopLevel() int {
+	return sizeTopLevelUnsignedSmallInt(uint64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *U8Value) AppendNested(dst []byte) ([]byte, error) {
+	return append(dst, value.Value), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *U8Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelUnsignedSmallInt(dst, uint64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *U8Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 1)
@@ -42,6 +63,25 @@ func (value *U8Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *U8Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(1)
+	if err != nil {
+		return err
+	}
+
+	value.Value = uint8(data[0])
+	return nil
+}
+
+func (value *U8Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *U8Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // U16Value is a wrapper for uint16
 type U16Value struct {
 	Value uint16
@@ -57,6 +97,26 @@ func (value *U16Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelUnsignedSmallInt(writer, uint64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *U16Value) SizeNested() int {
+	return 2
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *U16Value) SizeTopLevel() int {
+	return sizeTopLevelUnsignedSmallInt(uint64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *U16Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint16(dst, value.Value), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *U16Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelUnsignedSmallInt(dst, uint64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *U16Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 2)
@@ -73,6 +133,25 @@ func (value *U16Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *U16Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(2)
+	if err != nil {
+		return err
+	}
+
+	value.Value = uint16(binary.BigEndian.Uint16(data))
+	return nil
+}
+
+func (value *U16Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *U16Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // U32Value is a wrapper for uint16
 type U32Value struct {
 	Value uint32
@@ -88,6 +167,26 @@ func (value *U32Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelUnsignedSmallInt(writer, uint64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *U32Value) SizeNested() int {
+	return 4
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *U32Value) SizeTopLevel() int {
+	return sizeTopLevelUnsignedSmallInt(uint64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *U32Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(dst, value.Value), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *U32Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelUnsignedSmallInt(dst, uint64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *U32Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 4)
@@ -104,6 +203,25 @@ func (value *U32Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *U32Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(4)
+	if err != nil {
+		return err
+	}
+
+	value.Value = uint32(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+func (value *U32Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *U32Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // U64Value is a wrapper for uint16
 type U64Value struct {
 	Value uint64
@@ -119,6 +237,26 @@ func (value *U64Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelUnsignedSmallInt(writer, uint64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *U64Value) SizeNested() int {
+	return 8
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *U64Value) SizeTopLevel() int {
+	return sizeTopLevelUnsignedSmallInt(uint64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *U64Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(dst, value.Value), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *U64Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelUnsignedSmallInt(dst, uint64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *U64Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 8)
@@ -135,6 +273,25 @@ func (value *U64Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *U64Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(8)
+	if err != nil {
+		return err
+	}
+
+	value.Value = uint64(binary.BigEndian.Uint64(data))
+	return nil
+}
+
+func (value *U64Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *U64Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // I8Value is a wrapper for uint8
 type I8Value struct {
 	Value int8
@@ -150,6 +307,26 @@ func (value *I8Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelSignedSmallInt(writer, int64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *I8Value) SizeNested() int {
+	return 1
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *I8Value) SizeTopLevel() int {
+	return sizeTopLevelSignedSmallInt(int64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *I8Value) AppendNested(dst []byte) ([]byte, error) {
+	return append(dst, uint8(value.Value)), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *I8Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelSignedSmallInt(dst, int64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *I8Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 1)
@@ -166,6 +343,25 @@ func (value *I8Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *I8Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(1)
+	if err != nil {
+		return err
+	}
+
+	value.Value = int8(data[0])
+	return nil
+}
+
+func (value *I8Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *I8Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // I16Value is a wrapper for uint16
 type I16Value struct {
 	Value int16
@@ -181,6 +377,26 @@ func (value *I16Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelSignedSmallInt(writer, int64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *I16Value) SizeNested() int {
+	return 2
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *I16Value) SizeTopLevel() int {
+	return sizeTopLevelSignedSmallInt(int64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *I16Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint16(dst, uint16(value.Value)), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *I16Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelSignedSmallInt(dst, int64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *I16Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 2)
@@ -197,6 +413,25 @@ func (value *I16Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *I16Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(2)
+	if err != nil {
+		return err
+	}
+
+	value.Value = int16(binary.BigEndian.Uint16(data))
+	return nil
+}
+
+func (value *I16Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *I16Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // I32Value is a wrapper for uint16
 type I32Value struct {
 	Value int32
@@ -212,6 +447,26 @@ func (value *I32Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelSignedSmallInt(writer, int64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *I32Value) SizeNested() int {
+	return 4
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *I32Value) SizeTopLevel() int {
+	return sizeTopLevelSignedSmallInt(int64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *I32Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(dst, uint32(value.Value)), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *I32Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelSignedSmallInt(dst, int64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *I32Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 4)
@@ -228,6 +483,25 @@ func (value *I32Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *I32Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(4)
+	if err != nil {
+		return err
+	}
+
+	value.Value = int32(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+func (value *I32Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *I32Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 // I64Value is a wrapper for uint16
 type I64Value struct {
 	Value int64
@@ -243,6 +517,26 @@ func (value *I64Value) EncodeTopLevel(writer io.Writer) error {
 	return encodeTopLevelSignedSmallInt(writer, int64(value.Value))
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *I64Value) SizeNested() int {
+	return 8
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *I64Value) SizeTopLevel() int {
+	return sizeTopLevelSignedSmallInt(int64(value.Value))
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *I64Value) AppendNested(dst []byte) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(dst, uint64(value.Value)), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *I64Value) AppendTopLevel(dst []byte) ([]byte, error) {
+	return appendTopLevelSignedSmallInt(dst, int64(value.Value)), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *I64Value) DecodeNested(reader io.Reader) error {
 	return decodeNestedSmallInt(reader, &value.Value, 8)
@@ -259,6 +553,25 @@ func (value *I64Value) DecodeTopLevel(data []byte) error {
 	return nil
 }
 
+func (value *I64Value) decodeNested(c *cursor) error {
+	data, err := c.readSlice(8)
+	if err != nil {
+		return err
+	}
+
+	value.Value = int64(binary.BigEndian.Uint64(data))
+	return nil
+}
+
+func (value *I64Value) decodeTopLevel(c *cursor) error {
+	return value.DecodeTopLevel(c.readRemainingSlice())
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *I64Value) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
+
 func encodeNestedSmallInt(writer io.Writer, value any, numBytes int) error {
 	buffer := new(bytes.Buffer)
 
@@ -293,6 +606,38 @@ func encodeTopLevelSignedSmallInt(writer io.Writer, value int64) error {
 	return err
 }
 
+func sizeTopLevelUnsignedSmallInt(value uint64) int {
+	return (bits.Len64(value) + 7) / 8
+}
+
+func sizeTopLevelSignedSmallInt(value int64) int {
+	if value == 0 {
+		return 0
+	}
+
+	if value < 0 {
+		// For negative numbers, the minimal length is given by the magnitude of (-value - 1)
+		return bits.Len64(uint64(-(value+1)))/8 + 1
+	}
+
+	// An extra bit is needed for the sign (to disambiguate from a negative number)
+	return bits.Len64(uint64(value))/8 + 1
+}
+
+func appendTopLevelUnsignedSmallInt(dst []byte, value uint64) []byte {
+	size := sizeTopLevelUnsignedSmallInt(value)
+	data := [8]byte{}
+	binary.BigEndian.PutUint64(data[:], value)
+	return append(dst, data[8-size:]...)
+}
+
+func appendTopLevelSignedSmallInt(dst []byte, value int64) []byte {
+	size := sizeTopLevelSignedSmallInt(value)
+	data := [8]byte{}
+	binary.BigEndian.PutUint64(data[:], uint64(value))
+	return append(dst, data[8-size:]...)
+}
+
 func decodeNestedSmallInt(reader io.Reader, value any, numBytes int) error {
 	data, err := readBytesExactly(reader, numBytes)
 	if err != nil {
@@ -0,0 +1,453 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StorageMapperKind is the kind of a storage mapper, which dictates the layout of the storage entries
+type StorageMapperKind int
+
+const (
+	// SingleValueMapper stores a single value under the base key
+	SingleValueMapper StorageMapperKind = iota
+	// VecMapper stores the length (".len") and the items (".item" + index, starting from 1)
+	VecMapper
+	// SetMapper stores the items in a linked list (".info", ".node_links" + id, ".value" + id), along with an index (".node_id" + item)
+	SetMapper
+	// UnorderedSetMapper stores the items as a VecMapper does, along with an index (".index" + item)
+	UnorderedSetMapper
+	// MapMapper stores the keys as a SetMapper does, along with the values (".mapped" + key)
+	MapMapper
+	// LinkedListMapper stores the list information (".info") and the nodes (".node" + id)
+	LinkedListMapper
+	// UnknownStorageMapper is the kind of the entries whose keys don't belong to any known mapper (not a valid kind of mapper, thus)
+	UnknownStorageMapper
+)
+
+// String returns the name of the kind (e.g. "VecMapper")
+func (kind StorageMapperKind) String() string {
+	switch kind {
+	case SingleValueMapper:
+		return "SingleValueMapper"
+	case VecMapper:
+		return "VecMapper"
+	case SetMapper:
+		return "SetMapper"
+	case UnorderedSetMapper:
+		return "UnorderedSetMapper"
+	case MapMapper:
+		return "MapMapper"
+	case LinkedListMapper:
+		return "LinkedListMapper"
+	case UnknownStorageMapper:
+		return "UnknownStorageMapper"
+	default:
+		return fmt.Sprintf("StorageMapperKind(%d)", int(kind))
+	}
+}
+
+// StorageEntryKind is the kind of a storage entry (of a mapper): the suffix appended to the base key (and to the key arguments)
+type StorageEntryKind string
+
+const (
+	// StorageEntrySingleValue is the entry of a SingleValueMapper (no suffix)
+	StorageEntrySingleValue StorageEntryKind = ""
+	// StorageEntryLen holds the length of a VecMapper (or of an UnorderedSetMapper)
+	StorageEntryLen StorageEntryKind = ".len"
+	// StorageEntryItem holds an item (by index) of a VecMapper (or of an UnorderedSetMapper)
+	StorageEntryItem StorageEntryKind = ".item"
+	// StorageEntryIndex holds the index of an item of an UnorderedSetMapper
+	StorageEntryIndex StorageEntryKind = ".index"
+	// StorageEntryInfo holds the information (length, front, back, next id) of a SetMapper, MapMapper or LinkedListMapper
+	StorageEntryInfo StorageEntryKind = ".info"
+	// StorageEntryNodeLinks holds the links (previous, next) of a node of a SetMapper (or of a MapMapper)
+	StorageEntryNodeLinks StorageEntryKind = ".node_links"
+	// StorageEntryNodeValue holds the item of a node of a SetMapper (or the key of a node of a MapMapper)
+	StorageEntryNodeValue StorageEntryKind = ".value"
+	// StorageEntryNodeId holds the id of the node of an item of a SetMapper (or of a key of a MapMapper)
+	StorageEntryNodeId StorageEntryKind = ".node_id"
+	// StorageEntryMapped holds a value (by key) of a MapMapper
+	StorageEntryMapped StorageEntryKind = ".mapped"
+	// StorageEntryNode holds a node (value, node id, next id, previous id) of a LinkedListMapper
+	StorageEntryNode StorageEntryKind = ".node"
+)
+
+// StorageKeyArgument is an (additional) argument of a storage mapper, appended (nested-encoded) to the base key
+type StorageKeyArgument struct {
+	Name         string
+	ValueCreator func() SingleValue
+}
+
+// StorageMapper describes a storage mapper of a contract
+type StorageMapper struct {
+	// Name is the base key of the mapper
+	Name    string
+	Kind    StorageMapperKind
+	KeyArgs []StorageKeyArgument
+	// ValueCreator creates placeholders for the value (SingleValueMapper), the items (VecMapper, SetMapper, UnorderedSetMapper, LinkedListMapper)
+	// or the values (MapMapper) of the mapper
+	ValueCreator func() SingleValue
+	// MapKeyCreator creates placeholders for the keys of a MapMapper
+	MapKeyCreator func() SingleValue
+}
+
+// StorageEntry is a decoded storage entry (key and value)
+type StorageEntry struct {
+	// MapperName is the name of the mapper (empty if the key isn't recognized)
+	MapperName string
+	// MapperKind is the kind of the mapper (UnknownStorageMapper if the key isn't recognized, e.g. within storage dumps)
+	MapperKind StorageMapperKind
+	Entry      StorageEntryKind
+	KeyArgs    []NamedValue
+	// EntryKey is the key of the entry, following the suffix (e.g. the index of a VecMapper item); nil if none
+	EntryKey SingleValue
+	Value    SingleValue
+	RawKey   []byte
+	RawValue []byte
+	// Failure describes why the value couldn't be decoded (only recorded by DecodeStorageDump, nil on success).
+	// On failure, Value holds everything successfully decoded so far.
+	Failure *DecodingFailure
+}
+
+// storageEntryLayout describes an entry of a mapper: the suffix, along with placeholder creators for the entry key (if any) and the value
+type storageEntryLayout struct {
+	kind            StorageEntryKind
+	entryKeyCreator func() SingleValue
+	valueCreator    func() SingleValue
+}
+
+// ArgsNewStorageCodec defines the arguments needed for a new storage codec
+type ArgsNewStorageCodec struct {
+	Mappers []StorageMapper
+}
+
+// storageCodec builds storage keys and decodes storage entries, according to the layout of the storage mappers
+// (as implemented by the MultiversX Rust framework).
+type storageCodec struct {
+	// mappers are sorted by name (descending length), so that names being prefixes of other names are tried last
+	mappers []StorageMapper
+}
+
+// NewStorageCodec creates a new storage codec
+func NewStorageCodec(args ArgsNewStorageCodec) (*storageCodec, error) {
+	for _, mapper := range args.Mappers {
+		err := checkStorageMapper(mapper)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create storage codec, because of: %w", err)
+		}
+	}
+
+	mappers := make([]StorageMapper, len(args.Mappers))
+	copy(mappers, args.Mappers)
+
+	sort.SliceStable(mappers, func(i, j int) bool {
+		return len(mappers[i].Name) > len(mappers[j].Name)
+	})
+
+	return &storageCodec{
+		mappers: mappers,
+	}, nil
+}
+
+func checkStorageMapper(mapper StorageMapper) error {
+	if mapper.Name == "" {
+		return errors.New("mapper name must not be empty")
+	}
+
+	if mapper.Kind < SingleValueMapper || mapper.Kind > LinkedListMapper {
+		return fmt.Errorf("bad kind of mapper '%s': %s", mapper.Name, mapper.Kind)
+	}
+
+	if mapper.ValueCreator == nil {
+		return fmt.Errorf("missing value creator for mapper '%s'", mapper.Name)
+	}
+
+	if mapper.Kind == MapMapper && mapper.MapKeyCreator == nil {
+		return fmt.Errorf("missing key creator for mapper '%s'", mapper.Name)
+	}
+
+	for _, keyArg := range mapper.KeyArgs {
+		if keyArg.ValueCreator == nil {
+			return fmt.Errorf("missing value creator for key argument '%s' of mapper '%s'", keyArg.Name, mapper.Name)
+		}
+	}
+
+	return nil
+}
+
+// BuildKey builds the storage key of an entry of the given mapper: base key, then key arguments, then suffix, then entry key (if any).
+// For example, the key of the 3rd item of a VecMapper is built with StorageEntryItem and an entry key of &U32Value{Value: 3}.
+func (storage *storageCodec) BuildKey(mapperName string, keyArgs []SingleValue, entry StorageEntryKind, entryKey SingleValue) ([]byte, error) {
+	mapper, err := storage.getMapper(mapperName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyArgs) != len(mapper.KeyArgs) {
+		return nil, fmt.Errorf("bad key arguments for mapper '%s': expected %d, got %d", mapperName, len(mapper.KeyArgs), len(keyArgs))
+	}
+
+	layout, err := getStorageEntryLayout(mapper, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if (layout.entryKeyCreator == nil) != (entryKey == nil) {
+		return nil, fmt.Errorf("bad entry key for entry '%s' of mapper '%s'", entry, mapperName)
+	}
+
+	key := bytes.NewBufferString(mapper.Name)
+
+	for i, keyArg := range keyArgs {
+		err := keyArg.EncodeNested(key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode key argument '%s', because of: %w", mapper.KeyArgs[i].Name, err)
+		}
+	}
+
+	key.WriteString(string(entry))
+
+	if entryKey != nil {
+		err := entryKey.EncodeNested(key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode entry key, because of: %w", err)
+		}
+	}
+
+	return key.Bytes(), nil
+}
+
+// DecodeEntry decodes a storage entry (key and value). The value is decoded according to the mapper and the kind of entry.
+func (storage *storageCodec) DecodeEntry(key []byte, value []byte) (*StorageEntry, error) {
+	entry := storage.findEntry(key)
+	if entry == nil {
+		return nil, fmt.Errorf("unknown storage key: %x", key)
+	}
+
+	entry.RawValue = value
+
+	err := decodeTopLevelExactly(value, entry.Value)
+	if err != nil {
+		return nil, entry.newValueDecodingError(err)
+	}
+
+	return entry, nil
+}
+
+func (entry *StorageEntry) newValueDecodingError(err error) error {
+	return fmt.Errorf("cannot decode value of entry '%s' of mapper '%s', because of: %w", entry.Entry, entry.MapperName, err)
+}
+
+// findEntry looks for the mapper (and the kind of entry) of the key; the value isn't decoded. Returns nil if the key isn't recognized.
+func (storage *storageCodec) findEntry(key []byte) *StorageEntry {
+	for _, mapper := range storage.mappers {
+		if !bytes.HasPrefix(key, []byte(mapper.Name)) {
+			continue
+		}
+
+		entry, ok := storage.matchKey(mapper, key)
+		if ok {
+			entry.RawKey = key
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// matchKey attempts to decode the key (following the base key) according to the layout of the mapper
+func (storage *storageCodec) matchKey(mapper StorageMapper, key []byte) (*StorageEntry, bool) {
+	c := newCursor(key[len(mapper.Name):], false)
+	keyArgs := make([]NamedValue, len(mapper.KeyArgs))
+
+	for i, keyArg := range mapper.KeyArgs {
+		value := keyArg.ValueCreator()
+
+		err := decodeNestedFromCursor(c, value)
+		if err != nil {
+			return nil, false
+		}
+
+		keyArgs[i] = NamedValue{Name: keyArg.Name, Value: value}
+	}
+
+	rest := c.readRemainingSlice()
+
+	for _, layout := range getStorageEntriesLayouts(mapper) {
+		if !bytes.HasPrefix(rest, []byte(layout.kind)) {
+			continue
+		}
+
+		entryKeyData := rest[len(layout.kind):]
+
+		var entryKey SingleValue
+		if layout.entryKeyCreator != nil {
+			entryKey = layout.entryKeyCreator()
+
+			if decodeNestedExactly(entryKeyData, entryKey) != nil {
+				continue
+			}
+		} else if len(entryKeyData) > 0 {
+			continue
+		}
+
+		return &StorageEntry{
+			MapperName: mapper.Name,
+			MapperKind: mapper.Kind,
+			Entry:      layout.kind,
+			KeyArgs:    keyArgs,
+			EntryKey:   entryKey,
+			Value:      layout.valueCreator(),
+		}, true
+	}
+
+	return nil, false
+}
+
+func (storage *storageCodec) getMapper(name string) (*StorageMapper, error) {
+	for i := range storage.mappers {
+		if storage.mappers[i].Name == name {
+			return &storage.mappers[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("mapper not found: %s", name)
+}
+
+func getStorageEntryLayout(mapper *StorageMapper, entry StorageEntryKind) (*storageEntryLayout, error) {
+	for _, layout := range getStorageEntriesLayouts(*mapper) {
+		if layout.kind == entry {
+			return &layout, nil
+		}
+	}
+
+	return nil, fmt.Errorf("entry '%s' not applicable to mapper '%s' (%s)", entry, mapper.Name, mapper.Kind)
+}
+
+// getStorageEntriesLayouts returns the layouts of the entries of a mapper (longer suffixes first)
+func getStorageEntriesLayouts(mapper StorageMapper) []storageEntryLayout {
+	switch mapper.Kind {
+	case SingleValueMapper:
+		return []storageEntryLayout{
+			{kind: StorageEntrySingleValue, valueCreator: mapper.ValueCreator},
+		}
+	case VecMapper:
+		return []storageEntryLayout{
+			{kind: StorageEntryItem, entryKeyCreator: newU32Placeholder, valueCreator: mapper.ValueCreator},
+			{kind: StorageEntryLen, valueCreator: newU32Placeholder},
+		}
+	case UnorderedSetMapper:
+		return []storageEntryLayout{
+			{kind: StorageEntryIndex, entryKeyCreator: mapper.ValueCreator, valueCreator: newU32Placeholder},
+			{kind: StorageEntryItem, entryKeyCreator: newU32Placeholder, valueCreator: mapper.ValueCreator},
+			{kind: StorageEntryLen, valueCreator: newU32Placeholder},
+		}
+	case SetMapper:
+		return getSetStorageEntriesLayouts(mapper.ValueCreator)
+	case MapMapper:
+		return append(
+			[]storageEntryLayout{{kind: StorageEntryMapped, entryKeyCreator: mapper.MapKeyCreator, valueCreator: mapper.ValueCreator}},
+			getSetStorageEntriesLayouts(mapper.MapKeyCreator)...,
+		)
+	case LinkedListMapper:
+		return []storageEntryLayout{
+			{kind: StorageEntryInfo, valueCreator: newListInfoPlaceholder},
+			{kind: StorageEntryNode, entryKeyCreator: newU32Placeholder, valueCreator: func() SingleValue {
+				return &StructValue{Fields: []Field{
+					{Name: "value", Value: mapper.ValueCreator()},
+					{Name: "node_id", Value: &U32Value{}},
+					{Name: "next_id", Value: &U32Value{}},
+					{Name: "prev_id", Value: &U32Value{}},
+				}}
+			}},
+		}
+	default:
+		return nil
+	}
+}
+
+func getSetStorageEntriesLayouts(itemCreator func() SingleValue) []storageEntryLayout {
+	return []storageEntryLayout{
+		{kind: StorageEntryNodeLinks, entryKeyCreator: newU32Placeholder, valueCreator: func() SingleValue {
+			return &StructValue{Fields: []Field{
+				{Name: "previous", Value: &U32Value{}},
+				{Name: "next", Value: &U32Value{}},
+			}}
+		}},
+		{kind: StorageEntryNodeId, entryKeyCreator: itemCreator, valueCreator: newU32Placeholder},
+		{kind: StorageEntryNodeValue, entryKeyCreator: newU32Placeholder, valueCreator: itemCreator},
+		{kind: StorageEntryInfo, valueCreator: newListInfoPlaceholder},
+	}
+}
+
+func newU32Placeholder() SingleValue {
+	return &U32Value{}
+}
+
+// newListInfoPlaceholder creates a placeholder for the information of a linked list (as used by SetMapper, MapMapper and LinkedListMapper)
+func newListInfoPlaceholder() SingleValue {
+	return &StructValue{Fields: []Field{
+		{Name: "len", Value: &U32Value{}},
+		{Name: "front", Value: &U32Value{}},
+		{Name: "back", Value: &U32Value{}},
+		{Name: "new", Value: &U32Value{}},
+	}}
+}
+
+// decodeNestedExactly decodes the value (nested form); all the data must be consumed
+func decodeNestedExactly(data []byte, value SingleValue) error {
+	c := newCursor(data, false)
+
+	err := decodeNestedFromCursor(c, value)
+	if err != nil {
+		return err
+	}
+
+	if !c.isEmpty() {
+		return fmt.Errorf("unexpected trailing bytes: %d", c.remaining())
+	}
+
+	return nil
+}
+
+// decodeTopLevelExactly decodes the value (top-level form); all the data must be consumed
+func decodeTopLevelExactly(data []byte, value SingleValue) error {
+	c := newCursor(data, false)
+
+	err := decodeTopLevelFromCursor(c, value)
+	if err != nil {
+		return err
+	}
+
+	if !c.isEmpty() {
+		return fmt.Errorf("unexpected trailing bytes: %d", c.remaining())
+	}
+
+	return nil
+}
+
+// String returns a short description of the entry, e.g. "balances(erd1...).item(3)"
+func (entry *StorageEntry) String() string {
+	if entry.MapperName == "" {
+		return fmt.Sprintf("unknown(%x)", entry.RawKey)
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(entry.MapperName)
+
+	for _, keyArg := range entry.KeyArgs {
+		builder.WriteString(fmt.Sprintf("(%v)", keyArg.Value))
+	}
+
+	builder.WriteString(string(entry.Entry))
+
+	if entry.EntryKey != nil {
+		builder.WriteString(fmt.Sprintf("(%v)", entry.EntryKey))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,281 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestStorageCodec(t *testing.T) *storageCodec {
+	storage, err := NewStorageCodec(ArgsNewStorageCodec{
+		Mappers: []StorageMapper{
+			{Name: "counter", Kind: SingleValueMapper, ValueCreator: func() SingleValue { return &BigUIntValue{} }},
+			{Name: "counterMax", Kind: SingleValueMapper, ValueCreator: func() SingleValue { return &U64Value{} }},
+			{
+				Name:         "balance",
+				Kind:         SingleValueMapper,
+				KeyArgs:      []StorageKeyArgument{{Name: "user", ValueCreator: func() SingleValue { return &AddressValue{} }}},
+				ValueCreator: func() SingleValue { return &BigUIntValue{} },
+			},
+			{Name: "users", Kind: VecMapper, ValueCreator: func() SingleValue { return &AddressValue{} }},
+			{Name: "whitelist", Kind: UnorderedSetMapper, ValueCreator: func() SingleValue { return &StringValue{} }},
+			{Name: "tokens", Kind: SetMapper, ValueCreator: func() SingleValue { return &StringValue{} }},
+			{
+				Name:          "prices",
+				Kind:          MapMapper,
+				MapKeyCreator: func() SingleValue { return &StringValue{} },
+				ValueCreator:  func() SingleValue { return &BigUIntValue{} },
+			},
+			{Name: "queue", Kind: LinkedListMapper, ValueCreator: func() SingleValue { return &U64Value{} }},
+		},
+	})
+	require.NoError(t, err)
+	return storage
+}
+
+func TestNewStorageCodec(t *testing.T) {
+	_, err := NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{{Kind: SingleValueMapper}}})
+	require.ErrorContains(t, err, "mapper name must not be empty")
+
+	_, err = NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{{Name: "a", Kind: StorageMapperKind(42)}}})
+	require.ErrorContains(t, err, "bad kind of mapper 'a': StorageMapperKind(42)")
+
+	_, err = NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{{Name: "a", Kind: UnknownStorageMapper}}})
+	require.ErrorContains(t, err, "bad kind of mapper 'a': UnknownStorageMapper")
+
+	_, err = NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{{Name: "a", Kind: VecMapper}}})
+	require.ErrorContains(t, err, "missing value creator for mapper 'a'")
+
+	_, err = NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{
+		{Name: "a", Kind: MapMapper, ValueCreator: func() SingleValue { return &U8Value{} }},
+	}})
+	require.ErrorContains(t, err, "missing key creator for mapper 'a'")
+
+	_, err = NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{
+		{Name: "a", Kind: SingleValueMapper, ValueCreator: func() SingleValue { return &U8Value{} }, KeyArgs: []StorageKeyArgument{{Name: "b"}}},
+	}})
+	require.ErrorContains(t, err, "missing value creator for key argument 'b' of mapper 'a'")
+}
+
+func TestStorageCodec_BuildKey(t *testing.T) {
+	storage := createTestStorageCodec(t)
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	t.Run("SingleValueMapper", func(t *testing.T) {
+		key, err := storage.BuildKey("counter", nil, StorageEntrySingleValue, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("counter"), key)
+
+		key, err = storage.BuildKey("balance", []SingleValue{&AddressValue{Value: alice}}, StorageEntrySingleValue, nil)
+		require.NoError(t, err)
+		require.Equal(t, "62616c616e6365"+"0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1", hex.EncodeToString(key))
+	})
+
+	t.Run("VecMapper", func(t *testing.T) {
+		key, err := storage.BuildKey("users", nil, StorageEntryLen, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("users.len"), key)
+
+		key, err = storage.BuildKey("users", nil, StorageEntryItem, &U32Value{Value: 3})
+		require.NoError(t, err)
+		require.Equal(t, "75736572732e6974656d"+"00000003", hex.EncodeToString(key))
+	})
+
+	t.Run("UnorderedSetMapper, SetMapper, MapMapper", func(t *testing.T) {
+		key, err := storage.BuildKey("whitelist", nil, StorageEntryIndex, &StringValue{Value: "abc"})
+		require.NoError(t, err)
+		require.Equal(t, "77686974656c697374"+"2e696e646578"+"00000003616263", hex.EncodeToString(key))
+
+		key, err = storage.BuildKey("tokens", nil, StorageEntryNodeLinks, &U32Value{Value: 1})
+		require.NoError(t, err)
+		require.Equal(t, []byte("tokens.node_links\x00\x00\x00\x01"), key)
+
+		key, err = storage.BuildKey("prices", nil, StorageEntryMapped, &StringValue{Value: "abc"})
+		require.NoError(t, err)
+		require.Equal(t, []byte("prices.mapped\x00\x00\x00\x03abc"), key)
+
+		key, err = storage.BuildKey("prices", nil, StorageEntryInfo, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("prices.info"), key)
+	})
+
+	t.Run("LinkedListMapper", func(t *testing.T) {
+		key, err := storage.BuildKey("queue", nil, StorageEntryNode, &U32Value{Value: 2})
+		require.NoError(t, err)
+		require.Equal(t, []byte("queue.node\x00\x00\x00\x02"), key)
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		_, err := storage.BuildKey("missing", nil, StorageEntrySingleValue, nil)
+		require.ErrorContains(t, err, "mapper not found: missing")
+
+		_, err = storage.BuildKey("balance", nil, StorageEntrySingleValue, nil)
+		require.ErrorContains(t, err, "bad key arguments for mapper 'balance': expected 1, got 0")
+
+		_, err = storage.BuildKey("users", nil, StorageEntryMapped, nil)
+		require.ErrorContains(t, err, "entry '.mapped' not applicable to mapper 'users' (VecMapper)")
+
+		_, err = storage.BuildKey("users", nil, StorageEntryItem, nil)
+		require.ErrorContains(t, err, "bad entry key for entry '.item' of mapper 'users'")
+
+		_, err = storage.BuildKey("users", nil, StorageEntryLen, &U32Value{Value: 1})
+		require.ErrorContains(t, err, "bad entry key for entry '.len' of mapper 'users'")
+	})
+}
+
+func TestStorageCodec_DecodeEntry(t *testing.T) {
+	storage := createTestStorageCodec(t)
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	t.Run("SingleValueMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("counter"), []byte{0x2a})
+		require.NoError(t, err)
+		require.Equal(t, "counter", entry.MapperName)
+		require.Equal(t, SingleValueMapper, entry.MapperKind)
+		require.Equal(t, StorageEntrySingleValue, entry.Entry)
+		require.Empty(t, entry.KeyArgs)
+		require.Nil(t, entry.EntryKey)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(42)}, entry.Value)
+
+		// "counter" is a prefix of "counterMax"
+		entry, err = storage.DecodeEntry([]byte("counterMax"), []byte{0x01, 0x00})
+		require.NoError(t, err)
+		require.Equal(t, "counterMax", entry.MapperName)
+		require.Equal(t, &U64Value{Value: 256}, entry.Value)
+
+		key, _ := storage.BuildKey("balance", []SingleValue{&AddressValue{Value: alice}}, StorageEntrySingleValue, nil)
+		entry, err = storage.DecodeEntry(key, []byte{0x64})
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "user", Value: &AddressValue{Value: alice}}}, entry.KeyArgs)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(100)}, entry.Value)
+	})
+
+	t.Run("VecMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("users.len"), []byte{0x02})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryLen, entry.Entry)
+		require.Equal(t, &U32Value{Value: 2}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("users.item\x00\x00\x00\x02"), alice)
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryItem, entry.Entry)
+		require.Equal(t, &U32Value{Value: 2}, entry.EntryKey)
+		require.Equal(t, &AddressValue{Value: alice}, entry.Value)
+	})
+
+	t.Run("UnorderedSetMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("whitelist.index\x00\x00\x00\x03abc"), []byte{0x01})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryIndex, entry.Entry)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.EntryKey)
+		require.Equal(t, &U32Value{Value: 1}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("whitelist.item\x00\x00\x00\x01"), []byte("abc"))
+		require.NoError(t, err)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.Value)
+	})
+
+	t.Run("SetMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("tokens.info"), []byte{0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 2})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryInfo, entry.Entry)
+		require.Equal(t, &StructValue{Fields: []Field{
+			{Name: "len", Value: &U32Value{Value: 2}},
+			{Name: "front", Value: &U32Value{Value: 1}},
+			{Name: "back", Value: &U32Value{Value: 2}},
+			{Name: "new", Value: &U32Value{Value: 2}},
+		}}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("tokens.node_links\x00\x00\x00\x01"), []byte{0, 0, 0, 0, 0, 0, 0, 2})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryNodeLinks, entry.Entry)
+		require.Equal(t, &StructValue{Fields: []Field{
+			{Name: "previous", Value: &U32Value{Value: 0}},
+			{Name: "next", Value: &U32Value{Value: 2}},
+		}}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("tokens.value\x00\x00\x00\x01"), []byte("abc"))
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryNodeValue, entry.Entry)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("tokens.node_id\x00\x00\x00\x03abc"), []byte{0x01})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryNodeId, entry.Entry)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.EntryKey)
+		require.Equal(t, &U32Value{Value: 1}, entry.Value)
+	})
+
+	t.Run("MapMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("prices.mapped\x00\x00\x00\x03abc"), []byte{0x03, 0xe8})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryMapped, entry.Entry)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.EntryKey)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(1000)}, entry.Value)
+
+		entry, err = storage.DecodeEntry([]byte("prices.value\x00\x00\x00\x01"), []byte("abc"))
+		require.NoError(t, err)
+		require.Equal(t, &StringValue{Value: "abc"}, entry.Value)
+	})
+
+	t.Run("LinkedListMapper", func(t *testing.T) {
+		entry, err := storage.DecodeEntry([]byte("queue.node\x00\x00\x00\x01"), []byte{
+			0, 0, 0, 0, 0, 0, 0, 0x2a, // value
+			0, 0, 0, 1, // node id
+			0, 0, 0, 2, // next id
+			0, 0, 0, 0, // previous id
+		})
+		require.NoError(t, err)
+		require.Equal(t, StorageEntryNode, entry.Entry)
+		require.Equal(t, &StructValue{Fields: []Field{
+			{Name: "value", Value: &U64Value{Value: 42}},
+			{Name: "node_id", Value: &U32Value{Value: 1}},
+			{Name: "next_id", Value: &U32Value{Value: 2}},
+			{Name: "prev_id", Value: &U32Value{Value: 0}},
+		}}, entry.Value)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		key, err := storage.BuildKey("whitelist", nil, StorageEntryIndex, &StringValue{Value: "hello"})
+		require.NoError(t, err)
+
+		entry, err := storage.DecodeEntry(key, []byte{0x07})
+		require.NoError(t, err)
+		require.Equal(t, &StringValue{Value: "hello"}, entry.EntryKey)
+		require.Equal(t, key, entry.RawKey)
+		require.Equal(t, []byte{0x07}, entry.RawValue)
+	})
+
+	t.Run("with bad entries", func(t *testing.T) {
+		_, err := storage.DecodeEntry([]byte("missing"), nil)
+		require.ErrorContains(t, err, "unknown storage key: 6d697373696e67")
+
+		_, err = storage.DecodeEntry([]byte("users.item\x00\x00\x01"), nil)
+		require.ErrorContains(t, err, "unknown storage key")
+
+		_, err = storage.DecodeEntry([]byte("users.unknown"), nil)
+		require.ErrorContains(t, err, "unknown storage key")
+
+		_, err = storage.DecodeEntry([]byte("tokens.info"), []byte{0, 0, 0, 2})
+		require.ErrorContains(t, err, "cannot decode value of entry '.info' of mapper 'tokens'")
+
+		_, err = storage.DecodeEntry([]byte("tokens.node_links\x00\x00\x00\x01"), []byte{0, 0, 0, 0, 0, 0, 0, 2, 0xff})
+		require.ErrorContains(t, err, "unexpected trailing bytes: 1")
+	})
+}
+
+func TestStorageEntry_String(t *testing.T) {
+	storage := createTestStorageCodec(t)
+
+	entry, err := storage.DecodeEntry([]byte("users.item\x00\x00\x00\x02"), make([]byte, 32))
+	require.NoError(t, err)
+	require.Equal(t, "users.item(2)", entry.String())
+
+	entry, err = storage.DecodeEntry([]byte("counter"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "counter", entry.String())
+
+	require.Equal(t, "unknown(6162)", (&StorageEntry{RawKey: []byte("ab")}).String())
+}
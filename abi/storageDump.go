@@ -0,0 +1,101 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DecodeStorageDump decodes the storage of an account, given as JSON (hex-encoded keys and values), either bare or wrapped
+// (as returned by the proxy for "address/:address/keys"): {"data": {"pairs": {...}}}, {"pairs": {...}} or {...}.
+// Keys which don't belong to any known mapper are included as well, as undecoded entries. The entries are sorted by key.
+// Values which cannot be decoded (according to their mapper) don't abort the decoding of the dump: the failures are recorded within the entries.
+func (storage *storageCodec) DecodeStorageDump(data []byte) ([]*StorageEntry, error) {
+	pairs, err := parseStorageDumpPairs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	entries := make([]*StorageEntry, 0, len(keys))
+
+	for _, keyHex := range keys {
+		key, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode storage key %q, because of: %w", keyHex, err)
+		}
+
+		value, err := hex.DecodeString(pairs[keyHex])
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode storage value of key %q, because of: %w", keyHex, err)
+		}
+
+		entry := storage.findEntry(key)
+		if entry == nil {
+			entries = append(entries, &StorageEntry{MapperKind: UnknownStorageMapper, RawKey: key, RawValue: value})
+			continue
+		}
+
+		entry.RawValue = value
+		entry.Failure = decodeEntryValueLeniently(entry, value)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// decodeEntryValueLeniently decodes the value of an entry (as DecodeEntry does), on a best-effort basis (see DecodeTopLevelLeniently).
+// It returns nil if the value was successfully decoded.
+func decodeEntryValueLeniently(entry *StorageEntry, data []byte) *DecodingFailure {
+	explainer := newExplainer(false)
+	c := newCursor(data, false)
+
+	err := explainer.explainTopLevel(c, "$", entry.Value)
+	if err == nil && !c.isEmpty() {
+		explainer.failedPath = "$"
+		err = fmt.Errorf("unexpected trailing bytes: %d", c.remaining())
+	}
+	if err == nil {
+		return nil
+	}
+
+	explainer.addUndecoded(c, err)
+	explainer.failure.Err = entry.newValueDecodingError(err)
+	return explainer.failure
+}
+
+func parseStorageDumpPairs(data []byte) (map[string]string, error) {
+	var wrapped struct {
+		Data *struct {
+			Pairs map[string]string `json:"pairs"`
+		} `json:"data"`
+		Pairs map[string]string `json:"pairs"`
+	}
+
+	err := json.Unmarshal(data, &wrapped)
+	if err == nil {
+		if wrapped.Data != nil && wrapped.Data.Pairs != nil {
+			return wrapped.Data.Pairs, nil
+		}
+
+		if wrapped.Pairs != nil {
+			return wrapped.Pairs, nil
+		}
+	}
+
+	var pairs map[string]string
+
+	err = json.Unmarshal(data, &pairs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse storage dump, because of: %w", err)
+	}
+
+	return pairs, nil
+}
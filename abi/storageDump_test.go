@@ -0,0 +1,99 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageCodec_DecodeStorageDump(t *testing.T) {
+	storage := createTestStorageCodec(t)
+
+	t.Run("wrapped (proxy)", func(t *testing.T) {
+		entries, err := storage.DecodeStorageDump([]byte(`{
+			"data": {
+				"blockInfo": {"nonce": 42},
+				"pairs": {
+					"75736572732e6c656e": "01",
+					"636f756e746572": "2a",
+					"454c524f4e44657364744142432d313233343536": "1203"
+				}
+			},
+			"error": "",
+			"code": "successful"
+		}`))
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		// Sorted by key
+		require.Equal(t, "", entries[0].MapperName)
+		require.Equal(t, UnknownStorageMapper, entries[0].MapperKind)
+		require.Equal(t, []byte("ELRONDesdtABC-123456"), entries[0].RawKey)
+		require.Equal(t, []byte{0x12, 0x03}, entries[0].RawValue)
+		require.Equal(t, "counter", entries[1].MapperName)
+		require.Equal(t, SingleValueMapper, entries[1].MapperKind)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(42)}, entries[1].Value)
+		require.Equal(t, "users", entries[2].MapperName)
+		require.Equal(t, &U32Value{Value: 1}, entries[2].Value)
+	})
+
+	t.Run("bare", func(t *testing.T) {
+		entries, err := storage.DecodeStorageDump([]byte(`{"pairs": {"636f756e746572": "2b"}}`))
+		require.NoError(t, err)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(43)}, entries[0].Value)
+
+		entries, err = storage.DecodeStorageDump([]byte(`{"636f756e746572": "2c"}`))
+		require.NoError(t, err)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(44)}, entries[0].Value)
+	})
+
+	t.Run("with bad dump", func(t *testing.T) {
+		_, err := storage.DecodeStorageDump([]byte(`{`))
+		require.ErrorContains(t, err, "cannot parse storage dump")
+
+		_, err = storage.DecodeStorageDump([]byte(`{"pairs": {"xyz": "00"}}`))
+		require.ErrorContains(t, err, `cannot decode storage key "xyz"`)
+
+		_, err = storage.DecodeStorageDump([]byte(`{"pairs": {"636f756e746572": "xyz"}}`))
+		require.ErrorContains(t, err, `cannot decode storage value of key "636f756e746572"`)
+	})
+
+	t.Run("with bad values", func(t *testing.T) {
+		// "users.len" (bad value), "users.item" 1 (bad value), "counter" (good value)
+		entries, err := storage.DecodeStorageDump([]byte(`{"pairs": {
+			"75736572732e6c656e": "0102030405",
+			"75736572732e6974656d00000001": "0102",
+			"636f756e746572": "2a"
+		}}`))
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		require.Equal(t, "counter", entries[0].MapperName)
+		require.Equal(t, &BigUIntValue{Value: big.NewInt(42)}, entries[0].Value)
+		require.Nil(t, entries[0].Failure)
+
+		require.Equal(t, StorageEntryItem, entries[1].Entry)
+		require.Equal(t, []byte{0x01, 0x02}, entries[1].RawValue)
+		require.ErrorContains(t, entries[1].Failure, "cannot decode value of entry '.item' of mapper 'users'")
+		require.Equal(t, 0, entries[1].Failure.Offset)
+
+		require.Equal(t, StorageEntryLen, entries[2].Entry)
+		require.ErrorContains(t, entries[2].Failure, "cannot decode value of entry '.len' of mapper 'users'")
+
+		// Trailing bytes (after a struct)
+		storage, err := NewStorageCodec(ArgsNewStorageCodec{Mappers: []StorageMapper{{
+			Name:         "pair",
+			Kind:         SingleValueMapper,
+			ValueCreator: func() SingleValue { return &StructValue{Fields: []Field{{Name: "a", Value: &U8Value{}}}} },
+		}}})
+		require.NoError(t, err)
+
+		entries, err = storage.DecodeStorageDump([]byte(`{"pairs": {"70616972": "2a2b"}}`))
+		require.NoError(t, err)
+		require.Equal(t, &StructValue{Fields: []Field{{Name: "a", Value: &U8Value{Value: 42}}}}, entries[0].Value)
+		require.ErrorContains(t, entries[0].Failure, "unexpected trailing bytes: 1")
+		require.Equal(t, 1, entries[0].Failure.Offset)
+		require.Equal(t, []byte{0x2b}, entries[0].Failure.RemainingBytes)
+	})
+}
@@ -0,0 +1,102 @@
+package abi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+)
+
+// streamingPartsWriter writes the parts produced by serialization to a writer (hex-encoded, separated by the parts separator),
+// as soon as they are produced.
+type streamingPartsWriter struct {
+	writer         *bufio.Writer
+	encoder        io.Writer
+	partsSeparator string
+	numParts       int
+}
+
+func newStreamingPartsWriter(writer io.Writer, partsSeparator string) *streamingPartsWriter {
+	bufferedWriter := bufio.NewWriter(writer)
+
+	return &streamingPartsWriter{
+		writer:         bufferedWriter,
+		encoder:        hex.NewEncoder(bufferedWriter),
+		partsSeparator: partsSeparator,
+	}
+}
+
+func (partsWriter *streamingPartsWriter) writePart(part []byte) error {
+	if partsWriter.numParts > 0 {
+		_, err := partsWriter.writer.WriteString(partsWriter.partsSeparator)
+		if err != nil {
+			return err
+		}
+	}
+
+	partsWriter.numParts++
+
+	_, err := partsWriter.encoder.Write(part)
+	return err
+}
+
+func (partsWriter *streamingPartsWriter) flush() error {
+	return partsWriter.writer.Flush()
+}
+
+// readParts reads parts from the reader (hex-encoded, separated by the parts separator), decoding them one by one.
+// Only the current part is held in its hex-encoded form. As for Deserialize, an empty input results in a single (empty) part.
+func readParts(reader io.Reader, partsSeparator string) ([][]byte, error) {
+	bufferedReader := bufio.NewReader(reader)
+	separator := []byte(partsSeparator)
+	lastSeparatorByte := separator[len(separator)-1]
+
+	parts := make([][]byte, 0)
+	partHex := make([]byte, 0)
+
+	for {
+		chunk, err := bufferedReader.ReadSlice(lastSeparatorByte)
+		partHex = append(partHex, chunk...)
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The chunk ends with the last byte of the separator, but not necessarily with the whole separator
+		if !bytes.HasSuffix(partHex, separator) {
+			continue
+		}
+
+		part, err := decodePartHex(partHex[:len(partHex)-len(separator)])
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, part)
+		partHex = partHex[:0]
+	}
+
+	part, err := decodePartHex(partHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(parts, part), nil
+}
+
+func decodePartHex(partHex []byte) ([]byte, error) {
+	part := make([]byte, hex.DecodedLen(len(partHex)))
+
+	_, err := hex.Decode(part, partHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
@@ -0,0 +1,139 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializer_SerializeTo(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should serialize like Serialize", func(t *testing.T) {
+		values := []any{
+			&U8Value{Value: 0x42},
+			&BytesValue{Value: []byte{}},
+			&StringValue{Value: "hello"},
+			&VariadicValues{Items: []any{&U16Value{Value: 1}, &U16Value{Value: 2}}},
+		}
+
+		expected, err := serializer.Serialize(values)
+		require.NoError(t, err)
+
+		buffer := bytes.NewBuffer(nil)
+		err = serializer.SerializeTo(buffer, values)
+		require.NoError(t, err)
+		require.Equal(t, expected, buffer.String())
+		require.Equal(t, "42@@68656c6c6f@01@02", buffer.String())
+	})
+
+	t.Run("should serialize large payload", func(t *testing.T) {
+		code := bytes.Repeat([]byte{0xab, 0xcd}, 1_000_000)
+
+		buffer := bytes.NewBuffer(nil)
+		err := serializer.SerializeTo(buffer, []any{&BytesValue{Value: code}, &U8Value{Value: 1}})
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat("abcd", 1_000_000)+"@01", buffer.String())
+	})
+
+	t.Run("should write parts as soon as they are produced", func(t *testing.T) {
+		code := bytes.Repeat([]byte{0xab}, 100_000)
+
+		// The second value cannot be serialized, but the first one is already written (except for the buffered tail)
+		buffer := bytes.NewBuffer(nil)
+		err := serializer.SerializeTo(buffer, []any{&BytesValue{Value: code}, nil})
+		require.ErrorContains(t, err, "cannot serialize nil value")
+		require.Greater(t, buffer.Len(), 100_000)
+		require.True(t, strings.HasPrefix(strings.Repeat("ab", 100_000), buffer.String()))
+	})
+
+	t.Run("should err on bad values", func(t *testing.T) {
+		err := serializer.SerializeTo(bytes.NewBuffer(nil), []any{nil})
+		require.ErrorContains(t, err, "cannot serialize nil value")
+	})
+
+	t.Run("should err on write error", func(t *testing.T) {
+		err := serializer.SerializeTo(&failingWriter{}, []any{&U8Value{Value: 1}})
+		require.ErrorContains(t, err, "write failed")
+	})
+}
+
+func TestSerializer_DeserializeFrom(t *testing.T) {
+	serializer, err := NewSerializer(ArgsNewSerializer{PartsSeparator: "@"})
+	require.NoError(t, err)
+
+	t.Run("should deserialize like Deserialize", func(t *testing.T) {
+		for _, data := range []string{"", "@", "42@@68656C6C6F@01@02", "@@42@"} {
+			expected, err := serializer.decodeIntoParts(data)
+			require.NoError(t, err)
+
+			// One byte at a time, to exercise the reader
+			actual, err := readParts(iotest.OneByteReader(strings.NewReader(data)), "@")
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+
+		u8 := &U8Value{}
+		str := &StringValue{}
+		variadic := &VariadicValues{ItemCreator: func() any { return &U16Value{} }}
+
+		err = serializer.DeserializeFrom(strings.NewReader("42@68656c6c6f@01@02"), []any{u8, str, variadic})
+		require.NoError(t, err)
+		require.Equal(t, uint8(0x42), u8.Value)
+		require.Equal(t, "hello", str.Value)
+		require.Equal(t, []any{&U16Value{Value: 1}, &U16Value{Value: 2}}, variadic.Items)
+	})
+
+	t.Run("should deserialize, with multi-character separator", func(t *testing.T) {
+		parts, err := readParts(strings.NewReader("41<>4243<>"), "<>")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{{0x41}, {0x42, 0x43}, {}}, parts)
+
+		parts, err = readParts(strings.NewReader("41@@4243@"), "@@")
+		require.ErrorContains(t, err, "invalid byte")
+		require.Nil(t, parts)
+
+		parts, err = readParts(strings.NewReader("41<<>42"), "<>")
+		require.ErrorContains(t, err, "invalid byte")
+		require.Nil(t, parts)
+
+		parts, err = readParts(strings.NewReader("41@@@@42"), "@@")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{{0x41}, {}, {0x42}}, parts)
+	})
+
+	t.Run("should deserialize large payload", func(t *testing.T) {
+		code := &BytesValue{}
+		err := serializer.DeserializeFrom(strings.NewReader(strings.Repeat("abcd", 1_000_000)+"@01"), []any{code, &U8Value{}})
+		require.NoError(t, err)
+		require.Equal(t, bytes.Repeat([]byte{0xab, 0xcd}, 1_000_000), code.Value)
+	})
+
+	t.Run("should err on bad hex", func(t *testing.T) {
+		err := serializer.DeserializeFrom(strings.NewReader("4@42"), []any{&U8Value{}, &U8Value{}})
+		require.ErrorContains(t, err, "odd length hex string")
+
+		err = serializer.DeserializeFrom(strings.NewReader("42@4"), []any{&U8Value{}, &U8Value{}})
+		require.ErrorContains(t, err, "odd length hex string")
+
+		err = serializer.DeserializeFrom(strings.NewReader("zz"), []any{&U8Value{}})
+		require.ErrorContains(t, err, "invalid byte")
+	})
+
+	t.Run("should err on read error", func(t *testing.T) {
+		err := serializer.DeserializeFrom(iotest.ErrReader(errors.New("read failed")), []any{&U8Value{}})
+		require.ErrorContains(t, err, "read failed")
+	})
+}
+
+type failingWriter struct {
+}
+
+func (writer *failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -1,6 +1,7 @@
 package abi
 
 import (
+	"fmt"
 	"io"
 )
 
@@ -27,6 +28,27 @@ func (value *StringValue) EncodeTopLevel(writer io.Writer) error {
 	return err
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *StringValue) SizeNested() int {
+	return lengthPrefixSize + len(value.Value)
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *StringValue) SizeTopLevel() int {
+	return len(value.Value)
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *StringValue) AppendNested(dst []byte) ([]byte, error) {
+	dst = appendLength(dst, uint32(len(value.Value)))
+	return append(dst, value.Value...), nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *StringValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	return append(dst, value.Value...), nil
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *StringValue) DecodeNested(reader io.Reader) error {
 	length, err := decodeLength(reader)
@@ -48,3 +70,33 @@ func (value *StringValue) DecodeTopLevel(data []byte) error {
 	value.Value = string(data)
 	return nil
 }
+
+func (value *StringValue) decodeNested(c *cursor) error {
+	length, err := c.readLength()
+	if err != nil {
+		return err
+	}
+
+	data, err := c.readString(int(length))
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+func (value *StringValue) decodeTopLevel(c *cursor) error {
+	data, err := c.readString(c.remaining())
+	if err != nil {
+		return err
+	}
+
+	value.Value = data
+	return nil
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *StringValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
+}
@@ -1,7 +1,6 @@
 package abi
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 )
@@ -28,6 +27,41 @@ func (value *StructValue) EncodeTopLevel(writer io.Writer) error {
 	return value.EncodeNested(writer)
 }
 
+// SizeNested returns the length of the nested-encoded form of the value (without encoding it)
+func (value *StructValue) SizeNested() int {
+	size := 0
+
+	for _, field := range value.Fields {
+		size += sizeNestedOf(field.Value)
+	}
+
+	return size
+}
+
+// SizeTopLevel returns the length of the top-level-encoded form of the value (without encoding it)
+func (value *StructValue) SizeTopLevel() int {
+	return value.SizeNested()
+}
+
+// AppendNested appends the nested-encoded form of the value to the given buffer
+func (value *StructValue) AppendNested(dst []byte) ([]byte, error) {
+	for _, field := range value.Fields {
+		var err error
+
+		dst, err = appendNestedOf(dst, field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode field '%s' of struct, because of: %w", field.Name, err)
+		}
+	}
+
+	return dst, nil
+}
+
+// AppendTopLevel appends the top-level-encoded form of the value to the given buffer
+func (value *StructValue) AppendTopLevel(dst []byte) ([]byte, error) {
+	return value.AppendNested(dst)
+}
+
 // DecodeNested decodes the value from the nested form
 func (value *StructValue) DecodeNested(reader io.Reader) error {
 	for _, field := range value.Fields {
@@ -42,6 +76,25 @@ func (value *StructValue) DecodeNested(reader io.Reader) error {
 
 // DecodeTopLevel decodes the value from the top-level form
 func (value *StructValue) DecodeTopLevel(data []byte) error {
-	reader := bytes.NewReader(data)
-	return value.DecodeNested(reader)
+	return value.decodeTopLevel(newCursor(data, false))
+}
+
+func (value *StructValue) decodeNested(c *cursor) error {
+	for _, field := range value.Fields {
+		err := decodeNestedFromCursor(c, field.Value)
+		if err != nil {
+			return fmt.Errorf("cannot decode field '%s' of struct, because of: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (value *StructValue) decodeTopLevel(c *cursor) error {
+	return value.decodeNested(c)
+}
+
+// Format implements fmt.Formatter: "%v" renders the value on a single line, "%+v" renders it on multiple lines
+func (value *StructValue) Format(state fmt.State, verb rune) {
+	formatWithVerb(state, verb, value)
 }
@@ -0,0 +1,246 @@
+package abi
+
+// The built-in (system) smart contracts don't ship ABI files. Below, their ABIs are defined programmatically
+// (only the commonly used endpoints are covered).
+
+const (
+	// ESDTSystemContractAddress is the address of the system contract for issuing and managing tokens
+	ESDTSystemContractAddress = "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqzllls8a5w6u"
+	// DelegationManagerContractAddress is the address of the system contract for creating delegation (staking provider) contracts
+	DelegationManagerContractAddress = "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqylllslmq6y6"
+	// StakingContractAddress is the address of the system contract for staking (validators)
+	StakingContractAddress = "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqplllst77y4l"
+	// GovernanceContractAddress is the address of the system contract for on-chain governance
+	GovernanceContractAddress = "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqrlllsrujgla"
+)
+
+// NewESDTSystemContractAbi creates the ABI of the ESDT system contract
+func NewESDTSystemContractAbi() *Definition {
+	return &Definition{
+		Endpoints: []Endpoint{
+			{
+				Name: "issue",
+				Inputs: []Parameter{
+					newStringParameter("tokenName"),
+					newStringParameter("tokenTicker"),
+					newBigUIntParameter("initialSupply"),
+					newU32Parameter("numDecimals"),
+					newTokenPropertiesParameter(),
+				},
+			},
+			{
+				Name: "issueNonFungible",
+				Inputs: []Parameter{
+					newStringParameter("tokenName"),
+					newStringParameter("tokenTicker"),
+					newTokenPropertiesParameter(),
+				},
+			},
+			{
+				Name: "issueSemiFungible",
+				Inputs: []Parameter{
+					newStringParameter("tokenName"),
+					newStringParameter("tokenTicker"),
+					newTokenPropertiesParameter(),
+				},
+			},
+			{
+				Name: "registerMetaESDT",
+				Inputs: []Parameter{
+					newStringParameter("tokenName"),
+					newStringParameter("tokenTicker"),
+					newU32Parameter("numDecimals"),
+					newTokenPropertiesParameter(),
+				},
+			},
+			{
+				Name: "registerAndSetAllRoles",
+				Inputs: []Parameter{
+					newStringParameter("tokenName"),
+					newStringParameter("tokenTicker"),
+					newStringParameter("tokenType"),
+					newU32Parameter("numDecimals"),
+				},
+			},
+			{
+				Name: "setSpecialRole",
+				Inputs: []Parameter{
+					newStringParameter("tokenIdentifier"),
+					newAddressParameter("address"),
+					newVariadicStringsParameter("roles"),
+				},
+			},
+			{
+				Name: "unSetSpecialRole",
+				Inputs: []Parameter{
+					newStringParameter("tokenIdentifier"),
+					newAddressParameter("address"),
+					newVariadicStringsParameter("roles"),
+				},
+			},
+			{
+				Name: "transferOwnership",
+				Inputs: []Parameter{
+					newStringParameter("tokenIdentifier"),
+					newAddressParameter("newOwner"),
+				},
+			},
+			{
+				Name:   "pause",
+				Inputs: []Parameter{newStringParameter("tokenIdentifier")},
+			},
+			{
+				Name:   "unPause",
+				Inputs: []Parameter{newStringParameter("tokenIdentifier")},
+			},
+			{
+				Name:   "freeze",
+				Inputs: []Parameter{newStringParameter("tokenIdentifier"), newAddressParameter("address")},
+			},
+			{
+				Name:   "unFreeze",
+				Inputs: []Parameter{newStringParameter("tokenIdentifier"), newAddressParameter("address")},
+			},
+			{
+				Name:   "wipe",
+				Inputs: []Parameter{newStringParameter("tokenIdentifier"), newAddressParameter("address")},
+			},
+			{
+				Name:    "getTokenProperties",
+				Inputs:  []Parameter{newStringParameter("tokenIdentifier")},
+				Outputs: []Parameter{newVariadicStringsParameter("properties")},
+			},
+			{
+				Name:    "getSpecialRoles",
+				Inputs:  []Parameter{newStringParameter("tokenIdentifier")},
+				Outputs: []Parameter{newVariadicStringsParameter("roles")},
+			},
+		},
+	}
+}
+
+// NewDelegationManagerContractAbi creates the ABI of the delegation manager system contract
+func NewDelegationManagerContractAbi() *Definition {
+	return &Definition{
+		Endpoints: []Endpoint{
+			{
+				Name: "createNewDelegationContract",
+				Inputs: []Parameter{
+					newBigUIntParameter("totalDelegationCap"),
+					newBigUIntParameter("serviceFee"),
+				},
+				Outputs: []Parameter{newAddressParameter("contractAddress")},
+			},
+			{
+				Name: "getAllContractAddresses",
+				Outputs: []Parameter{
+					{Name: "addresses", ValueCreator: func() any {
+						return &VariadicValues{ItemCreator: func() any { return &AddressValue{} }}
+					}},
+				},
+			},
+		},
+	}
+}
+
+// NewStakingContractAbi creates the ABI of the staking (validator) system contract.
+// The optional reward address of "stake" isn't covered (as it follows variadic values).
+func NewStakingContractAbi() *Definition {
+	return &Definition{
+		Endpoints: []Endpoint{
+			{
+				Name: "stake",
+				Inputs: []Parameter{
+					newU32Parameter("numNodes"),
+					{Name: "nodes", ValueCreator: func() any {
+						return &VariadicValues{ItemCreator: func() any {
+							return &MultiValue{Items: []any{&BytesValue{}, &BytesValue{}}}
+						}}
+					}},
+				},
+			},
+			{Name: "unStake", Inputs: []Parameter{newVariadicBytesParameter("blsKeys")}},
+			{Name: "unBond", Inputs: []Parameter{newVariadicBytesParameter("blsKeys")}},
+			{Name: "unJail", Inputs: []Parameter{newVariadicBytesParameter("blsKeys")}},
+			{Name: "claim"},
+			{Name: "changeRewardAddress", Inputs: []Parameter{newAddressParameter("rewardAddress")}},
+		},
+	}
+}
+
+// NewGovernanceContractAbi creates the ABI of the governance system contract
+func NewGovernanceContractAbi() *Definition {
+	return &Definition{
+		Endpoints: []Endpoint{
+			{
+				Name: "proposal",
+				Inputs: []Parameter{
+					newStringParameter("commitHash"),
+					newU64Parameter("startVoteEpoch"),
+					newU64Parameter("endVoteEpoch"),
+				},
+			},
+			{
+				Name: "vote",
+				Inputs: []Parameter{
+					newU64Parameter("proposalNonce"),
+					newStringParameter("vote"),
+				},
+			},
+			{
+				Name: "delegateVote",
+				Inputs: []Parameter{
+					newU64Parameter("proposalNonce"),
+					newStringParameter("vote"),
+					newAddressParameter("voter"),
+					newBigUIntParameter("userStake"),
+				},
+			},
+			{
+				Name:   "closeProposal",
+				Inputs: []Parameter{newU64Parameter("proposalNonce")},
+			},
+		},
+	}
+}
+
+func newStringParameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any { return &StringValue{} }}
+}
+
+func newAddressParameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any { return &AddressValue{} }}
+}
+
+func newBigUIntParameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any { return &BigUIntValue{} }}
+}
+
+func newU32Parameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any { return &U32Value{} }}
+}
+
+func newU64Parameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any { return &U64Value{} }}
+}
+
+func newVariadicStringsParameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any {
+		return &VariadicValues{ItemCreator: func() any { return &StringValue{} }}
+	}}
+}
+
+func newVariadicBytesParameter(name string) Parameter {
+	return Parameter{Name: name, ValueCreator: func() any {
+		return &VariadicValues{ItemCreator: func() any { return &BytesValue{} }}
+	}}
+}
+
+// newTokenPropertiesParameter creates the parameter holding the properties of a token: pairs of name and value ("true" or "false")
+func newTokenPropertiesParameter() Parameter {
+	return Parameter{Name: "properties", ValueCreator: func() any {
+		return &VariadicValues{ItemCreator: func() any {
+			return &MultiValue{Items: []any{&StringValue{}, &StringValue{}}}
+		}}
+	}}
+}
@@ -0,0 +1,280 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// VoteType is a vote on a governance proposal
+type VoteType string
+
+const (
+	// VoteYes is a vote in favor of a proposal
+	VoteYes VoteType = "yes"
+	// VoteNo is a vote against a proposal
+	VoteNo VoteType = "no"
+	// VoteAbstain is an abstention
+	VoteAbstain VoteType = "abstain"
+	// VoteVeto is a vote against a proposal, with veto
+	VoteVeto VoteType = "veto"
+)
+
+// TokenProperties holds the properties of a token, set at issuance
+type TokenProperties struct {
+	CanFreeze          bool
+	CanWipe            bool
+	CanPause           bool
+	CanChangeOwner     bool
+	CanUpgrade         bool
+	CanAddSpecialRoles bool
+	// CanTransferNFTCreateRole is only applicable to non-fungible, semi-fungible and meta tokens
+	CanTransferNFTCreateRole bool
+}
+
+// FungibleTokenIssuance holds the details of issuing a fungible token
+type FungibleTokenIssuance struct {
+	Name          string
+	Ticker        string
+	InitialSupply *big.Int
+	NumDecimals   uint32
+	Properties    TokenProperties
+}
+
+// NonFungibleTokenIssuance holds the details of issuing a non-fungible, semi-fungible or meta token
+type NonFungibleTokenIssuance struct {
+	Name   string
+	Ticker string
+	// NumDecimals is only applicable to meta tokens
+	NumDecimals uint32
+	Properties  TokenProperties
+}
+
+// ValidatorNode is a node to be staked: its BLS public key, along with the signature (of the staker's address)
+type ValidatorNode struct {
+	BLSKey    []byte
+	Signature []byte
+}
+
+// systemContractsDataBuilder builds the data field of the common operations with the system contracts
+// (the arguments are validated against the predefined ABIs, see systemContracts.go)
+type systemContractsDataBuilder struct {
+	esdtBuilder              *callDataBuilder
+	delegationManagerBuilder *callDataBuilder
+	stakingBuilder           *callDataBuilder
+	governanceBuilder        *callDataBuilder
+}
+
+// NewSystemContractsDataBuilder creates a new builder for the data field of system contract calls
+func NewSystemContractsDataBuilder() (*systemContractsDataBuilder, error) {
+	builders := make([]*callDataBuilder, 0, 4)
+
+	abis := []*Definition{
+		NewESDTSystemContractAbi(),
+		NewDelegationManagerContractAbi(),
+		NewStakingContractAbi(),
+		NewGovernanceContractAbi(),
+	}
+
+	for _, abi := range abis {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{Abi: abi})
+		if err != nil {
+			return nil, err
+		}
+
+		builders = append(builders, builder)
+	}
+
+	return &systemContractsDataBuilder{
+		esdtBuilder:              builders[0],
+		delegationManagerBuilder: builders[1],
+		stakingBuilder:           builders[2],
+		governanceBuilder:        builders[3],
+	}, nil
+}
+
+// BuildIssueFungible builds "issue@name@ticker@initialSupply@numDecimals@property@value..."
+func (builder *systemContractsDataBuilder) BuildIssueFungible(issuance FungibleTokenIssuance) (string, error) {
+	if issuance.InitialSupply == nil || issuance.InitialSupply.Sign() < 0 {
+		return "", errors.New("initial supply must be set, and non-negative")
+	}
+
+	return builder.esdtBuilder.Build("issue", []any{
+		&StringValue{Value: issuance.Name},
+		&StringValue{Value: issuance.Ticker},
+		&BigUIntValue{Value: issuance.InitialSupply},
+		&U32Value{Value: issuance.NumDecimals},
+		encodeTokenProperties(issuance.Properties, false),
+	})
+}
+
+// BuildIssueNonFungible builds "issueNonFungible@name@ticker@property@value..."
+func (builder *systemContractsDataBuilder) BuildIssueNonFungible(issuance NonFungibleTokenIssuance) (string, error) {
+	return builder.esdtBuilder.Build("issueNonFungible", []any{
+		&StringValue{Value: issuance.Name},
+		&StringValue{Value: issuance.Ticker},
+		encodeTokenProperties(issuance.Properties, true),
+	})
+}
+
+// BuildIssueSemiFungible builds "issueSemiFungible@name@ticker@property@value..."
+func (builder *systemContractsDataBuilder) BuildIssueSemiFungible(issuance NonFungibleTokenIssuance) (string, error) {
+	return builder.esdtBuilder.Build("issueSemiFungible", []any{
+		&StringValue{Value: issuance.Name},
+		&StringValue{Value: issuance.Ticker},
+		encodeTokenProperties(issuance.Properties, true),
+	})
+}
+
+// BuildRegisterMetaESDT builds "registerMetaESDT@name@ticker@numDecimals@property@value..."
+func (builder *systemContractsDataBuilder) BuildRegisterMetaESDT(issuance NonFungibleTokenIssuance) (string, error) {
+	return builder.esdtBuilder.Build("registerMetaESDT", []any{
+		&StringValue{Value: issuance.Name},
+		&StringValue{Value: issuance.Ticker},
+		&U32Value{Value: issuance.NumDecimals},
+		encodeTokenProperties(issuance.Properties, true),
+	})
+}
+
+// BuildSetSpecialRoles builds "setSpecialRole@token@address@role...", e.g. with roles "ESDTRoleLocalMint", "ESDTRoleNFTCreate"
+func (builder *systemContractsDataBuilder) BuildSetSpecialRoles(tokenIdentifier string, address []byte, roles []string) (string, error) {
+	return builder.buildSpecialRoles("setSpecialRole", tokenIdentifier, address, roles)
+}
+
+// BuildUnsetSpecialRoles builds "unSetSpecialRole@token@address@role..."
+func (builder *systemContractsDataBuilder) BuildUnsetSpecialRoles(tokenIdentifier string, address []byte, roles []string) (string, error) {
+	return builder.buildSpecialRoles("unSetSpecialRole", tokenIdentifier, address, roles)
+}
+
+func (builder *systemContractsDataBuilder) buildSpecialRoles(functionName string, tokenIdentifier string, address []byte, roles []string) (string, error) {
+	if len(roles) == 0 {
+		return "", errors.New("no roles")
+	}
+
+	encodedRoles := make([]any, len(roles))
+
+	for i, role := range roles {
+		encodedRoles[i] = &StringValue{Value: role}
+	}
+
+	return builder.esdtBuilder.Build(functionName, []any{
+		&StringValue{Value: tokenIdentifier},
+		&AddressValue{Value: address},
+		&VariadicValues{Items: encodedRoles},
+	})
+}
+
+// BuildCreateNewDelegationContract builds "createNewDelegationContract@totalDelegationCap@serviceFee".
+// The service fee is expressed in hundredths of percent (e.g. 1000 for 10%).
+func (builder *systemContractsDataBuilder) BuildCreateNewDelegationContract(totalDelegationCap *big.Int, serviceFee *big.Int) (string, error) {
+	if totalDelegationCap == nil || serviceFee == nil {
+		return "", errors.New("total delegation cap and service fee must be set")
+	}
+
+	return builder.delegationManagerBuilder.Build("createNewDelegationContract", []any{
+		&BigUIntValue{Value: totalDelegationCap},
+		&BigUIntValue{Value: serviceFee},
+	})
+}
+
+// BuildStake builds "stake@numNodes@blsKey@signature..."
+func (builder *systemContractsDataBuilder) BuildStake(nodes []ValidatorNode) (string, error) {
+	if len(nodes) == 0 {
+		return "", errors.New("no nodes")
+	}
+
+	encodedNodes := make([]any, len(nodes))
+
+	for i, node := range nodes {
+		if len(node.BLSKey) == 0 || len(node.Signature) == 0 {
+			return "", fmt.Errorf("bad node %d: BLS key and signature must be set", i)
+		}
+
+		encodedNodes[i] = &MultiValue{Items: []any{&BytesValue{Value: node.BLSKey}, &BytesValue{Value: node.Signature}}}
+	}
+
+	return builder.stakingBuilder.Build("stake", []any{
+		&U32Value{Value: uint32(len(nodes))},
+		&VariadicValues{Items: encodedNodes},
+	})
+}
+
+// BuildUnStake builds "unStake@blsKey..."
+func (builder *systemContractsDataBuilder) BuildUnStake(blsKeys [][]byte) (string, error) {
+	if len(blsKeys) == 0 {
+		return "", errors.New("no BLS keys")
+	}
+
+	encodedKeys := make([]any, len(blsKeys))
+
+	for i, key := range blsKeys {
+		encodedKeys[i] = &BytesValue{Value: key}
+	}
+
+	return builder.stakingBuilder.Build("unStake", []any{
+		&VariadicValues{Items: encodedKeys},
+	})
+}
+
+// BuildGovernanceProposal builds "proposal@commitHash@startVoteEpoch@endVoteEpoch"
+func (builder *systemContractsDataBuilder) BuildGovernanceProposal(commitHash string, startVoteEpoch uint64, endVoteEpoch uint64) (string, error) {
+	if startVoteEpoch > endVoteEpoch {
+		return "", fmt.Errorf("bad vote epochs: start (%d) is after end (%d)", startVoteEpoch, endVoteEpoch)
+	}
+
+	return builder.governanceBuilder.Build("proposal", []any{
+		&StringValue{Value: commitHash},
+		&U64Value{Value: startVoteEpoch},
+		&U64Value{Value: endVoteEpoch},
+	})
+}
+
+// BuildGovernanceVote builds "vote@proposalNonce@vote"
+func (builder *systemContractsDataBuilder) BuildGovernanceVote(proposalNonce uint64, vote VoteType) (string, error) {
+	switch vote {
+	case VoteYes, VoteNo, VoteAbstain, VoteVeto:
+	default:
+		return "", fmt.Errorf("bad vote: %q", vote)
+	}
+
+	return builder.governanceBuilder.Build("vote", []any{
+		&U64Value{Value: proposalNonce},
+		&StringValue{Value: string(vote)},
+	})
+}
+
+// encodeTokenProperties encodes the properties as pairs of name and value, in the order expected by the ESDT system contract
+func encodeTokenProperties(properties TokenProperties, isNonFungible bool) *VariadicValues {
+	type namedProperty struct {
+		name  string
+		value bool
+	}
+
+	namedProperties := []namedProperty{
+		{"canFreeze", properties.CanFreeze},
+		{"canWipe", properties.CanWipe},
+		{"canPause", properties.CanPause},
+	}
+
+	if isNonFungible {
+		namedProperties = append(namedProperties, namedProperty{"canTransferNFTCreateRole", properties.CanTransferNFTCreateRole})
+	}
+
+	namedProperties = append(namedProperties,
+		namedProperty{"canChangeOwner", properties.CanChangeOwner},
+		namedProperty{"canUpgrade", properties.CanUpgrade},
+		namedProperty{"canAddSpecialRoles", properties.CanAddSpecialRoles},
+	)
+
+	items := make([]any, len(namedProperties))
+
+	for i, property := range namedProperties {
+		items[i] = &MultiValue{Items: []any{
+			&StringValue{Value: property.name},
+			&StringValue{Value: strconv.FormatBool(property.value)},
+		}}
+	}
+
+	return &VariadicValues{Items: items}
+}
@@ -0,0 +1,123 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemContractsDataBuilder(t *testing.T) {
+	builder, err := NewSystemContractsDataBuilder()
+	require.NoError(t, err)
+
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	t.Run("BuildIssueFungible", func(t *testing.T) {
+		data, err := builder.BuildIssueFungible(FungibleTokenIssuance{
+			Name:          "TEST",
+			Ticker:        "TST",
+			InitialSupply: big.NewInt(1_000_000),
+			NumDecimals:   6,
+			Properties:    TokenProperties{CanFreeze: true, CanUpgrade: true, CanAddSpecialRoles: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "issue@54455354@545354@0f4240@06"+
+			"@63616e467265657a65@74727565"+
+			"@63616e57697065@66616c7365"+
+			"@63616e5061757365@66616c7365"+
+			"@63616e4368616e67654f776e6572@66616c7365"+
+			"@63616e55706772616465@74727565"+
+			"@63616e4164645370656369616c526f6c6573@74727565", data)
+
+		_, err = builder.BuildIssueFungible(FungibleTokenIssuance{Name: "TEST", Ticker: "TST"})
+		require.ErrorContains(t, err, "initial supply must be set, and non-negative")
+	})
+
+	t.Run("BuildIssueNonFungible", func(t *testing.T) {
+		data, err := builder.BuildIssueNonFungible(NonFungibleTokenIssuance{
+			Name:       "TEST",
+			Ticker:     "TST",
+			Properties: TokenProperties{CanTransferNFTCreateRole: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "issueNonFungible@54455354@545354"+
+			"@63616e467265657a65@66616c7365"+
+			"@63616e57697065@66616c7365"+
+			"@63616e5061757365@66616c7365"+
+			"@63616e5472616e736665724e4654437265617465526f6c65@74727565"+
+			"@63616e4368616e67654f776e6572@66616c7365"+
+			"@63616e55706772616465@66616c7365"+
+			"@63616e4164645370656369616c526f6c6573@66616c7365", data)
+	})
+
+	t.Run("BuildIssueSemiFungible, BuildRegisterMetaESDT", func(t *testing.T) {
+		data, err := builder.BuildIssueSemiFungible(NonFungibleTokenIssuance{Name: "TEST", Ticker: "TST"})
+		require.NoError(t, err)
+		require.Contains(t, data, "issueSemiFungible@54455354@545354@63616e467265657a65@66616c7365")
+
+		data, err = builder.BuildRegisterMetaESDT(NonFungibleTokenIssuance{Name: "TEST", Ticker: "TST", NumDecimals: 18})
+		require.NoError(t, err)
+		require.Contains(t, data, "registerMetaESDT@54455354@545354@12@63616e467265657a65@66616c7365")
+	})
+
+	t.Run("BuildSetSpecialRoles, BuildUnsetSpecialRoles", func(t *testing.T) {
+		data, err := builder.BuildSetSpecialRoles("TST-123456", alice, []string{"ESDTRoleNFTCreate", "ESDTRoleLocalMint"})
+		require.NoError(t, err)
+		require.Equal(t, "setSpecialRole@5453542d313233343536@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1"+
+			"@45534454526f6c654e4654437265617465@45534454526f6c654c6f63616c4d696e74", data)
+
+		data, err = builder.BuildUnsetSpecialRoles("TST-123456", alice, []string{"ESDTRoleLocalMint"})
+		require.NoError(t, err)
+		require.Equal(t, "unSetSpecialRole@5453542d313233343536@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1"+
+			"@45534454526f6c654c6f63616c4d696e74", data)
+
+		_, err = builder.BuildSetSpecialRoles("TST-123456", alice, nil)
+		require.ErrorContains(t, err, "no roles")
+	})
+
+	t.Run("BuildCreateNewDelegationContract", func(t *testing.T) {
+		data, err := builder.BuildCreateNewDelegationContract(big.NewInt(0), big.NewInt(1000))
+		require.NoError(t, err)
+		require.Equal(t, "createNewDelegationContract@@03e8", data)
+
+		_, err = builder.BuildCreateNewDelegationContract(nil, big.NewInt(1000))
+		require.ErrorContains(t, err, "total delegation cap and service fee must be set")
+	})
+
+	t.Run("BuildStake, BuildUnStake", func(t *testing.T) {
+		data, err := builder.BuildStake([]ValidatorNode{
+			{BLSKey: []byte{0xaa}, Signature: []byte{0xbb}},
+			{BLSKey: []byte{0xcc}, Signature: []byte{0xdd}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "stake@02@aa@bb@cc@dd", data)
+
+		_, err = builder.BuildStake([]ValidatorNode{{BLSKey: []byte{0xaa}}})
+		require.ErrorContains(t, err, "bad node 0: BLS key and signature must be set")
+
+		data, err = builder.BuildUnStake([][]byte{{0xaa}, {0xcc}})
+		require.NoError(t, err)
+		require.Equal(t, "unStake@aa@cc", data)
+
+		_, err = builder.BuildUnStake(nil)
+		require.ErrorContains(t, err, "no BLS keys")
+	})
+
+	t.Run("BuildGovernanceProposal, BuildGovernanceVote", func(t *testing.T) {
+		data, err := builder.BuildGovernanceProposal("0123456789abcdef0123456789abcdef01234567", 10, 15)
+		require.NoError(t, err)
+		require.Equal(t, "proposal@30313233343536373839616263646566303132333435363738396162636465663031323334353637@0a@0f", data)
+
+		_, err = builder.BuildGovernanceProposal("0123456789abcdef0123456789abcdef01234567", 15, 10)
+		require.ErrorContains(t, err, "bad vote epochs: start (15) is after end (10)")
+
+		data, err = builder.BuildGovernanceVote(1, VoteVeto)
+		require.NoError(t, err)
+		require.Equal(t, "vote@01@7665746f", data)
+
+		_, err = builder.BuildGovernanceVote(1, "maybe")
+		require.ErrorContains(t, err, `bad vote: "maybe"`)
+	})
+}
@@ -0,0 +1,117 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	esdtSetRoleEventIdentifier   = "ESDTSetRole"
+	esdtUnSetRoleEventIdentifier = "ESDTUnSetRole"
+)
+
+// The identifiers of the events emitted by the ESDT system contract when a token is issued (the names of the endpoints)
+var tokenIssuedEventIdentifiers = map[string]struct{}{
+	"issue":                  {},
+	"issueNonFungible":       {},
+	"issueSemiFungible":      {},
+	"registerMetaESDT":       {},
+	"registerAndSetAllRoles": {},
+}
+
+// TokenIssuedEvent is emitted by the ESDT system contract when a token is issued
+type TokenIssuedEvent struct {
+	TokenIdentifier string
+	Name            string
+	Ticker          string
+	// TokenType is one of: "FungibleESDT", "NonFungibleESDT", "SemiFungibleESDT", "MetaESDT"
+	TokenType   string
+	NumDecimals uint32
+	// Issuer is the address of the issuer (bech32)
+	Issuer string
+}
+
+// TokenRolesEvent is emitted when special roles are set (or unset) for an address
+type TokenRolesEvent struct {
+	TokenIdentifier string
+	Roles           []string
+	// Address is the address (bech32) which received (or lost) the roles
+	Address string
+	// IsUnset is set if the roles were unset
+	IsUnset bool
+}
+
+// IsTokenIssuedEvent returns whether the event is emitted when a token is issued
+func IsTokenIssuedEvent(event *TransactionEvent) bool {
+	_, ok := tokenIssuedEventIdentifiers[event.Identifier]
+	return ok
+}
+
+// ParseTokenIssuedEvent parses an event emitted when a token is issued.
+// The topics are: token identifier, name, ticker, type and (for fungible and meta tokens) the number of decimals.
+func ParseTokenIssuedEvent(event *TransactionEvent) (*TokenIssuedEvent, error) {
+	if !IsTokenIssuedEvent(event) {
+		return nil, fmt.Errorf("not a token issued event: %s", event.Identifier)
+	}
+
+	if len(event.Topics) < 4 {
+		return nil, fmt.Errorf("bad token issued event: expected at least 4 topics, got %d", len(event.Topics))
+	}
+
+	parsed := &TokenIssuedEvent{
+		TokenIdentifier: string(event.Topics[0]),
+		Name:            string(event.Topics[1]),
+		Ticker:          string(event.Topics[2]),
+		TokenType:       string(event.Topics[3]),
+		Issuer:          event.Address,
+	}
+
+	if len(event.Topics) > 4 {
+		numDecimals := big.NewInt(0).SetBytes(event.Topics[4])
+		if !numDecimals.IsUint64() || numDecimals.Uint64() > 0xFFFFFFFF {
+			return nil, fmt.Errorf("bad token issued event: bad number of decimals: %s", numDecimals)
+		}
+
+		parsed.NumDecimals = uint32(numDecimals.Uint64())
+	}
+
+	return parsed, nil
+}
+
+// ParseTokenRolesEvent parses an "ESDTSetRole" (or "ESDTUnSetRole") event.
+// The topics are: token identifier, nonce (empty), value (empty), then the roles.
+func ParseTokenRolesEvent(event *TransactionEvent) (*TokenRolesEvent, error) {
+	isUnset := event.Identifier == esdtUnSetRoleEventIdentifier
+	if event.Identifier != esdtSetRoleEventIdentifier && !isUnset {
+		return nil, fmt.Errorf("not a token roles event: %s", event.Identifier)
+	}
+
+	if len(event.Topics) < 3 {
+		return nil, fmt.Errorf("bad token roles event: expected at least 3 topics, got %d", len(event.Topics))
+	}
+
+	roles := make([]string, 0, len(event.Topics)-3)
+
+	for _, topic := range event.Topics[3:] {
+		roles = append(roles, string(topic))
+	}
+
+	return &TokenRolesEvent{
+		TokenIdentifier: string(event.Topics[0]),
+		Roles:           roles,
+		Address:         event.Address,
+		IsUnset:         isUnset,
+	}, nil
+}
+
+// FindTokenIssuedEvent looks for the event emitted when the token was issued (by the given transaction)
+func FindTokenIssuedEvent(transaction *TransactionOnNetwork) (*TokenIssuedEvent, error) {
+	for _, event := range transaction.getAllEvents() {
+		if IsTokenIssuedEvent(event) {
+			return ParseTokenIssuedEvent(event)
+		}
+	}
+
+	return nil, errors.New("token issued event not found")
+}
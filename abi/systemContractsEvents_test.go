@@ -0,0 +1,94 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenIssuedEvent(t *testing.T) {
+	t.Run("fungible token", func(t *testing.T) {
+		event := &TransactionEvent{
+			Address:    "erd1alice",
+			Identifier: "issue",
+			Topics:     [][]byte{[]byte("TST-123456"), []byte("TEST"), []byte("TST"), []byte("FungibleESDT"), {0x06}},
+		}
+
+		require.True(t, IsTokenIssuedEvent(event))
+
+		parsed, err := ParseTokenIssuedEvent(event)
+		require.NoError(t, err)
+		require.Equal(t, &TokenIssuedEvent{
+			TokenIdentifier: "TST-123456",
+			Name:            "TEST",
+			Ticker:          "TST",
+			TokenType:       "FungibleESDT",
+			NumDecimals:     6,
+			Issuer:          "erd1alice",
+		}, parsed)
+	})
+
+	t.Run("non-fungible token", func(t *testing.T) {
+		parsed, err := ParseTokenIssuedEvent(&TransactionEvent{
+			Identifier: "issueNonFungible",
+			Topics:     [][]byte{[]byte("NFT-123456"), []byte("TEST"), []byte("NFT"), []byte("NonFungibleESDT")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "NFT-123456", parsed.TokenIdentifier)
+		require.Equal(t, uint32(0), parsed.NumDecimals)
+	})
+
+	t.Run("with bad event", func(t *testing.T) {
+		_, err := ParseTokenIssuedEvent(&TransactionEvent{Identifier: "writeLog"})
+		require.ErrorContains(t, err, "not a token issued event: writeLog")
+
+		_, err = ParseTokenIssuedEvent(&TransactionEvent{Identifier: "issue", Topics: [][]byte{{}}})
+		require.ErrorContains(t, err, "bad token issued event: expected at least 4 topics, got 1")
+
+		_, err = ParseTokenIssuedEvent(&TransactionEvent{Identifier: "issue", Topics: [][]byte{{}, {}, {}, {}, {0x01, 0x00, 0x00, 0x00, 0x00}}})
+		require.ErrorContains(t, err, "bad number of decimals: 4294967296")
+	})
+}
+
+func TestParseTokenRolesEvent(t *testing.T) {
+	parsed, err := ParseTokenRolesEvent(&TransactionEvent{
+		Address:    "erd1alice",
+		Identifier: "ESDTSetRole",
+		Topics:     [][]byte{[]byte("TST-123456"), {}, {}, []byte("ESDTRoleLocalMint"), []byte("ESDTRoleLocalBurn")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &TokenRolesEvent{
+		TokenIdentifier: "TST-123456",
+		Roles:           []string{"ESDTRoleLocalMint", "ESDTRoleLocalBurn"},
+		Address:         "erd1alice",
+	}, parsed)
+
+	parsed, err = ParseTokenRolesEvent(&TransactionEvent{Identifier: "ESDTUnSetRole", Topics: [][]byte{[]byte("TST-123456"), {}, {}}})
+	require.NoError(t, err)
+	require.True(t, parsed.IsUnset)
+	require.Empty(t, parsed.Roles)
+
+	_, err = ParseTokenRolesEvent(&TransactionEvent{Identifier: "issue"})
+	require.ErrorContains(t, err, "not a token roles event: issue")
+
+	_, err = ParseTokenRolesEvent(&TransactionEvent{Identifier: "ESDTSetRole", Topics: [][]byte{[]byte("TST-123456")}})
+	require.ErrorContains(t, err, "bad token roles event: expected at least 3 topics, got 1")
+}
+
+func TestFindTokenIssuedEvent(t *testing.T) {
+	transaction := &TransactionOnNetwork{
+		SmartContractResults: []*SmartContractResultOnNetwork{
+			{Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "completedTxEvent"},
+				{Identifier: "issueSemiFungible", Topics: [][]byte{[]byte("SFT-123456"), []byte("TEST"), []byte("SFT"), []byte("SemiFungibleESDT")}},
+			}}},
+		},
+	}
+
+	parsed, err := FindTokenIssuedEvent(transaction)
+	require.NoError(t, err)
+	require.Equal(t, "SFT-123456", parsed.TokenIdentifier)
+
+	_, err = FindTokenIssuedEvent(&TransactionOnNetwork{})
+	require.ErrorContains(t, err, "token issued event not found")
+}
@@ -0,0 +1,87 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemContractsAddresses(t *testing.T) {
+	addresses := map[string]string{
+		"000000000000000000010000000000000000000000000000000000000002ffff": ESDTSystemContractAddress,
+		"000000000000000000010000000000000000000000000000000000000004ffff": DelegationManagerContractAddress,
+		"000000000000000000010000000000000000000000000000000000000001ffff": StakingContractAddress,
+		"000000000000000000010000000000000000000000000000000000000003ffff": GovernanceContractAddress,
+	}
+
+	for pubkeyHex, expectedAddress := range addresses {
+		pubkey, _ := hex.DecodeString(pubkeyHex)
+
+		address, err := encodeBech32("erd", pubkey)
+		require.NoError(t, err)
+		require.Equal(t, expectedAddress, address)
+	}
+}
+
+func TestSystemContractsAbis(t *testing.T) {
+	t.Run("should decode outputs of ESDT system contract", func(t *testing.T) {
+		parser, err := NewContractResultParser(ArgsNewContractResultParser{Abi: NewESDTSystemContractAbi()})
+		require.NoError(t, err)
+
+		// "erd1...:ESDTRoleLocalMint"
+		parsed, err := parser.ParseEndpointResult("getSpecialRoles", "@6f6b@6572643171717171713a45534454526f6c654c6f63616c4d696e74")
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "roles", Value: &VariadicValues{
+			Items: []any{&StringValue{Value: "erd1qqqqq:ESDTRoleLocalMint"}},
+		}}}, clearItemCreators(parsed.Values))
+	})
+
+	t.Run("should decode outputs of delegation manager", func(t *testing.T) {
+		parser, err := NewContractResultParser(ArgsNewContractResultParser{Abi: NewDelegationManagerContractAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseEndpointResult("createNewDelegationContract", "@6f6b@000000000000000000010000000000000000000000000000000000000004ffff")
+		require.NoError(t, err)
+
+		address, _ := hex.DecodeString("000000000000000000010000000000000000000000000000000000000004ffff")
+		require.Equal(t, []NamedValue{{Name: "contractAddress", Value: &AddressValue{Value: address}}}, parsed.Values)
+	})
+
+	t.Run("should parse call data, with variadic multi-values", func(t *testing.T) {
+		parser, err := NewCallDataParser(ArgsNewCallDataParser{Abi: NewStakingContractAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("stake@02@aa@bb@cc@dd")
+		require.NoError(t, err)
+		require.Equal(t, "numNodes", parsed.Arguments[0].Name)
+		require.Equal(t, &U32Value{Value: 2}, parsed.Arguments[0].Value)
+
+		nodes := parsed.Arguments[1].Value.(*VariadicValues)
+		require.Len(t, nodes.Items, 2)
+		require.Equal(t, []any{&BytesValue{Value: []byte{0xcc}}, &BytesValue{Value: []byte{0xdd}}}, nodes.Items[1].(*MultiValue).Items)
+	})
+
+	t.Run("should validate arguments", func(t *testing.T) {
+		builder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{Abi: NewGovernanceContractAbi()})
+		require.NoError(t, err)
+
+		data, err := builder.Build("closeProposal", []any{&U64Value{Value: 3}})
+		require.NoError(t, err)
+		require.Equal(t, "closeProposal@03", data)
+
+		_, err = builder.Build("closeProposal", []any{&BigUIntValue{Value: big.NewInt(3)}})
+		require.ErrorContains(t, err, "bad arguments for endpoint 'closeProposal'")
+	})
+}
+
+func clearItemCreators(values []NamedValue) []NamedValue {
+	for _, value := range values {
+		if variadic, ok := value.Value.(*VariadicValues); ok {
+			variadic.ItemCreator = nil
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,98 @@
+package abi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TransactionOnNetwork is a (processed) transaction, as returned by the API or the proxy (with its results)
+type TransactionOnNetwork struct {
+	Hash     string `json:"hash"`
+	Nonce    uint64 `json:"nonce"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	// Data is base64-encoded in the JSON
+	Data                 []byte                          `json:"data"`
+	Status               string                          `json:"status"`
+	Function             string                          `json:"function"`
+	SmartContractResults []*SmartContractResultOnNetwork `json:"smartContractResults"`
+	Logs                 *TransactionLogsOnNetwork       `json:"logs"`
+}
+
+// SmartContractResultOnNetwork is a smart contract result (SCR), as returned by the API or the proxy
+type SmartContractResultOnNetwork struct {
+	Hash     string `json:"hash"`
+	Nonce    uint64 `json:"nonce"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	// Data is not encoded in the JSON (e.g. "@6f6b@2a")
+	Data           string                    `json:"data"`
+	CallType       CallType                  `json:"callType"`
+	PrevTxHash     string                    `json:"prevTxHash"`
+	OriginalTxHash string                    `json:"originalTxHash"`
+	ReturnMessage  string                    `json:"returnMessage"`
+	Logs           *TransactionLogsOnNetwork `json:"logs"`
+}
+
+// TransactionLogsOnNetwork holds the events emitted while processing a transaction (or a smart contract result)
+type TransactionLogsOnNetwork struct {
+	Address string              `json:"address"`
+	Events  []*TransactionEvent `json:"events"`
+}
+
+// TransactionEvent is an event (log entry), as returned by the API or the proxy.
+// Topics, data and additional data are base64-encoded in the JSON.
+type TransactionEvent struct {
+	Address        string   `json:"address"`
+	Identifier     string   `json:"identifier"`
+	Topics         [][]byte `json:"topics"`
+	Data           []byte   `json:"data"`
+	AdditionalData [][]byte `json:"additionalData"`
+}
+
+// ParseTransactionOnNetwork parses the JSON of a transaction, either bare or wrapped in a response of the API or the proxy:
+// {"data": {"transaction": {...}}}.
+func ParseTransactionOnNetwork(data []byte) (*TransactionOnNetwork, error) {
+	var response struct {
+		Data struct {
+			Transaction *TransactionOnNetwork `json:"transaction"`
+		} `json:"data"`
+	}
+
+	// The "data" field of a bare transaction is a string (not an object), thus only responses are unmarshalled here.
+	err := json.Unmarshal(data, &response)
+	if err == nil && response.Data.Transaction != nil {
+		return response.Data.Transaction, nil
+	}
+
+	transaction := &TransactionOnNetwork{}
+
+	err = json.Unmarshal(data, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse transaction, because of: %w", err)
+	}
+
+	if transaction.Hash == "" {
+		return nil, errors.New("cannot parse transaction, because of: missing hash")
+	}
+
+	return transaction, nil
+}
+
+// getAllEvents returns the events of the transaction, followed by the events of its smart contract results
+func (transaction *TransactionOnNetwork) getAllEvents() []*TransactionEvent {
+	events := make([]*TransactionEvent, 0)
+
+	if transaction.Logs != nil {
+		events = append(events, transaction.Logs.Events...)
+	}
+
+	for _, result := range transaction.SmartContractResults {
+		if result.Logs != nil {
+			events = append(events, result.Logs.Events...)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,64 @@
+package abi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransactionOnNetwork(t *testing.T) {
+	t.Run("should parse response (of API or proxy)", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/txGetSumSuccess.json")
+		require.NoError(t, err)
+
+		transaction, err := ParseTransactionOnNetwork(data)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), transaction.Nonce)
+		require.Equal(t, []byte("getSum"), transaction.Data)
+		require.Len(t, transaction.SmartContractResults, 2)
+		require.Equal(t, "@6f6b@2a", transaction.SmartContractResults[1].Data)
+		require.Len(t, transaction.Logs.Events, 3)
+		require.Equal(t, [][]byte{[]byte("distributed"), []byte("WEGLD-d7c6bb")}, transaction.Logs.Events[0].Topics)
+		require.Nil(t, transaction.Logs.Events[2].Data)
+	})
+
+	t.Run("should parse bare transaction", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/txAddSignalError.json")
+		require.NoError(t, err)
+
+		transaction, err := ParseTransactionOnNetwork(data)
+		require.NoError(t, err)
+		require.Equal(t, "5b2c", transaction.Hash)
+		require.Equal(t, []byte("add@07"), transaction.Data)
+		require.Len(t, transaction.getAllEvents(), 1)
+	})
+
+	t.Run("should err on bad JSON", func(t *testing.T) {
+		_, err := ParseTransactionOnNetwork([]byte("{"))
+		require.ErrorContains(t, err, "cannot parse transaction")
+
+		_, err = ParseTransactionOnNetwork([]byte(`{"data": {"foo": "bar"}}`))
+		require.ErrorContains(t, err, "cannot parse transaction")
+
+		_, err = ParseTransactionOnNetwork([]byte(`{"nonce": 42}`))
+		require.ErrorContains(t, err, "cannot parse transaction, because of: missing hash")
+	})
+}
+
+func TestTransactionOnNetwork_getAllEvents(t *testing.T) {
+	transaction := &TransactionOnNetwork{
+		Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{{Identifier: "a"}}},
+		SmartContractResults: []*SmartContractResultOnNetwork{
+			{},
+			{Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{{Identifier: "b"}, {Identifier: "c"}}}},
+		},
+	}
+
+	events := transaction.getAllEvents()
+	require.Len(t, events, 3)
+	require.Equal(t, "a", events[0].Identifier)
+	require.Equal(t, "c", events[2].Identifier)
+
+	require.Empty(t, (&TransactionOnNetwork{}).getAllEvents())
+}
@@ -0,0 +1,216 @@
+package abi
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	writeLogEventIdentifier         = "writeLog"
+	signalErrorEventIdentifier      = "signalError"
+	internalVMErrorsEventIdentifier = "internalVMErrors"
+	completedTxEventIdentifier      = "completedTxEvent"
+)
+
+// TransactionOutcome is the outcome of a contract call transaction, decoded according to the ABI
+type TransactionOutcome struct {
+	// FunctionName is the name of the called endpoint (empty if none, e.g. for deployments)
+	FunctionName   string
+	ReturnCode     ReturnCode
+	ReturnCodeText string
+	ReturnMessage  string
+	// RawValues holds the returned (raw) values
+	RawValues [][]byte
+	// Values holds the decoded outputs of the endpoint (only on success)
+	Values []NamedValue
+	// Events holds the decoded events (only the ones defined by the ABI)
+	Events []*ParsedEvent
+	// IsCompleted is set if the transaction was marked as completed (by a "completedTxEvent")
+	IsCompleted bool
+}
+
+// ArgsNewTransactionOutcomeParser defines the arguments needed for a new transaction outcome parser
+type ArgsNewTransactionOutcomeParser struct {
+	Abi *Definition
+}
+
+// transactionOutcomeParser extracts the outcome of a contract call from a transaction on the network:
+// the return code and the returned values are held by a smart contract result (or by a "writeLog" / "signalError" / "internalVMErrors" event),
+// while the events emitted by the contract are held by the logs (of the transaction itself, or of its smart contract results).
+type transactionOutcomeParser struct {
+	contractResultParser *contractResultParser
+	transferDataParser   *transferDataParser
+	eventParser          *eventParser
+}
+
+// NewTransactionOutcomeParser creates a new transaction outcome parser
+func NewTransactionOutcomeParser(args ArgsNewTransactionOutcomeParser) (*transactionOutcomeParser, error) {
+	eventParser, err := NewEventParser(ArgsNewEventParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractResultParser, err := NewContractResultParser(ArgsNewContractResultParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transferDataParser, err := NewTransferDataParser(ArgsNewTransferDataParser{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionOutcomeParser{
+		contractResultParser: contractResultParser,
+		transferDataParser:   transferDataParser,
+		eventParser:          eventParser,
+	}, nil
+}
+
+// ParseJSON parses the JSON of a transaction (see ParseTransactionOnNetwork), then extracts its outcome
+func (parser *transactionOutcomeParser) ParseJSON(data []byte) (*TransactionOutcome, error) {
+	transaction, err := ParseTransactionOnNetwork(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.Parse(transaction)
+}
+
+// Parse extracts the outcome of the given transaction
+func (parser *transactionOutcomeParser) Parse(transaction *TransactionOnNetwork) (*TransactionOutcome, error) {
+	result, err := parser.findResult(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := &TransactionOutcome{
+		FunctionName:   parser.getFunctionName(transaction),
+		ReturnCode:     result.ReturnCode,
+		ReturnCodeText: result.ReturnCodeText,
+		ReturnMessage:  result.ReturnMessage,
+		RawValues:      result.RawValues,
+		Events:         make([]*ParsedEvent, 0),
+	}
+
+	if outcome.ReturnCode == ReturnCodeOk && outcome.FunctionName != "" {
+		outcome.Values, err = parser.contractResultParser.decodeOutputs(outcome.FunctionName, outcome.RawValues)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, event := range transaction.getAllEvents() {
+		if event.Identifier == completedTxEventIdentifier {
+			outcome.IsCompleted = true
+		}
+
+		if !parser.eventParser.CanParse(event) {
+			continue
+		}
+
+		parsedEvent, err := parser.eventParser.Parse(event)
+		if err != nil {
+			return nil, err
+		}
+
+		outcome.Events = append(outcome.Events, parsedEvent)
+	}
+
+	return outcome, nil
+}
+
+// findResult looks for the result of the call: first, among the smart contract results (the one sent back to the caller),
+// then, among the events ("signalError", "writeLog", "internalVMErrors", in this order).
+func (parser *transactionOutcomeParser) findResult(transaction *TransactionOnNetwork) (*ParsedContractResult, error) {
+	for _, result := range transaction.SmartContractResults {
+		if result.Receiver == transaction.Sender && strings.HasPrefix(result.Data, callDataPartsSeparator) {
+			return parser.contractResultParser.Parse(result.Data)
+		}
+	}
+
+	events := transaction.getAllEvents()
+
+	event := findEventByIdentifier(events, signalErrorEventIdentifier)
+	if event != nil {
+		return parser.parseSignalError(event), nil
+	}
+
+	event = findEventByIdentifier(events, writeLogEventIdentifier)
+	if event != nil {
+		return parser.contractResultParser.Parse(string(event.Data))
+	}
+
+	event = findEventByIdentifier(events, internalVMErrorsEventIdentifier)
+	if event != nil {
+		return &ParsedContractResult{
+			ReturnCode:     ReturnCodeExecutionFailed,
+			ReturnCodeText: ReturnCodeExecutionFailed.String(),
+			ReturnMessage:  string(event.Data),
+			RawValues:      [][]byte{},
+		}, nil
+	}
+
+	return nil, errors.New("cannot find the outcome of the transaction (neither in smart contract results, nor in logs)")
+}
+
+// parseSignalError parses the data of a "signalError" event ("@returnCode@message").
+// If the data is missing (or malformed), the message is taken from the second topic.
+func (parser *transactionOutcomeParser) parseSignalError(event *TransactionEvent) *ParsedContractResult {
+	result, err := parser.contractResultParser.Parse(string(event.Data))
+	if err == nil && result.ReturnMessage != "" {
+		return result
+	}
+
+	message := ""
+	if len(event.Topics) > 1 {
+		message = string(event.Topics[1])
+	}
+
+	return &ParsedContractResult{
+		ReturnCode:     ReturnCodeUserError,
+		ReturnCodeText: ReturnCodeUserError.String(),
+		ReturnMessage:  message,
+		RawValues:      [][]byte{},
+	}
+}
+
+// getFunctionName returns the name of the called function (for token transfers, the name of the inner call).
+// It's extracted from the data field, falling back to the "function" field (set by the API) if the data is missing.
+func (parser *transactionOutcomeParser) getFunctionName(transaction *TransactionOnNetwork) string {
+	if len(transaction.Data) == 0 {
+		return transaction.Function
+	}
+
+	data := string(transaction.Data)
+
+	parsedTransfer, err := parser.transferDataParser.Parse(data)
+	if err == nil {
+		if parsedTransfer.Call == nil {
+			return ""
+		}
+
+		return parsedTransfer.Call.FunctionName
+	}
+
+	functionName, _, _ := strings.Cut(data, callDataPartsSeparator)
+	if checkFunctionName(functionName) != nil {
+		return ""
+	}
+
+	return functionName
+}
+
+func findEventByIdentifier(events []*TransactionEvent, identifier string) *TransactionEvent {
+	for _, event := range events {
+		if event.Identifier == identifier {
+			return event
+		}
+	}
+
+	return nil
+}
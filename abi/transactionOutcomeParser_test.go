@@ -0,0 +1,122 @@
+package abi
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionOutcomeParser(t *testing.T) {
+	parser, err := NewTransactionOutcomeParser(ArgsNewTransactionOutcomeParser{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("on success (outcome in smart contract result)", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/txGetSumSuccess.json")
+		require.NoError(t, err)
+
+		outcome, err := parser.ParseJSON(data)
+		require.NoError(t, err)
+		require.Equal(t, "getSum", outcome.FunctionName)
+		require.Equal(t, ReturnCodeOk, outcome.ReturnCode)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, outcome.Values)
+		require.True(t, outcome.IsCompleted)
+		require.Len(t, outcome.Events, 1)
+		require.Equal(t, "distributed", outcome.Events[0].Identifier)
+		require.Equal(t, []NamedValue{
+			{Name: "token", Value: &StringValue{Value: "WEGLD-d7c6bb"}},
+			{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(42)}},
+		}, outcome.Events[0].Values)
+	})
+
+	t.Run("on failure (outcome in signalError)", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/txAddSignalError.json")
+		require.NoError(t, err)
+
+		outcome, err := parser.ParseJSON(data)
+		require.NoError(t, err)
+		require.Equal(t, "add", outcome.FunctionName)
+		require.Equal(t, ReturnCodeUserError, outcome.ReturnCode)
+		require.Equal(t, "not enough funds", outcome.ReturnMessage)
+		require.Nil(t, outcome.Values)
+		require.Empty(t, outcome.Events)
+		require.False(t, outcome.IsCompleted)
+	})
+
+	t.Run("on failure (signalError without data)", func(t *testing.T) {
+		outcome, err := parser.Parse(&TransactionOnNetwork{
+			Data: []byte("add@07"),
+			Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "signalError", Topics: [][]byte{{0x01}, []byte("something failed")}},
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeUserError, outcome.ReturnCode)
+		require.Equal(t, "something failed", outcome.ReturnMessage)
+	})
+
+	t.Run("on success (outcome in writeLog)", func(t *testing.T) {
+		outcome, err := parser.Parse(&TransactionOnNetwork{
+			Data: []byte("getSum"),
+			Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "writeLog", Topics: [][]byte{{0x01}}, Data: []byte("@6f6b@2b")},
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(43)}}}, outcome.Values)
+	})
+
+	t.Run("on failure (outcome in internalVMErrors)", func(t *testing.T) {
+		outcome, err := parser.Parse(&TransactionOnNetwork{
+			Data: []byte("add@07"),
+			Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "internalVMErrors", Data: []byte("\n\truntime.go:856 [execution failed]")},
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeExecutionFailed, outcome.ReturnCode)
+		require.Contains(t, outcome.ReturnMessage, "execution failed")
+	})
+
+	t.Run("with token transfer and inner call", func(t *testing.T) {
+		outcome, err := parser.Parse(&TransactionOnNetwork{
+			Sender:               "erd1alice",
+			Data:                 []byte("ESDTTransfer@5745474c442d643763366262@0de0b6b3a7640000@67657453756d"),
+			SmartContractResults: []*SmartContractResultOnNetwork{{Receiver: "erd1alice", Data: "@6f6b@2a"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "getSum", outcome.FunctionName)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, outcome.Values)
+	})
+
+	t.Run("without data, with function", func(t *testing.T) {
+		outcome, err := parser.ParseJSON([]byte(`{"hash": "aa", "sender": "erd1alice", "function": "getSum", "smartContractResults": [{"receiver": "erd1alice", "data": "@6f6b@2a"}]}`))
+		require.NoError(t, err)
+		require.Equal(t, "getSum", outcome.FunctionName)
+		require.Len(t, outcome.Values, 1)
+	})
+
+	t.Run("without outcome", func(t *testing.T) {
+		_, err := parser.Parse(&TransactionOnNetwork{Data: []byte("add@07")})
+		require.ErrorContains(t, err, "cannot find the outcome of the transaction")
+	})
+
+	t.Run("with bad outputs", func(t *testing.T) {
+		_, err := parser.Parse(&TransactionOnNetwork{
+			Data: []byte("missing@01"),
+			Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "writeLog", Data: []byte("@6f6b@2a")},
+			}},
+		})
+		require.ErrorContains(t, err, "endpoint not found")
+
+		_, err = parser.Parse(&TransactionOnNetwork{
+			Data: []byte("getSum"),
+			Logs: &TransactionLogsOnNetwork{Events: []*TransactionEvent{
+				{Identifier: "writeLog", Data: []byte("@6f6b@2a@2b")},
+			}},
+		})
+		require.ErrorContains(t, err, "cannot decode outputs of endpoint 'getSum': too many parts")
+	})
+}
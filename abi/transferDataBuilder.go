@@ -0,0 +1,154 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// ESDTTransferFunctionName is the name of the built-in function for transferring fungible tokens
+	ESDTTransferFunctionName = "ESDTTransfer"
+	// ESDTNFTTransferFunctionName is the name of the built-in function for transferring (semi-)non-fungible tokens
+	ESDTNFTTransferFunctionName = "ESDTNFTTransfer"
+	// MultiESDTNFTTransferFunctionName is the name of the built-in function for transferring many tokens at once
+	MultiESDTNFTTransferFunctionName = "MultiESDTNFTTransfer"
+)
+
+// TokenTransfer is a transfer of (fungible or non-fungible) tokens. The nonce of fungible tokens is zero.
+type TokenTransfer struct {
+	TokenIdentifier string
+	Nonce           uint64
+	Amount          *big.Int
+}
+
+// ArgsNewTransferDataBuilder defines the arguments needed for a new transfer data builder
+type ArgsNewTransferDataBuilder struct {
+	// Abi is optional: if set, the inner call (if any) is validated against the endpoints of the ABI
+	Abi *Definition
+}
+
+// transferDataBuilder builds the data field of token transfer transactions, which might also call a contract
+// (the inner call: function name and arguments, following the transfers).
+type transferDataBuilder struct {
+	callDataBuilder *callDataBuilder
+}
+
+// NewTransferDataBuilder creates a new transfer data builder
+func NewTransferDataBuilder(args ArgsNewTransferDataBuilder) (*transferDataBuilder, error) {
+	callDataBuilder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transferDataBuilder{
+		callDataBuilder: callDataBuilder,
+	}, nil
+}
+
+// BuildESDTTransfer builds "ESDTTransfer@token@amount[@function@args...]". The receiver is the one of the transaction.
+// If the function name is empty, there's no inner call (and no arguments are allowed).
+func (builder *transferDataBuilder) BuildESDTTransfer(transfer TokenTransfer, functionName string, args []any) (string, error) {
+	err := checkFungibleTransfer(transfer)
+	if err != nil {
+		return "", err
+	}
+
+	values := []any{
+		&StringValue{Value: transfer.TokenIdentifier},
+		&BigUIntValue{Value: transfer.Amount},
+	}
+
+	return builder.build(ESDTTransferFunctionName, values, functionName, args)
+}
+
+// BuildESDTNFTTransfer builds "ESDTNFTTransfer@token@nonce@quantity@receiver[@function@args...]".
+// The receiver of the transaction must be the sender itself.
+func (builder *transferDataBuilder) BuildESDTNFTTransfer(transfer TokenTransfer, receiver []byte, functionName string, args []any) (string, error) {
+	err := checkTransfer(transfer)
+	if err != nil {
+		return "", err
+	}
+
+	values := []any{
+		&StringValue{Value: transfer.TokenIdentifier},
+		&U64Value{Value: transfer.Nonce},
+		&BigUIntValue{Value: transfer.Amount},
+		&AddressValue{Value: receiver},
+	}
+
+	return builder.build(ESDTNFTTransferFunctionName, values, functionName, args)
+}
+
+// BuildMultiESDTNFTTransfer builds "MultiESDTNFTTransfer@receiver@numTransfers@(token@nonce@amount)*[@function@args...]".
+// The receiver of the transaction must be the sender itself.
+func (builder *transferDataBuilder) BuildMultiESDTNFTTransfer(transfers []TokenTransfer, receiver []byte, functionName string, args []any) (string, error) {
+	if len(transfers) == 0 {
+		return "", errors.New("no transfers")
+	}
+
+	values := []any{
+		&AddressValue{Value: receiver},
+		&U32Value{Value: uint32(len(transfers))},
+	}
+
+	for i, transfer := range transfers {
+		err := checkTransfer(transfer)
+		if err != nil {
+			return "", fmt.Errorf("bad transfer %d: %w", i, err)
+		}
+
+		values = append(values,
+			&StringValue{Value: transfer.TokenIdentifier},
+			&U64Value{Value: transfer.Nonce},
+			&BigUIntValue{Value: transfer.Amount},
+		)
+	}
+
+	return builder.build(MultiESDTNFTTransferFunctionName, values, functionName, args)
+}
+
+func (builder *transferDataBuilder) build(transferFunctionName string, transferValues []any, functionName string, args []any) (string, error) {
+	values := transferValues
+
+	if functionName != "" {
+		err := builder.callDataBuilder.checkCall(functionName, args)
+		if err != nil {
+			return "", err
+		}
+
+		values = append(values, &StringValue{Value: functionName})
+		values = append(values, args...)
+	} else if len(args) > 0 {
+		return "", errors.New("arguments are not allowed without a function name")
+	}
+
+	return builder.callDataBuilder.buildWithPrefix(transferFunctionName, values)
+}
+
+func checkTransfer(transfer TokenTransfer) error {
+	if transfer.TokenIdentifier == "" {
+		return errors.New("token identifier must not be empty")
+	}
+
+	if transfer.Amount == nil || transfer.Amount.Sign() < 0 {
+		return errors.New("amount must be set, and non-negative")
+	}
+
+	return nil
+}
+
+func checkFungibleTransfer(transfer TokenTransfer) error {
+	err := checkTransfer(transfer)
+	if err != nil {
+		return err
+	}
+
+	if transfer.Nonce != 0 {
+		return fmt.Errorf("nonce must be zero for %s", ESDTTransferFunctionName)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferDataBuilder(t *testing.T) {
+	builder, err := NewTransferDataBuilder(ArgsNewTransferDataBuilder{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	oneEgld := big.NewInt(1_000_000_000_000_000_000)
+
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		data, err := builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld}, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "ESDTTransfer@5745474c442d643763366262@0de0b6b3a7640000", data)
+
+		data, err = builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld}, "add", []any{&BigUIntValue{Value: big.NewInt(7)}})
+		require.NoError(t, err)
+		require.Equal(t, "ESDTTransfer@5745474c442d643763366262@0de0b6b3a7640000@616464@07", data)
+	})
+
+	t.Run("ESDTNFTTransfer", func(t *testing.T) {
+		data, err := builder.BuildESDTNFTTransfer(TokenTransfer{TokenIdentifier: "NFT-123456", Nonce: 42, Amount: big.NewInt(1)}, alice, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "ESDTNFTTransfer@4e46542d313233343536@2a@01@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1", data)
+	})
+
+	t.Run("MultiESDTNFTTransfer", func(t *testing.T) {
+		transfers := []TokenTransfer{
+			{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld},
+			{TokenIdentifier: "NFT-123456", Nonce: 42, Amount: big.NewInt(1)},
+		}
+
+		data, err := builder.BuildMultiESDTNFTTransfer(transfers, alice, "add", []any{&BigUIntValue{Value: big.NewInt(7)}})
+		require.NoError(t, err)
+		require.Equal(t, "MultiESDTNFTTransfer@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1@02"+
+			"@5745474c442d643763366262@@0de0b6b3a7640000"+
+			"@4e46542d313233343536@2a@01"+
+			"@616464@07", data)
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		_, err := builder.BuildESDTTransfer(TokenTransfer{Amount: oneEgld}, "", nil)
+		require.ErrorContains(t, err, "token identifier must not be empty")
+
+		_, err = builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb"}, "", nil)
+		require.ErrorContains(t, err, "amount must be set, and non-negative")
+
+		_, err = builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb", Nonce: 1, Amount: oneEgld}, "", nil)
+		require.ErrorContains(t, err, "nonce must be zero for ESDTTransfer")
+
+		_, err = builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld}, "", []any{&U8Value{}})
+		require.ErrorContains(t, err, "arguments are not allowed without a function name")
+
+		_, err = builder.BuildESDTTransfer(TokenTransfer{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld}, "add", []any{&U8Value{}})
+		require.ErrorContains(t, err, "bad arguments for endpoint 'add'")
+
+		_, err = builder.BuildMultiESDTNFTTransfer(nil, alice, "", nil)
+		require.ErrorContains(t, err, "no transfers")
+
+		_, err = builder.BuildMultiESDTNFTTransfer([]TokenTransfer{{TokenIdentifier: "A-123456"}}, alice, "", nil)
+		require.ErrorContains(t, err, "bad transfer 0: amount must be set, and non-negative")
+	})
+}
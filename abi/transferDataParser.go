@@ -0,0 +1,164 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// ParsedTransferData is the outcome of parsing the data field of a token transfer transaction
+type ParsedTransferData struct {
+	// TransferFunctionName is one of: ESDTTransfer, ESDTNFTTransfer, MultiESDTNFTTransfer
+	TransferFunctionName string
+	Transfers            []TokenTransfer
+	// Receiver is the (actual) receiver of the transfers, for ESDTNFTTransfer and MultiESDTNFTTransfer (nil for ESDTTransfer)
+	Receiver []byte
+	// Call is the inner call (nil if none)
+	Call *ParsedCallData
+}
+
+// ArgsNewTransferDataParser defines the arguments needed for a new transfer data parser
+type ArgsNewTransferDataParser struct {
+	// Abi is optional: if set, the arguments of the inner call (if any) are decoded according to the endpoints of the ABI
+	Abi *Definition
+}
+
+// transferDataParser parses the data field of token transfer transactions (see transferDataBuilder)
+type transferDataParser struct {
+	callDataParser *callDataParser
+}
+
+// NewTransferDataParser creates a new transfer data parser
+func NewTransferDataParser(args ArgsNewTransferDataParser) (*transferDataParser, error) {
+	callDataParser, err := NewCallDataParser(ArgsNewCallDataParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transferDataParser{
+		callDataParser: callDataParser,
+	}, nil
+}
+
+// Parse parses the given data field, which must be the one of a token transfer (possibly with an inner call)
+func (parser *transferDataParser) Parse(data string) (*ParsedTransferData, error) {
+	functionName, parts, err := parser.callDataParser.splitCallData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed *ParsedTransferData
+	var remainingParts [][]byte
+
+	switch functionName {
+	case ESDTTransferFunctionName:
+		parsed, remainingParts, err = parser.parseESDTTransfer(parts)
+	case ESDTNFTTransferFunctionName:
+		parsed, remainingParts, err = parser.parseESDTNFTTransfer(parts)
+	case MultiESDTNFTTransferFunctionName:
+		parsed, remainingParts, err = parser.parseMultiESDTNFTTransfer(parts)
+	default:
+		return nil, fmt.Errorf("not a token transfer: %s", functionName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s, because of: %w", functionName, err)
+	}
+
+	parsed.TransferFunctionName = functionName
+
+	if len(remainingParts) > 0 {
+		parsed.Call, err = parser.parseInnerCall(remainingParts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parsed, nil
+}
+
+func (parser *transferDataParser) parseESDTTransfer(parts [][]byte) (*ParsedTransferData, [][]byte, error) {
+	token := &StringValue{}
+	amount := &BigUIntValue{}
+
+	remainingParts, err := parser.decodePrefix(parts, token, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ParsedTransferData{
+		Transfers: []TokenTransfer{{TokenIdentifier: token.Value, Amount: amount.Value}},
+	}, remainingParts, nil
+}
+
+func (parser *transferDataParser) parseESDTNFTTransfer(parts [][]byte) (*ParsedTransferData, [][]byte, error) {
+	token := &StringValue{}
+	nonce := &U64Value{}
+	amount := &BigUIntValue{}
+	receiver := &AddressValue{}
+
+	remainingParts, err := parser.decodePrefix(parts, token, nonce, amount, receiver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ParsedTransferData{
+		Transfers: []TokenTransfer{{TokenIdentifier: token.Value, Nonce: nonce.Value, Amount: amount.Value}},
+		Receiver:  receiver.Value,
+	}, remainingParts, nil
+}
+
+func (parser *transferDataParser) parseMultiESDTNFTTransfer(parts [][]byte) (*ParsedTransferData, [][]byte, error) {
+	receiver := &AddressValue{}
+	numTransfers := &U32Value{}
+
+	remainingParts, err := parser.decodePrefix(parts, receiver, numTransfers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transfers := make([]TokenTransfer, 0, len(remainingParts)/3)
+
+	for i := uint32(0); i < numTransfers.Value; i++ {
+		token := &StringValue{}
+		nonce := &U64Value{}
+		amount := &BigUIntValue{}
+
+		remainingParts, err = parser.decodePrefix(remainingParts, token, nonce, amount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bad transfer %d: %w", i, err)
+		}
+
+		transfers = append(transfers, TokenTransfer{TokenIdentifier: token.Value, Nonce: nonce.Value, Amount: amount.Value})
+	}
+
+	return &ParsedTransferData{
+		Transfers: transfers,
+		Receiver:  receiver.Value,
+	}, remainingParts, nil
+}
+
+// decodePrefix decodes the first parts into the given values, returning the remaining parts
+func (parser *transferDataParser) decodePrefix(parts [][]byte, values ...any) ([][]byte, error) {
+	if len(parts) < len(values) {
+		return nil, fmt.Errorf("not enough parts: expected at least %d, got %d", len(values), len(parts))
+	}
+
+	err := parser.callDataParser.serializer.DeserializeParts(parts[:len(values)], values)
+	if err != nil {
+		return nil, err
+	}
+
+	return parts[len(values):], nil
+}
+
+// parseInnerCall parses the parts following the transfers: the function name, then the arguments
+func (parser *transferDataParser) parseInnerCall(parts [][]byte) (*ParsedCallData, error) {
+	functionName := string(parts[0])
+
+	err := checkFunctionName(functionName)
+	if err != nil {
+		return nil, fmt.Errorf("bad inner call: %w", err)
+	}
+
+	return parser.callDataParser.parseCall(functionName, parts[1:])
+}
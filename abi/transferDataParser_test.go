@@ -0,0 +1,98 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferDataParser_Parse(t *testing.T) {
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	oneEgld := big.NewInt(1_000_000_000_000_000_000)
+
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		parser, err := NewTransferDataParser(ArgsNewTransferDataParser{})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("ESDTTransfer@5745474c442d643763366262@0de0b6b3a7640000")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedTransferData{
+			TransferFunctionName: "ESDTTransfer",
+			Transfers:            []TokenTransfer{{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld}},
+		}, parsed)
+
+		parsed, err = parser.Parse("ESDTTransfer@5745474c442d643763366262@0de0b6b3a7640000@616464@07")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedCallData{FunctionName: "add", RawArguments: [][]byte{{0x07}}}, parsed.Call)
+	})
+
+	t.Run("ESDTNFTTransfer", func(t *testing.T) {
+		parser, err := NewTransferDataParser(ArgsNewTransferDataParser{})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("ESDTNFTTransfer@4e46542d313233343536@2a@01@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+		require.NoError(t, err)
+		require.Equal(t, &ParsedTransferData{
+			TransferFunctionName: "ESDTNFTTransfer",
+			Transfers:            []TokenTransfer{{TokenIdentifier: "NFT-123456", Nonce: 42, Amount: big.NewInt(1)}},
+			Receiver:             alice,
+		}, parsed)
+	})
+
+	t.Run("MultiESDTNFTTransfer, with ABI", func(t *testing.T) {
+		parser, err := NewTransferDataParser(ArgsNewTransferDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse("MultiESDTNFTTransfer@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1@02" +
+			"@5745474c442d643763366262@@0de0b6b3a7640000" +
+			"@4e46542d313233343536@2a@01" +
+			"@616464@07")
+		require.NoError(t, err)
+		require.Equal(t, "MultiESDTNFTTransfer", parsed.TransferFunctionName)
+		require.Equal(t, alice, parsed.Receiver)
+		require.Equal(t, []TokenTransfer{
+			{TokenIdentifier: "WEGLD-d7c6bb", Amount: oneEgld},
+			{TokenIdentifier: "NFT-123456", Nonce: 42, Amount: big.NewInt(1)},
+		}, parsed.Transfers)
+		require.Equal(t, "add", parsed.Call.FunctionName)
+		require.Equal(t, []NamedValue{{Name: "value", Value: &BigUIntValue{Value: big.NewInt(7)}}}, parsed.Call.Arguments)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		builder, err := NewTransferDataBuilder(ArgsNewTransferDataBuilder{})
+		require.NoError(t, err)
+		parser, err := NewTransferDataParser(ArgsNewTransferDataParser{})
+		require.NoError(t, err)
+
+		transfers := []TokenTransfer{{TokenIdentifier: "A-123456", Nonce: 1, Amount: big.NewInt(0)}}
+		data, err := builder.BuildMultiESDTNFTTransfer(transfers, alice, "", nil)
+		require.NoError(t, err)
+
+		parsed, err := parser.Parse(data)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0).Cmp(parsed.Transfers[0].Amount), 0)
+		require.Nil(t, parsed.Call)
+	})
+
+	t.Run("with bad data", func(t *testing.T) {
+		parser, err := NewTransferDataParser(ArgsNewTransferDataParser{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = parser.Parse("add@07")
+		require.ErrorContains(t, err, "not a token transfer: add")
+
+		_, err = parser.Parse("ESDTTransfer@5745474c442d643763366262")
+		require.ErrorContains(t, err, "cannot parse ESDTTransfer, because of: not enough parts: expected at least 2, got 1")
+
+		_, err = parser.Parse("MultiESDTNFTTransfer@0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1@02@5745474c442d643763366262@@01")
+		require.ErrorContains(t, err, "cannot parse MultiESDTNFTTransfer, because of: bad transfer 1: not enough parts")
+
+		_, err = parser.Parse("ESDTTransfer@5745474c442d643763366262@01@20")
+		require.ErrorContains(t, err, "bad inner call: invalid function name")
+
+		_, err = parser.Parse("ESDTTransfer@5745474c442d643763366262@01@6d697373696e67")
+		require.ErrorContains(t, err, "endpoint not found: missing")
+	})
+}
@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidationProblem describes a problem found (at a given path) while validating a value tree.
+// The path follows the same conventions as the ones of ValueDifference.
+type ValidationProblem struct {
+	Path    string
+	Message string
+}
+
+// String returns a human-readable description of the problem
+func (problem ValidationProblem) String() string {
+	return fmt.Sprintf("%s: %s", problem.Path, problem.Message)
+}
+
+// ValidateValue walks the given value tree (single value or multi-value) and returns all the problems found,
+// such as nil values, negative unsigned big integers, addresses of invalid length etc.
+// When a declared type is available (the fields provider of an enum, the item creator of a list or of variadic values),
+// the actual values are checked against it.
+func ValidateValue(value any) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+	validateValue(&problems, "$", value)
+	return problems
+}
+
+// ValidateValues is like ValidateValue, but for a sequence of values (e.g. input values of a contract call)
+func ValidateValues(values []any) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+
+	for i, value := range values {
+		validateValue(&problems, fmt.Sprintf("$[%d]", i), value)
+	}
+
+	return problems
+}
+
+func validateValue(problems *[]ValidationProblem, path string, value any) {
+	switch value := value.(type) {
+	case nil:
+		addProblem(problems, path, "value is nil")
+	case *OptionalValue:
+		if value.Value != nil {
+			validateValue(problems, path+".value", value.Value)
+		}
+	case *MultiValue:
+		validateItems(problems, path, value.Items)
+	case *VariadicValues:
+		validateItems(problems, path, value.Items)
+
+		if value.ItemCreator != nil {
+			validateItemsTypes(problems, path, value.Items, value.ItemCreator())
+		}
+	case *BigIntValue:
+		if value.Value == nil {
+			addProblem(problems, path, "big integer is nil")
+		}
+	case *BigUIntValue:
+		if value.Value == nil {
+			addProblem(problems, path, "big integer is nil")
+		} else if value.Value.Sign() < 0 {
+			addProblem(problems, path, fmt.Sprintf("unsigned big integer is negative: %s", value.Value))
+		}
+	case *AddressValue:
+		if len(value.Value) != pubKeyLength {
+			addProblem(problems, path, fmt.Sprintf("public key (address) has invalid length: %d", len(value.Value)))
+		}
+	case *OptionValue:
+		if value.Value != nil {
+			validateValue(problems, path+".value", value.Value)
+		}
+	case *ListValue:
+		items := singleValuesAsAny(value.Items)
+		validateItems(problems, path, items)
+
+		if value.ItemCreator != nil {
+			validateItemsTypes(problems, path, items, value.ItemCreator())
+		}
+	case *StructValue:
+		validateFields(problems, path, value.Fields)
+	case *EnumValue:
+		validateFields(problems, path, value.Fields)
+
+		if value.FieldsProvider != nil {
+			validateEnumVariant(problems, path, value)
+		}
+	case genericValue:
+		validateValue(problems, path, value.toUntyped())
+	case SingleValue:
+		// Other single values (small integers, booleans, strings, byte slices) are always valid.
+	default:
+		addProblem(problems, path, fmt.Sprintf("unsupported type: %T", value))
+	}
+}
+
+func validateItems(problems *[]ValidationProblem, path string, items []any) {
+	for i, item := range items {
+		validateValue(problems, fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+func validateItemsTypes(problems *[]ValidationProblem, path string, items []any, declaredItem any) {
+	declaredType := reflect.TypeOf(declaredItem)
+
+	for i, item := range items {
+		if item != nil && reflect.TypeOf(item) != declaredType {
+			addProblem(problems, fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("unexpected type: %T (declared: %v)", item, declaredType))
+		}
+	}
+}
+
+func validateFields(problems *[]ValidationProblem, path string, fields []Field) {
+	for i, field := range fields {
+		validateValue(problems, pathOfField(path, i, field.Name), singleValueAsAny(field.Value))
+	}
+}
+
+func validateEnumVariant(problems *[]ValidationProblem, path string, value *EnumValue) {
+	declaredFields := value.FieldsProvider(value.Discriminant)
+
+	if len(declaredFields) != len(value.Fields) {
+		addProblem(problems, path, fmt.Sprintf(
+			"unexpected number of fields for variant %d: %d (declared: %d)",
+			value.Discriminant,
+			len(value.Fields),
+			len(declaredFields),
+		))
+		return
+	}
+
+	for i, declaredField := range declaredFields {
+		field := value.Fields[i]
+		fieldPath := pathOfField(path, i, field.Name)
+
+		if declaredField.Name != "" && field.Name != declaredField.Name {
+			addProblem(problems, fieldPath, fmt.Sprintf("unexpected field name: '%s' (declared: '%s')", field.Name, declaredField.Name))
+		}
+
+		if field.Value != nil && reflect.TypeOf(field.Value) != reflect.TypeOf(declaredField.Value) {
+			addProblem(problems, fieldPath, fmt.Sprintf("unexpected type: %T (declared: %T)", field.Value, declaredField.Value))
+		}
+	}
+}
+
+func addProblem(problems *[]ValidationProblem, path string, message string) {
+	*problems = append(*problems, ValidationProblem{
+		Path:    path,
+		Message: message,
+	})
+}
+
+// problemsToError converts validation problems to an error (or nil, if there are no problems)
+func problemsToError(problems []ValidationProblem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(problems))
+	for i, problem := range problems {
+		descriptions[i] = problem.String()
+	}
+
+	return fmt.Errorf("invalid values: %s", strings.Join(descriptions, "; "))
+}
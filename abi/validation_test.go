@@ -0,0 +1,114 @@
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateValue(t *testing.T) {
+	t.Run("should return no problems for valid values", func(t *testing.T) {
+		problems := ValidateValue(&MultiValue{
+			Items: []any{
+				&BigUIntValue{Value: big.NewInt(42)},
+				&BigIntValue{Value: big.NewInt(-42)},
+				&AddressValue{Value: bytes.Repeat([]byte{0x42}, 32)},
+				&OptionValue{},
+				&OptionalValue{},
+				&ListValue{Items: []SingleValue{&U8Value{}}, ItemCreator: func() SingleValue { return &U8Value{} }},
+			},
+		})
+
+		require.Empty(t, problems)
+	})
+
+	t.Run("should return all problems", func(t *testing.T) {
+		fieldsProvider := func(discriminant uint8) []Field {
+			if discriminant == 1 {
+				return []Field{{Name: "amount", Value: &BigUIntValue{}}, {Name: "nonce", Value: &U64Value{}}}
+			}
+
+			return nil
+		}
+
+		problems := ValidateValues([]any{
+			&StructValue{
+				Fields: []Field{
+					{Name: "a", Value: &BigUIntValue{}},
+					{Name: "b", Value: &BigUIntValue{Value: big.NewInt(-1)}},
+					{Name: "c", Value: nil},
+					{Value: &AddressValue{Value: []byte{0x01}}},
+				},
+			},
+			&EnumValue{
+				Discriminant:   1,
+				Fields:         []Field{{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1)}}, {Name: "nonce", Value: &U32Value{}}},
+				FieldsProvider: fieldsProvider,
+			},
+			&EnumValue{
+				Discriminant:   1,
+				Fields:         []Field{{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(1)}}},
+				FieldsProvider: fieldsProvider,
+			},
+			&ListValue{
+				Items:       []SingleValue{&U8Value{}, &U16Value{}},
+				ItemCreator: func() SingleValue { return &U8Value{} },
+			},
+			&OptionalValue{Value: &MultiValue{Items: []any{&BigIntValue{}, 42}}},
+			&VariadicValues{
+				Items:       []any{&U8Value{}, nil},
+				ItemCreator: func() any { return &U8Value{} },
+			},
+		})
+
+		require.Equal(t, []ValidationProblem{
+			{Path: "$[0].a", Message: "big integer is nil"},
+			{Path: "$[0].b", Message: "unsigned big integer is negative: -1"},
+			{Path: "$[0].c", Message: "value is nil"},
+			{Path: "$[0].3", Message: "public key (address) has invalid length: 1"},
+			{Path: "$[1].nonce", Message: "unexpected type: *abi.U32Value (declared: *abi.U64Value)"},
+			{Path: "$[2]", Message: "unexpected number of fields for variant 1: 1 (declared: 2)"},
+			{Path: "$[3][1]", Message: "unexpected type: *abi.U16Value (declared: *abi.U8Value)"},
+			{Path: "$[4].value[0]", Message: "big integer is nil"},
+			{Path: "$[4].value[1]", Message: "unsupported type: int"},
+			{Path: "$[5][1]", Message: "value is nil"},
+		}, problems)
+
+		require.Equal(t, "$[0].a: big integer is nil", problems[0].String())
+	})
+}
+
+func TestSerializer_SerializeWithValidation(t *testing.T) {
+	t.Run("should err before serialization", func(t *testing.T) {
+		serializer, err := NewSerializer(ArgsNewSerializer{
+			PartsSeparator:          "@",
+			ValidateBeforeSerialize: true,
+		})
+		require.NoError(t, err)
+
+		_, err = serializer.Serialize([]any{
+			&BigUIntValue{},
+			&ListValue{Items: []SingleValue{&BigUIntValue{Value: big.NewInt(-5)}}},
+		})
+
+		require.ErrorContains(t, err, "cannot serialize: invalid values: $[0]: big integer is nil; $[1][0]: unsigned big integer is negative: -5")
+	})
+
+	t.Run("should err on encoding, without validation", func(t *testing.T) {
+		serializer, err := NewSerializer(ArgsNewSerializer{
+			PartsSeparator: "@",
+		})
+		require.NoError(t, err)
+
+		_, err = serializer.Serialize([]any{&BigUIntValue{}})
+		require.ErrorContains(t, err, "cannot encode unsigned big integer: value is nil")
+
+		_, err = serializer.Serialize([]any{&BigUIntValue{Value: big.NewInt(-5)}})
+		require.ErrorContains(t, err, "cannot encode unsigned big integer: value is negative: -5")
+
+		_, err = serializer.Serialize([]any{&BigIntValue{}})
+		require.ErrorContains(t, err, "cannot encode big integer: value is nil")
+	})
+}
@@ -0,0 +1,155 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// VMQueryRequest is the body of a "vm-values/query" request. The arguments are hex-encoded.
+type VMQueryRequest struct {
+	ScAddress string   `json:"scAddress"`
+	FuncName  string   `json:"funcName"`
+	Caller    string   `json:"caller,omitempty"`
+	Value     string   `json:"value,omitempty"`
+	Args      []string `json:"args"`
+}
+
+// VMQueryResponse is the (inner) response of a "vm-values/query" request. The returned values are base64-encoded in the JSON.
+type VMQueryResponse struct {
+	ReturnData    [][]byte `json:"returnData"`
+	ReturnCode    string   `json:"returnCode"`
+	ReturnMessage string   `json:"returnMessage"`
+}
+
+// ArgsNewQueryCodec defines the arguments needed for a new query codec
+type ArgsNewQueryCodec struct {
+	// Abi is optional: if set, the arguments are validated against the inputs of the endpoint, and the outputs are decoded
+	Abi *Definition
+}
+
+// queryCodec encodes requests to (and decodes responses from) the "vm-values/query" route of the proxy (or the API)
+type queryCodec struct {
+	callDataBuilder      *callDataBuilder
+	contractResultParser *contractResultParser
+}
+
+// NewQueryCodec creates a new query codec
+func NewQueryCodec(args ArgsNewQueryCodec) (*queryCodec, error) {
+	callDataBuilder, err := NewCallDataBuilder(ArgsNewCallDataBuilder{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractResultParser, err := NewContractResultParser(ArgsNewContractResultParser{
+		Abi: args.Abi,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &queryCodec{
+		callDataBuilder:      callDataBuilder,
+		contractResultParser: contractResultParser,
+	}, nil
+}
+
+// EncodeRequest creates the request for querying the given function of the given contract (bech32 address)
+func (codec *queryCodec) EncodeRequest(contractAddress string, functionName string, args []any) (*VMQueryRequest, error) {
+	if contractAddress == "" {
+		return nil, errors.New("contract address must not be empty")
+	}
+
+	err := codec.callDataBuilder.checkCall(functionName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := codec.callDataBuilder.serializer.serializeToParts(args)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedArgs := make([]string, len(parts))
+
+	for i, part := range parts {
+		encodedArgs[i] = hex.EncodeToString(part)
+	}
+
+	return &VMQueryRequest{
+		ScAddress: contractAddress,
+		FuncName:  functionName,
+		Args:      encodedArgs,
+	}, nil
+}
+
+// DecodeResponse decodes the JSON response of querying the given function, either bare or wrapped (as returned by the proxy):
+// {"data": {"data": {...}}, "error": "...", "code": "..."}. On success, the returned values are decoded if the ABI is available.
+func (codec *queryCodec) DecodeResponse(functionName string, data []byte) (*ParsedContractResult, error) {
+	response, err := parseVMQueryResponse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	rawValues := response.ReturnData
+	if rawValues == nil {
+		rawValues = [][]byte{}
+	}
+
+	parsed := &ParsedContractResult{
+		ReturnCode:     ParseReturnCode(response.ReturnCode),
+		ReturnCodeText: response.ReturnCode,
+		ReturnMessage:  response.ReturnMessage,
+		RawValues:      rawValues,
+	}
+
+	if !parsed.IsSuccess() || codec.contractResultParser.abi == nil {
+		return parsed, nil
+	}
+
+	parsed.Values, err = codec.contractResultParser.decodeOutputs(functionName, rawValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func parseVMQueryResponse(data []byte) (*VMQueryResponse, error) {
+	var wrapped struct {
+		Data *struct {
+			Data *VMQueryResponse `json:"data"`
+		} `json:"data"`
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+
+	err := json.Unmarshal(data, &wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse query response, because of: %w", err)
+	}
+
+	if wrapped.Error != "" {
+		return nil, fmt.Errorf("query failed: %s (%s)", wrapped.Error, wrapped.Code)
+	}
+
+	if wrapped.Data != nil && wrapped.Data.Data != nil {
+		return wrapped.Data.Data, nil
+	}
+
+	response := &VMQueryResponse{}
+
+	err = json.Unmarshal(data, response)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse query response, because of: %w", err)
+	}
+
+	if response.ReturnCode == "" {
+		return nil, errors.New("cannot parse query response, because of: missing return code")
+	}
+
+	return response, nil
+}
@@ -0,0 +1,142 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCodec_EncodeRequest(t *testing.T) {
+	t.Run("without ABI", func(t *testing.T) {
+		codec, err := NewQueryCodec(ArgsNewQueryCodec{})
+		require.NoError(t, err)
+
+		request, err := codec.EncodeRequest("erd1contract", "add", []any{&BigUIntValue{Value: big.NewInt(7)}, &StringValue{Value: "abc"}, &U8Value{}})
+		require.NoError(t, err)
+		require.Equal(t, &VMQueryRequest{ScAddress: "erd1contract", FuncName: "add", Args: []string{"07", "616263", ""}}, request)
+
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		require.Equal(t, `{"scAddress":"erd1contract","funcName":"add","args":["07","616263",""]}`, string(body))
+
+		request, err = codec.EncodeRequest("erd1contract", "getSum", nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, request.Args)
+	})
+
+	t.Run("with ABI", func(t *testing.T) {
+		codec, err := NewQueryCodec(ArgsNewQueryCodec{Abi: createTestAbi()})
+		require.NoError(t, err)
+
+		_, err = codec.EncodeRequest("erd1contract", "add", []any{&U8Value{}})
+		require.ErrorContains(t, err, "bad arguments for endpoint 'add'")
+
+		_, err = codec.EncodeRequest("erd1contract", "missing", nil)
+		require.ErrorContains(t, err, "endpoint not found: missing")
+	})
+
+	t.Run("with bad input", func(t *testing.T) {
+		codec, err := NewQueryCodec(ArgsNewQueryCodec{})
+		require.NoError(t, err)
+
+		_, err = codec.EncodeRequest("", "getSum", nil)
+		require.ErrorContains(t, err, "contract address must not be empty")
+
+		_, err = codec.EncodeRequest("erd1contract", "", nil)
+		require.ErrorContains(t, err, "function name must not be empty")
+	})
+}
+
+func TestQueryCodec_DecodeResponse(t *testing.T) {
+	codec, err := NewQueryCodec(ArgsNewQueryCodec{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	t.Run("wrapped (proxy)", func(t *testing.T) {
+		parsed, err := codec.DecodeResponse("getSum", []byte(`{"data": {"data": {"returnData": ["Kg=="], "returnCode": "ok", "returnMessage": ""}}, "error": "", "code": "successful"}`))
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeOk, parsed.ReturnCode)
+		require.Equal(t, [][]byte{{0x2a}}, parsed.RawValues)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(42)}}}, parsed.Values)
+	})
+
+	t.Run("bare (API)", func(t *testing.T) {
+		parsed, err := codec.DecodeResponse("getSum", []byte(`{"returnData": [""], "returnCode": "ok"}`))
+		require.NoError(t, err)
+		require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: big.NewInt(0)}}}, parsed.Values)
+	})
+
+	t.Run("on failure", func(t *testing.T) {
+		parsed, err := codec.DecodeResponse("getSum", []byte(`{"returnData": null, "returnCode": "user error", "returnMessage": "not allowed"}`))
+		require.NoError(t, err)
+		require.Equal(t, ReturnCodeUserError, parsed.ReturnCode)
+		require.Equal(t, "not allowed", parsed.ReturnMessage)
+		require.Equal(t, [][]byte{}, parsed.RawValues)
+		require.Nil(t, parsed.Values)
+	})
+
+	t.Run("without ABI", func(t *testing.T) {
+		codec, err := NewQueryCodec(ArgsNewQueryCodec{})
+		require.NoError(t, err)
+
+		parsed, err := codec.DecodeResponse("getSum", []byte(`{"returnData": ["Kg==", "Kw=="], "returnCode": "ok"}`))
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{{0x2a}, {0x2b}}, parsed.RawValues)
+		require.Nil(t, parsed.Values)
+	})
+
+	t.Run("with bad response", func(t *testing.T) {
+		_, err := codec.DecodeResponse("getSum", []byte(`{"data": null, "error": "bad address", "code": "bad_request"}`))
+		require.ErrorContains(t, err, "query failed: bad address (bad_request)")
+
+		_, err = codec.DecodeResponse("getSum", []byte(`{`))
+		require.ErrorContains(t, err, "cannot parse query response")
+
+		_, err = codec.DecodeResponse("getSum", []byte(`{}`))
+		require.ErrorContains(t, err, "missing return code")
+
+		_, err = codec.DecodeResponse("getSum", []byte(`{"returnData": ["Kg==", "Kw=="], "returnCode": "ok"}`))
+		require.ErrorContains(t, err, "cannot decode outputs of endpoint 'getSum': too many parts")
+	})
+}
+
+func TestQueryCodec_WithServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/vm-values/query", r.URL.Path)
+
+		request := &VMQueryRequest{}
+		err := json.NewDecoder(r.Body).Decode(request)
+		require.NoError(t, err)
+		require.Equal(t, "getSum", request.FuncName)
+
+		_, _ = w.Write([]byte(`{"data": {"data": {"returnData": ["AQAAAAAAAAAA"], "returnCode": "ok", "returnMessage": ""}}, "error": "", "code": "successful"}`))
+	}))
+	defer server.Close()
+
+	codec, err := NewQueryCodec(ArgsNewQueryCodec{Abi: createTestAbi()})
+	require.NoError(t, err)
+
+	request, err := codec.EncodeRequest("erd1contract", "getSum", nil)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	response, err := http.Post(server.URL+"/vm-values/query", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	parsed, err := codec.DecodeResponse("getSum", responseBody)
+	require.NoError(t, err)
+
+	expectedSum, _ := big.NewInt(0).SetString("18446744073709551616", 10)
+	require.Equal(t, []NamedValue{{Name: "sum", Value: &BigUIntValue{Value: expectedSum}}}, parsed.Values)
+}
@@ -0,0 +1,195 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SkipChildren can be returned by the "Enter" callbacks of a Visitor, in order to skip the children of the value being visited.
+// In this case, the corresponding "Leave" callback isn't called either.
+var SkipChildren = errors.New("skip children")
+
+// Visitor holds callbacks to be called by Walk, for each kind of value within a value tree.
+// The path of each visited value follows the same conventions as the ones of ValueDifference (e.g. "$[0].amount").
+// Returning an error (other than SkipChildren) from any callback stops the walk.
+// Embed BaseVisitor to only implement the callbacks of interest.
+type Visitor interface {
+	EnterStruct(path string, value *StructValue) error
+	LeaveStruct(path string, value *StructValue) error
+	EnterEnum(path string, value *EnumValue) error
+	LeaveEnum(path string, value *EnumValue) error
+	EnterList(path string, value *ListValue) error
+	LeaveList(path string, value *ListValue) error
+	EnterOption(path string, value *OptionValue) error
+	LeaveOption(path string, value *OptionValue) error
+	EnterMultiValue(path string, value *MultiValue) error
+	LeaveMultiValue(path string, value *MultiValue) error
+	EnterVariadicValues(path string, value *VariadicValues) error
+	LeaveVariadicValues(path string, value *VariadicValues) error
+	EnterOptionalValue(path string, value *OptionalValue) error
+	LeaveOptionalValue(path string, value *OptionalValue) error
+	// VisitLeaf is called for single values which do not hold other values (e.g. integers, strings, addresses).
+	VisitLeaf(path string, value SingleValue) error
+}
+
+// BaseVisitor implements Visitor with no-op callbacks. It is meant to be embedded by actual visitors.
+type BaseVisitor struct {
+}
+
+// EnterStruct does nothing
+func (visitor *BaseVisitor) EnterStruct(_ string, _ *StructValue) error { return nil }
+
+// LeaveStruct does nothing
+func (visitor *BaseVisitor) LeaveStruct(_ string, _ *StructValue) error { return nil }
+
+// EnterEnum does nothing
+func (visitor *BaseVisitor) EnterEnum(_ string, _ *EnumValue) error { return nil }
+
+// LeaveEnum does nothing
+func (visitor *BaseVisitor) LeaveEnum(_ string, _ *EnumValue) error { return nil }
+
+// EnterList does nothing
+func (visitor *BaseVisitor) EnterList(_ string, _ *ListValue) error { return nil }
+
+// LeaveList does nothing
+func (visitor *BaseVisitor) LeaveList(_ string, _ *ListValue) error { return nil }
+
+// EnterOption does nothing
+func (visitor *BaseVisitor) EnterOption(_ string, _ *OptionValue) error { return nil }
+
+// LeaveOption does nothing
+func (visitor *BaseVisitor) LeaveOption(_ string, _ *OptionValue) error { return nil }
+
+// EnterMultiValue does nothing
+func (visitor *BaseVisitor) EnterMultiValue(_ string, _ *MultiValue) error { return nil }
+
+// LeaveMultiValue does nothing
+func (visitor *BaseVisitor) LeaveMultiValue(_ string, _ *MultiValue) error { return nil }
+
+// EnterVariadicValues does nothing
+func (visitor *BaseVisitor) EnterVariadicValues(_ string, _ *VariadicValues) error { return nil }
+
+// LeaveVariadicValues does nothing
+func (visitor *BaseVisitor) LeaveVariadicValues(_ string, _ *VariadicValues) error { return nil }
+
+// EnterOptionalValue does nothing
+func (visitor *BaseVisitor) EnterOptionalValue(_ string, _ *OptionalValue) error { return nil }
+
+// LeaveOptionalValue does nothing
+func (visitor *BaseVisitor) LeaveOptionalValue(_ string, _ *OptionalValue) error { return nil }
+
+// VisitLeaf does nothing
+func (visitor *BaseVisitor) VisitLeaf(_ string, _ SingleValue) error { return nil }
+
+// Walk traverses the given value tree (single value or multi-value), depth-first, calling the callbacks of the visitor.
+// Nil values (e.g. the value of a missing option) are not visited.
+func Walk(value any, visitor Visitor) error {
+	return walk("$", value, visitor)
+}
+
+// WalkValues is like Walk, but for a sequence of values (e.g. input values of a contract call)
+func WalkValues(values []any, visitor Visitor) error {
+	for i, value := range values {
+		err := walk(fmt.Sprintf("$[%d]", i), value, visitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walk(path string, value any, visitor Visitor) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case *StructValue:
+		return walkComposite(
+			func() error { return visitor.EnterStruct(path, value) },
+			func() error { return walkFields(path, value.Fields, visitor) },
+			func() error { return visitor.LeaveStruct(path, value) },
+		)
+	case *EnumValue:
+		return walkComposite(
+			func() error { return visitor.EnterEnum(path, value) },
+			func() error { return walkFields(path, value.Fields, visitor) },
+			func() error { return visitor.LeaveEnum(path, value) },
+		)
+	case *ListValue:
+		return walkComposite(
+			func() error { return visitor.EnterList(path, value) },
+			func() error { return walkItems(path, singleValuesAsAny(value.Items), visitor) },
+			func() error { return visitor.LeaveList(path, value) },
+		)
+	case *OptionValue:
+		return walkComposite(
+			func() error { return visitor.EnterOption(path, value) },
+			func() error { return walk(path+".value", singleValueAsAny(value.Value), visitor) },
+			func() error { return visitor.LeaveOption(path, value) },
+		)
+	case *MultiValue:
+		return walkComposite(
+			func() error { return visitor.EnterMultiValue(path, value) },
+			func() error { return walkItems(path, value.Items, visitor) },
+			func() error { return visitor.LeaveMultiValue(path, value) },
+		)
+	case *VariadicValues:
+		return walkComposite(
+			func() error { return visitor.EnterVariadicValues(path, value) },
+			func() error { return walkItems(path, value.Items, visitor) },
+			func() error { return visitor.LeaveVariadicValues(path, value) },
+		)
+	case *OptionalValue:
+		return walkComposite(
+			func() error { return visitor.EnterOptionalValue(path, value) },
+			func() error { return walk(path+".value", value.Value, visitor) },
+			func() error { return visitor.LeaveOptionalValue(path, value) },
+		)
+	case genericValue:
+		// Generic values are walked by means of their untyped counterparts (which share the items)
+		return walk(path, value.toUntyped(), visitor)
+	case SingleValue:
+		return visitor.VisitLeaf(path, value)
+	default:
+		return fmt.Errorf("cannot walk %s: unsupported type %T", path, value)
+	}
+}
+
+func walkComposite(enter func() error, walkChildren func() error, leave func() error) error {
+	err := enter()
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = walkChildren()
+	if err != nil {
+		return err
+	}
+
+	return leave()
+}
+
+func walkFields(path string, fields []Field, visitor Visitor) error {
+	for i, field := range fields {
+		err := walk(pathOfField(path, i, field.Name), singleValueAsAny(field.Value), visitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkItems(path string, items []any, visitor Visitor) error {
+	for i, item := range items {
+		err := walk(fmt.Sprintf("%s[%d]", path, i), item, visitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingVisitor records all callbacks
+type recordingVisitor struct {
+	events []string
+}
+
+func (visitor *recordingVisitor) record(event string, path string) error {
+	visitor.events = append(visitor.events, fmt.Sprintf("%s %s", event, path))
+	return nil
+}
+
+func (visitor *recordingVisitor) EnterStruct(path string, _ *StructValue) error {
+	return visitor.record("enter struct", path)
+}
+
+func (visitor *recordingVisitor) LeaveStruct(path string, _ *StructValue) error {
+	return visitor.record("leave struct", path)
+}
+
+func (visitor *recordingVisitor) EnterEnum(path string, _ *EnumValue) error {
+	return visitor.record("enter enum", path)
+}
+
+func (visitor *recordingVisitor) LeaveEnum(path string, _ *EnumValue) error {
+	return visitor.record("leave enum", path)
+}
+
+func (visitor *recordingVisitor) EnterList(path string, _ *ListValue) error {
+	return visitor.record("enter list", path)
+}
+
+func (visitor *recordingVisitor) LeaveList(path string, _ *ListValue) error {
+	return visitor.record("leave list", path)
+}
+
+func (visitor *recordingVisitor) EnterOption(path string, _ *OptionValue) error {
+	return visitor.record("enter option", path)
+}
+
+func (visitor *recordingVisitor) LeaveOption(path string, _ *OptionValue) error {
+	return visitor.record("leave option", path)
+}
+
+func (visitor *recordingVisitor) EnterMultiValue(path string, _ *MultiValue) error {
+	return visitor.record("enter multi", path)
+}
+
+func (visitor *recordingVisitor) LeaveMultiValue(path string, _ *MultiValue) error {
+	return visitor.record("leave multi", path)
+}
+
+func (visitor *recordingVisitor) EnterVariadicValues(path string, _ *VariadicValues) error {
+	return visitor.record("enter variadic", path)
+}
+
+func (visitor *recordingVisitor) LeaveVariadicValues(path string, _ *VariadicValues) error {
+	return visitor.record("leave variadic", path)
+}
+
+func (visitor *recordingVisitor) EnterOptionalValue(path string, _ *OptionalValue) error {
+	return visitor.record("enter optional", path)
+}
+
+func (visitor *recordingVisitor) LeaveOptionalValue(path string, _ *OptionalValue) error {
+	return visitor.record("leave optional", path)
+}
+
+func (visitor *recordingVisitor) VisitLeaf(path string, value SingleValue) error {
+	return visitor.record(fmt.Sprintf("leaf %T", value), path)
+}
+
+// amountsVisitor sums all unsigned big integers named "amount"
+type amountsVisitor struct {
+	BaseVisitor
+	total *big.Int
+}
+
+func (visitor *amountsVisitor) VisitLeaf(path string, value SingleValue) error {
+	amount, ok := value.(*BigUIntValue)
+	if ok && strings.HasSuffix(path, ".amount") {
+		visitor.total.Add(visitor.total, amount.Value)
+	}
+
+	return nil
+}
+
+// skippingVisitor skips the children of lists, and fails on enums
+type skippingVisitor struct {
+	BaseVisitor
+	numLeaves int
+}
+
+func (visitor *skippingVisitor) EnterList(_ string, _ *ListValue) error {
+	return SkipChildren
+}
+
+func (visitor *skippingVisitor) EnterEnum(path string, _ *EnumValue) error {
+	return fmt.Errorf("unexpected enum at %s", path)
+}
+
+func (visitor *skippingVisitor) VisitLeaf(_ string, _ SingleValue) error {
+	visitor.numLeaves++
+	return nil
+}
+
+func TestWalk(t *testing.T) {
+	createPayment := func(amount int64) *StructValue {
+		return &StructValue{
+			Fields: []Field{
+				{Name: "token_identifier", Value: &StringValue{Value: "TEST-abcdef"}},
+				{Name: "amount", Value: &BigUIntValue{Value: big.NewInt(amount)}},
+			},
+		}
+	}
+
+	t.Run("should visit all values, in order", func(t *testing.T) {
+		visitor := &recordingVisitor{}
+
+		err := WalkValues([]any{
+			&MultiValue{
+				Items: []any{
+					&U8Value{},
+					&OptionalValue{Value: &OptionValue{Value: &BoolValue{}}},
+				},
+			},
+			&VariadicValues{
+				Items: []any{
+					&EnumValue{Fields: []Field{{Value: &ListValue{Items: []SingleValue{&AddressValue{}}}}}},
+				},
+			},
+			&OptionValue{},
+		}, visitor)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"enter multi $[0]",
+			"leaf *abi.U8Value $[0][0]",
+			"enter optional $[0][1]",
+			"enter option $[0][1].value",
+			"leaf *abi.BoolValue $[0][1].value.value",
+			"leave option $[0][1].value",
+			"leave optional $[0][1]",
+			"leave multi $[0]",
+			"enter variadic $[1]",
+			"enter enum $[1][0]",
+			"enter list $[1][0].0",
+			"leaf *abi.AddressValue $[1][0].0[0]",
+			"leave list $[1][0].0",
+			"leave enum $[1][0]",
+			"leave variadic $[1]",
+			"enter option $[2]",
+			"leave option $[2]",
+		}, visitor.events)
+	})
+
+	t.Run("should sum amounts (metrics extraction)", func(t *testing.T) {
+		visitor := &amountsVisitor{total: big.NewInt(0)}
+
+		err := Walk(&ListValue{
+			Items: []SingleValue{createPayment(100), createPayment(200), createPayment(300)},
+		}, visitor)
+
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(600), visitor.total)
+	})
+
+	t.Run("should skip children", func(t *testing.T) {
+		visitor := &skippingVisitor{}
+
+		err := Walk(&StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &U8Value{}},
+				{Name: "b", Value: &ListValue{Items: []SingleValue{&U8Value{}, &U8Value{}}}},
+				{Name: "c", Value: &U8Value{}},
+			},
+		}, visitor)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, visitor.numLeaves)
+	})
+
+	t.Run("should stop on error", func(t *testing.T) {
+		visitor := &skippingVisitor{}
+
+		err := Walk(&StructValue{
+			Fields: []Field{
+				{Name: "a", Value: &EnumValue{}},
+				{Name: "b", Value: &U8Value{}},
+			},
+		}, visitor)
+
+		require.ErrorContains(t, err, "unexpected enum at $.a")
+		require.Equal(t, 0, visitor.numLeaves)
+
+		err = Walk(42, visitor)
+		require.ErrorContains(t, err, "cannot walk $: unsupported type int")
+		require.False(t, errors.Is(err, SkipChildren))
+	})
+}
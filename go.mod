@@ -5,10 +5,12 @@ go 1.20
 require (
 	github.com/multiversx/mx-components-big-int v1.0.0
 	github.com/stretchr/testify v1.7.1
+	golang.org/x/crypto v0.14.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )